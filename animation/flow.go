@@ -0,0 +1,53 @@
+package animation
+
+import (
+	"render2go/core"
+	"time"
+)
+
+// Flowing 随时间流动的对象，如向量场和流线上的粒子
+type Flowing interface {
+	core.Mobject
+	FlowTime() float64
+	SetFlowTime(t float64)
+}
+
+// FlowAnimation 流动动画：对象的流动时间随动画匀速增加，不使用缓动。
+// 从动画第一次更新时对象的流动时间继续，连续的流动动画首尾相接
+type FlowAnimation struct {
+	*BaseAnimation
+	flowing Flowing
+	start   float64
+	started bool
+}
+
+// NewFlowAnimation 创建流动动画
+func NewFlowAnimation(target Flowing, duration time.Duration) *FlowAnimation {
+	return &FlowAnimation{
+		BaseAnimation: NewBaseAnimation(target, duration),
+		flowing:       target,
+	}
+}
+
+func (a *FlowAnimation) Update(progress float64) {
+	if progress >= 1.0 {
+		progress = 1.0
+		a.finished = true
+	}
+	if !a.started {
+		a.start = a.flowing.FlowTime()
+		a.started = true
+	}
+
+	a.flowing.SetFlowTime(a.start + progress*a.duration.Seconds())
+	a.progress = progress
+}
+
+// Reset 恢复动画开始时的流动时间。起始值只在第一次更新时读取一次，
+// 逐帧重置再更新时不会把上一帧的进度累加进来
+func (a *FlowAnimation) Reset() {
+	a.BaseAnimation.Reset()
+	if a.started {
+		a.flowing.SetFlowTime(a.start)
+	}
+}
@@ -0,0 +1,69 @@
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"render2go/core"
+)
+
+// flowingStub 只记录流动时间的流动对象
+type flowingStub struct {
+	*core.BaseMobject
+	flowTime float64
+}
+
+func (f *flowingStub) FlowTime() float64     { return f.flowTime }
+func (f *flowingStub) SetFlowTime(t float64) { f.flowTime = t }
+
+// playFrames 按渲染时间线的方式逐帧播放依次排列的动画：
+// 每帧先重置所有动画，再更新已经开始的动画，已结束的动画停在最终状态
+func playFrames(anims []Animation, fps int, seconds float64) {
+	frames := int(seconds*float64(fps)) + 1
+	for frame := 0; frame < frames; frame++ {
+		now := float64(frame) / float64(fps)
+		for _, anim := range anims {
+			anim.Reset()
+		}
+		start := 0.0
+		for _, anim := range anims {
+			duration := anim.GetDuration().Seconds()
+			if now >= start {
+				anim.Update(math.Min(1, (now-start)/duration))
+			}
+			start += duration
+		}
+	}
+}
+
+func TestFlowAnimationFrames(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     float64
+		durations []float64
+		seconds   float64
+		want      float64
+	}{
+		{"single flow", 0, []float64{4}, 4, 4},
+		{"continues from the current flow time", 2.5, []float64{4}, 4, 6.5},
+		{"halfway", 0, []float64{4}, 2, 2},
+		{"finished flow holds its end", 0, []float64{1}, 3, 1},
+		{"consecutive flows chain", 0, []float64{2, 3}, 5, 5},
+		{"second flow halfway", 1, []float64{2, 2}, 3, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &flowingStub{BaseMobject: core.NewBaseMobject(), flowTime: tt.start}
+			var anims []Animation
+			for _, d := range tt.durations {
+				anims = append(anims, NewFlowAnimation(target, time.Duration(d*float64(time.Second))))
+			}
+			playFrames(anims, 30, tt.seconds)
+			if math.Abs(target.flowTime-tt.want) > 1e-9 {
+				t.Errorf("flow time = %g, want %g", target.flowTime, tt.want)
+			}
+		})
+	}
+}
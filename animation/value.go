@@ -0,0 +1,52 @@
+package animation
+
+import (
+	"render2go/core"
+	"time"
+)
+
+// ValueAnimation 数值属性动画：通过读写函数把对象的某个数值属性（如黎曼和的区间数）
+// 从动画开始时的值平滑地变化到目标值，对象按新的值重新生成
+type ValueAnimation struct {
+	*BaseAnimation
+	get     func() float64
+	set     func(float64)
+	start   float64
+	end     float64
+	started bool
+}
+
+// NewValueAnimation 创建数值属性动画，get 和 set 读写要改变的属性
+func NewValueAnimation(target core.Mobject, get func() float64, set func(float64), endValue float64, duration time.Duration) *ValueAnimation {
+	return &ValueAnimation{
+		BaseAnimation: NewBaseAnimation(target, duration),
+		get:           get,
+		set:           set,
+		end:           endValue,
+	}
+}
+
+func (a *ValueAnimation) Update(progress float64) {
+	if progress >= 1.0 {
+		progress = 1.0
+		a.finished = true
+	}
+	// 起始值在动画真正开始时读取，使同一属性的连续动画首尾相接
+	if !a.started {
+		a.start = a.get()
+		a.started = true
+	}
+
+	interpolator := GetInterpolator(a.interpolation)
+	a.set(interpolator.InterpolateFloat(a.start, a.end, a.easingFunc(progress)))
+	a.progress = progress
+}
+
+// Reset 恢复动画开始时的属性值。起始值只在第一次更新时读取一次，
+// 逐帧重置再更新时插值的起点保持不变
+func (a *ValueAnimation) Reset() {
+	a.BaseAnimation.Reset()
+	if a.started {
+		a.set(a.start)
+	}
+}
@@ -0,0 +1,47 @@
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+func TestValueAnimationFrames(t *testing.T) {
+	// 默认缓动和平滑插值下 progress 处的值
+	at := func(from, to, progress float64) float64 {
+		return GetInterpolator(Smooth).InterpolateFloat(from, to, gmMath.SmoothStep(progress))
+	}
+	tests := []struct {
+		name    string
+		start   float64
+		targets []float64
+		seconds float64
+		want    float64
+	}{
+		{"finished", 8, []float64{64}, 2, 64},
+		{"70 percent", 8, []float64{64}, 1.4, at(8, 64, 0.7)},
+		{"halfway", 8, []float64{64}, 1, at(8, 64, 0.5)},
+		{"finished animation holds its end", 8, []float64{64}, 5, 64},
+		{"consecutive animations chain", 8, []float64{64, 4}, 3, at(64, 4, 0.5)},
+		{"decreasing", 10, []float64{0}, 4, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.start
+			get := func() float64 { return value }
+			set := func(v float64) { value = v }
+			var anims []Animation
+			for _, target := range tt.targets {
+				anims = append(anims, NewValueAnimation(core.NewBaseMobject(), get, set, target, 2*time.Second))
+			}
+			playFrames(anims, 10, tt.seconds)
+			if math.Abs(value-tt.want) > 1e-9 {
+				t.Errorf("value = %g, want %g", value, tt.want)
+			}
+		})
+	}
+}
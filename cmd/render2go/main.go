@@ -1,10 +1,16 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"render2go/config"
+	"render2go/internal/defaults"
+	"render2go/internal/i18n"
+	"render2go/internal/logging"
 	"render2go/interpreter"
 )
 
@@ -17,14 +23,30 @@ func main() {
 		help        = flag.Bool("help", false, "Show help information")
 		version     = flag.Bool("version", false, "Show version information")
 		clean       = flag.Bool("clean", false, "Clean output directory")
+		quiet       = flag.Bool("quiet", false, "Only log warnings and errors")
+		verbose     = flag.Bool("verbose", false, "Log debug details, including per-frame progress")
+		logFormat   = flag.String("log-format", logging.FormatText, "Log format: text or json")
+		lang        = flag.String("lang", "", "Message language: zh-CN or en (default: from LANG)")
+		configPath  = flag.String("config", "", "Config file (default: render2go.json/.toml found from the script directory upwards)")
 	)
 
+	// 先按环境变量确定语言，保证参数解析出错时的用法说明也已本地化
+	i18n.SetLocale(i18n.DetectLocale())
+	flag.Usage = printUsage
 	flag.Parse()
 
+	if *lang != "" {
+		locale, err := i18n.ParseLocale(*lang)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+			os.Exit(2)
+		}
+		i18n.SetLocale(locale)
+	}
+
 	// 显示版本信息
 	if *version {
-		fmt.Println("Render2Go Script Interpreter v1.0.0")
-		fmt.Println("A powerful animation scripting language")
+		fmt.Println(i18n.T("cli.version"))
 		return
 	}
 
@@ -34,54 +56,46 @@ func main() {
 		return
 	}
 
+	// 日志统一写到 stderr，保持 stdout 干净
+	logger, err := logging.New(os.Stderr, logging.Options{
+		Quiet:   *quiet,
+		Verbose: *verbose || *debug,
+		Format:  *logFormat,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
+
 	// 清理输出目录
 	if *clean {
-		cleanOutput()
+		applyConfig(logger, *configPath, "")
+		cleanOutput(logger)
 		return
 	}
 
 	// 创建解释器
 	interp := interpreter.NewInterpreter(*debug)
+	interp.SetLogger(logger)
 
 	// 交互式模式
 	if *interactive {
+		applyConfig(logger, *configPath, "")
 		interp.RunInteractive()
 		return
 	}
 
 	// 执行文件
 	if *file != "" {
-		if !fileExists(*file) {
-			fmt.Printf("❌ Error: File '%s' does not exist\n", *file)
-			os.Exit(1)
-		}
-
-		fmt.Printf("🎬 Executing script: %s\n", *file)
-		err := interp.RunFile(*file)
-		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("✅ Script execution completed successfully!")
+		runScript(interp, logger, *configPath, *file)
 		return
 	}
 
 	// 检查是否有非标志参数（直接的文件名）
 	args := flag.Args()
 	if len(args) > 0 {
-		filename := args[0]
-		if !fileExists(filename) {
-			fmt.Printf("❌ Error: File '%s' does not exist\n", filename)
-			os.Exit(1)
-		}
-
-		fmt.Printf("🎬 Executing script: %s\n", filename)
-		err := interp.RunFile(filename)
-		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("✅ Script execution completed successfully!")
+		runScript(interp, logger, *configPath, args[0])
 		return
 	}
 
@@ -89,85 +103,79 @@ func main() {
 	defaultFiles := []string{"main.r2g", "script.r2g", "animation.r2g"}
 	for _, filename := range defaultFiles {
 		if fileExists(filename) {
-			fmt.Printf("🎬 Found and executing: %s\n", filename)
-			err := interp.RunFile(filename)
-			if err != nil {
-				fmt.Printf("❌ Error: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println("✅ Script execution completed successfully!")
+			logger.Info(i18n.T("log.default_script"), "file", filename)
+			runScript(interp, logger, *configPath, filename)
 			return
 		}
 	}
 
 	// 没有找到脚本文件，启动交互模式
-	fmt.Println("No script file specified. Starting interactive mode...")
-	fmt.Println("Use 'render2go --help' for usage information")
-	fmt.Println()
+	fmt.Println(i18n.T("cli.no_script"))
+	applyConfig(logger, *configPath, "")
 	interp.RunInteractive()
 }
 
+// runScript 执行脚本文件，失败时以非零状态退出
+func runScript(interp *interpreter.Interpreter, logger *slog.Logger, configPath, filename string) {
+	if !fileExists(filename) {
+		logger.Error(i18n.T("log.file_not_found"), "file", filename)
+		os.Exit(1)
+	}
+
+	applyConfig(logger, configPath, filename)
+
+	logger.Info(i18n.T("log.script_executing"), "file", filename)
+	if err := interp.RunFile(filename); err != nil {
+		logger.Error(i18n.T("log.script_failed"), errorAttrs(filename, err)...)
+		os.Exit(1)
+	}
+	logger.Info(i18n.T("log.script_done"), "file", filename)
+}
+
+// applyConfig 加载配置文件并设为运行时默认值。configPath 为空时从脚本所在目录
+// （scriptPath 为空时为当前目录）逐级向上查找，配置无效时以非零状态退出
+func applyConfig(logger *slog.Logger, configPath, scriptPath string) {
+	var cfg *config.Config
+	var err error
+	if configPath != "" {
+		cfg, err = config.Load(configPath)
+	} else {
+		cfg, err = config.LoadForScript(scriptPath)
+	}
+	if err == nil {
+		err = cfg.Apply()
+	}
+	if err != nil {
+		logger.Error(i18n.T("log.config_invalid"), "error", err)
+		os.Exit(2)
+	}
+
+	if cfg != nil {
+		logger.Info(i18n.T("log.config_loaded"), "path", cfg.Path)
+	}
+}
+
+// errorAttrs 从结构化错误中提取位置和错误代码作为日志属性
+func errorAttrs(filename string, err error) []any {
+	attrs := []any{"file", filename, "error", err}
+
+	var parseErr *interpreter.ParseError
+	var runtimeErr *interpreter.RuntimeError
+	var unknownErr *interpreter.UnknownObjectError
+	switch {
+	case errors.As(err, &parseErr):
+		attrs = append(attrs, "line", parseErr.Line, "column", parseErr.Column, "code", parseErr.Code)
+	case errors.As(err, &unknownErr):
+		attrs = append(attrs, "line", unknownErr.Line, "column", unknownErr.Column, "code", unknownErr.Code)
+	case errors.As(err, &runtimeErr):
+		attrs = append(attrs, "line", runtimeErr.Line, "column", runtimeErr.Column, "code", runtimeErr.Code)
+	}
+	return attrs
+}
+
 // printUsage 打印使用说明
 func printUsage() {
-	fmt.Println(`🎬 Render2Go Script Interpreter
-
-USAGE:
-    render2go [OPTIONS] [FILE]
-
-OPTIONS:
-    -file <file>        Execute the specified script file
-    -i                  Run in interactive mode
-    -debug              Enable debug mode (shows tokens and AST)
-    -clean              Clean output directory (remove all generated files)
-    -help               Show this help message
-    -version            Show version information
-
-FILE FORMATS:
-    .r2g                Render2Go Animation script files
-
-EXAMPLES:
-    render2go script.r2g              # Execute script.r2g
-    render2go -file animation.r2g     # Execute animation.r2g
-    render2go -i                      # Start interactive mode
-    render2go -debug script.r2g       # Execute with debug output
-    render2go -clean                  # Clean output directory
-
-SCRIPT LANGUAGE:
-    The Render2Go scripting language supports:
-    
-    Scene Management:
-        scene 800 600 "my_project"
-    
-    Object Creation:
-        create circle c1 50 (400, 300)
-        create rectangle r1 100 80 (200, 200)
-        create line l1 (0, 0) (100, 100)
-        create text t1 "Hello World" 24 (400, 100)
-    
-    Property Setting:
-        set c1.color = #576DA2
-        set c1.position = (500, 400)
-        set c1.opacity = 0.8
-    
-    Rendering:
-        render
-        save "my_frame.png"
-    
-    Control Flow:
-        loop 10 {
-            render
-            save "frame.png"
-        }
-
-DEFAULT BEHAVIOR:
-    If no file is specified, render2go will look for these files in order:
-    - main.r2g
-    - script.r2g
-    - animation.r2g
-    
-    If none are found, interactive mode will start.
-
-For more information, visit: https://github.com/render2go/render2go`)
+	fmt.Println(i18n.T("cli.usage"))
 }
 
 // fileExists 检查文件是否存在
@@ -185,28 +193,28 @@ func getFileExtension(filename string) string {
 }
 
 // cleanOutput 清理输出目录
-func cleanOutput() {
-	outputDir := "output"
+func cleanOutput(logger *slog.Logger) {
+	outputDir := defaults.CurrentRuntime().OutputRoot
 
-	// 检查output目录是否存在
+	// 检查输出目录是否存在
 	if _, err := os.Stat(outputDir); os.IsNotExist(err) {
-		fmt.Println("🧹 Output directory does not exist, nothing to clean.")
+		logger.Info(i18n.T("log.output_missing"), "dir", outputDir)
 		return
 	}
 
 	// 获取output目录下的所有内容
 	entries, err := os.ReadDir(outputDir)
 	if err != nil {
-		fmt.Printf("❌ Error reading output directory: %v\n", err)
+		logger.Error(i18n.T("log.output_read_failed"), "dir", outputDir, "error", err)
 		return
 	}
 
 	if len(entries) == 0 {
-		fmt.Println("🧹 Output directory is already empty.")
+		logger.Info(i18n.T("log.output_empty"), "dir", outputDir)
 		return
 	}
 
-	fmt.Printf("🧹 Cleaning output directory...\n")
+	logger.Info(i18n.T("log.output_cleaning"), "dir", outputDir)
 
 	deletedCount := 0
 	errorCount := 0
@@ -216,18 +224,18 @@ func cleanOutput() {
 		path := filepath.Join(outputDir, entry.Name())
 		err := os.RemoveAll(path)
 		if err != nil {
-			fmt.Printf("❌ Failed to remove '%s': %v\n", path, err)
+			logger.Error(i18n.T("log.remove_failed"), "path", path, "error", err)
 			errorCount++
 		} else {
-			fmt.Printf("   🗑️  Removed: %s\n", entry.Name())
+			logger.Debug(i18n.T("log.removed"), "path", path)
 			deletedCount++
 		}
 	}
 
 	// 显示清理结果
 	if errorCount == 0 {
-		fmt.Printf("✅ Successfully cleaned output directory! Removed %d items.\n", deletedCount)
+		logger.Info(i18n.T("log.output_cleaned"), "removed", deletedCount)
 	} else {
-		fmt.Printf("⚠️  Partially cleaned output directory. Removed %d items, %d errors.\n", deletedCount, errorCount)
+		logger.Warn(i18n.T("log.output_partial_cleaned"), "removed", deletedCount, "errors", errorCount)
 	}
 }
@@ -0,0 +1,103 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Colormap 连续色图：在 [0, 1] 上按等距色标线性插值，用于等高线、向量场等按数值着色
+type Colormap struct {
+	Name  string
+	Stops []color.RGBA
+}
+
+// 预定义色图
+var (
+	// Viridis 蓝紫到黄绿，亮度单调，适合大多数数值
+	Viridis = Colormap{Name: "viridis", Stops: hexStops("#440154", "#3B528B", "#21918C", "#5EC962", "#FDE725")}
+	// Plasma 深蓝到黄色
+	Plasma = Colormap{Name: "plasma", Stops: hexStops("#0D0887", "#7E03A8", "#CC4778", "#F89540", "#F0F921")}
+	// Inferno 黑色经红色到浅黄，适合浅色背景上的高低对比
+	Inferno = Colormap{Name: "inferno", Stops: hexStops("#000004", "#57106E", "#BC3754", "#F98E09", "#FCFFA4")}
+	// Coolwarm 蓝到红，中间为浅灰，适合正负对称的数值
+	Coolwarm = Colormap{Name: "coolwarm", Stops: hexStops("#3B4CC0", "#8DB0FE", "#DDDDDD", "#F49A7B", "#B40426")}
+	// Rainbow 彩虹色
+	Rainbow = Colormap{Name: "rainbow", Stops: hexStops("#6E40AA", "#2D8DE0", "#1AC7C2", "#52F667", "#DEDD32", "#FE4B83")}
+	// Grayscale 黑到白
+	Grayscale = Colormap{Name: "grayscale", Stops: hexStops("#000000", "#FFFFFF")}
+)
+
+// Colormaps 可按名称选择的色图
+var Colormaps = map[string]Colormap{
+	"viridis":   Viridis,
+	"plasma":    Plasma,
+	"inferno":   Inferno,
+	"coolwarm":  Coolwarm,
+	"rainbow":   Rainbow,
+	"grayscale": Grayscale,
+}
+
+func hexStops(hexes ...string) []color.RGBA {
+	stops := make([]color.RGBA, len(hexes))
+	for i, hex := range hexes {
+		stops[i] = HexToRGBA(hex)
+	}
+	return stops
+}
+
+// ColormapByName 根据名称查找色图，不区分大小写，gray 和 grey 是 grayscale 的别名
+func ColormapByName(name string) (Colormap, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "gray" || key == "grey" || key == "greyscale" {
+		key = "grayscale"
+	}
+	colormap, ok := Colormaps[key]
+	return colormap, ok
+}
+
+// ColormapNames 返回所有色图名称，按字母排序
+func ColormapNames() []string {
+	names := make([]string, 0, len(Colormaps))
+	for name := range Colormaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// At 返回 t 处的颜色，t 被限制在 [0, 1] 内
+func (m Colormap) At(t float64) color.RGBA {
+	if len(m.Stops) == 0 {
+		return Black
+	}
+	if math.IsNaN(t) || t <= 0 || len(m.Stops) == 1 {
+		return m.Stops[0]
+	}
+	if t >= 1 {
+		return m.Stops[len(m.Stops)-1]
+	}
+
+	pos := t * float64(len(m.Stops)-1)
+	i := int(pos)
+	f := pos - float64(i)
+	a, b := m.Stops[i], m.Stops[i+1]
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x)*(1-f) + float64(y)*f))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
+
+// Sample 在 [0, 1] 上均匀取 n 个颜色
+func (m Colormap) Sample(n int) []color.RGBA {
+	samples := make([]color.RGBA, n)
+	for i := range samples {
+		if n == 1 {
+			samples[i] = m.At(0.5)
+			continue
+		}
+		samples[i] = m.At(float64(i) / float64(n-1))
+	}
+	return samples
+}
@@ -1,6 +1,9 @@
 package colors
 
-import "image/color"
+import (
+	"image/color"
+	"strings"
+)
 
 // ColorScheme 配色方案
 type ColorScheme struct {
@@ -150,5 +153,32 @@ func Float64ToRGBA(r, g, b, a float64) color.RGBA {
 	}
 }
 
+// Classic 经典配色方案：白色背景、黑色图形，与未设置主题时的外观一致
+var Classic = ColorScheme{
+	Name: "Classic",
+	Colors: []color.RGBA{
+		HexToRGBA("#FFFFFF"), // 白色 - 主背景
+		HexToRGBA("#000000"), // 黑色 - 主要元素
+		HexToRGBA("#2196F3"), // 蓝色 - 强调色
+		HexToRGBA("#9E9E9E"), // 灰色 - 辅助色
+		HexToRGBA("#FAFAFA"), // 浅灰 - 深背景
+		HexToRGBA("#000000"), // 黑色 - 文字色
+	},
+}
+
 // 默认配色方案设置为专业蓝
 var DefaultColorScheme = ProfessionalBlue
+
+// Schemes 可按名称选择的配色方案（用于配置文件中的 theme）
+var Schemes = map[string]ColorScheme{
+	"professional-blue": ProfessionalBlue,
+	"classic":           Classic,
+}
+
+// SchemeByName 根据名称查找配色方案，名称不区分大小写，空格和下划线视为连字符
+func SchemeByName(name string) (ColorScheme, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.NewReplacer(" ", "-", "_", "-").Replace(key)
+	scheme, ok := Schemes[key]
+	return scheme, ok
+}
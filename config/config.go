@@ -0,0 +1,227 @@
+// Package config 读取项目级配置文件 render2go.json / render2go.toml。
+//
+// 配置文件从脚本所在目录开始逐级向上查找，找到的第一个生效。示例（JSON）:
+//
+//	{
+//	  "width": 1280,
+//	  "height": 720,
+//	  "fps": 30,
+//	  "background": "#FFFFFF",
+//	  "output_root": "build/output",
+//	  "font_paths": ["fonts", "/usr/share/fonts/truetype/noto"],
+//	  "ffmpeg_path": "/opt/ffmpeg/bin/ffmpeg",
+//	  "theme": "professional-blue",
+//	  "text_max_size": 96
+//	}
+//
+// TOML 格式使用相同的键名。相对路径以配置文件所在目录为基准。
+package config
+
+import (
+	"encoding/json"
+	"image/color"
+	"os"
+	"path/filepath"
+	"render2go/colors"
+	"render2go/internal/defaults"
+	"render2go/internal/i18n"
+	"strings"
+)
+
+// 配置文件名，同一目录下两者都存在时优先使用 JSON
+const (
+	FileNameJSON = "render2go.json"
+	FileNameTOML = "render2go.toml"
+)
+
+// Config 项目级默认配置，零值字段表示使用内置默认值
+type Config struct {
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	FPS        int      `json:"fps"`
+	Background string   `json:"background"`  // "#RRGGBB" 或颜色名，为空时使用主题背景色
+	OutputRoot string   `json:"output_root"` // 输出根目录
+	FontPaths  []string `json:"font_paths"`  // 字体文件或目录
+	FFmpegPath string   `json:"ffmpeg_path"` // FFmpeg 可执行文件
+	Theme      string   `json:"theme"`       // 配色主题: professional-blue, classic
+
+	// 文本渲染字号范围，默认 8 到 36，设为 -1 表示不限制
+	TextMinSize float64 `json:"text_min_size"`
+	TextMaxSize float64 `json:"text_max_size"`
+
+	// Path 配置文件路径，未从文件加载时为空
+	Path string `json:"-"`
+}
+
+// Find 从 dir 开始逐级向上查找配置文件，找不到时返回空字符串
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range []string{FileNameJSON, FileNameTOML} {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load 读取并校验配置文件，格式由扩展名决定
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, i18n.Errorf("config.file_error", path, err)
+		}
+	case ".toml":
+		if err := decodeTOML(string(data), cfg); err != nil {
+			return nil, i18n.Errorf("config.file_error", path, err)
+		}
+	default:
+		return nil, i18n.Errorf("config.unsupported_format", path)
+	}
+
+	cfg.Path = path
+	cfg.resolvePaths(filepath.Dir(path))
+
+	if err := cfg.Validate(); err != nil {
+		return nil, i18n.Errorf("config.file_error", path, err)
+	}
+	return cfg, nil
+}
+
+// LoadForScript 查找并加载脚本对应的配置文件，没有配置文件时返回 nil
+func LoadForScript(scriptPath string) (*Config, error) {
+	path, err := Find(filepath.Dir(scriptPath))
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// resolvePaths 将相对路径转换为以 base 为基准的路径
+func (c *Config) resolvePaths(base string) {
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(base, p)
+	}
+
+	c.OutputRoot = resolve(c.OutputRoot)
+	for i, p := range c.FontPaths {
+		c.FontPaths[i] = resolve(p)
+	}
+	// 只有写成路径形式的 FFmpeg 才相对配置目录解析，单独的命令名仍在 PATH 中查找
+	if strings.ContainsAny(c.FFmpegPath, `/\`) {
+		c.FFmpegPath = resolve(c.FFmpegPath)
+	}
+}
+
+// Validate 检查配置取值
+func (c *Config) Validate() error {
+	if c.Width < 0 || c.Height < 0 {
+		return i18n.Errorf("config.negative_resolution", c.Width, c.Height)
+	}
+	if (c.Width == 0) != (c.Height == 0) {
+		return i18n.Errorf("config.resolution_pair")
+	}
+	if c.FPS < 0 {
+		return i18n.Errorf("config.negative_fps", c.FPS)
+	}
+	if c.Background != "" {
+		if _, err := parseColor(c.Background); err != nil {
+			return err
+		}
+	}
+	if c.Theme != "" {
+		if _, ok := colors.SchemeByName(c.Theme); !ok {
+			return i18n.Errorf("config.unknown_theme", c.Theme)
+		}
+	}
+	if c.TextMinSize > 0 && c.TextMaxSize > 0 && c.TextMinSize > c.TextMaxSize {
+		return i18n.Errorf("config.text_size_range", c.TextMinSize, c.TextMaxSize)
+	}
+	return nil
+}
+
+// Runtime 将配置与内置默认值合并为运行时默认值；c 为 nil 时返回内置默认值
+func (c *Config) Runtime() (defaults.Runtime, error) {
+	rt := defaults.BuiltinRuntime()
+	if c == nil {
+		return rt, nil
+	}
+	if err := c.Validate(); err != nil {
+		return rt, err
+	}
+
+	if c.Width > 0 && c.Height > 0 {
+		rt.Width, rt.Height = c.Width, c.Height
+	}
+	if c.FPS > 0 {
+		rt.FPS = c.FPS
+	}
+	if c.Theme != "" {
+		scheme, _ := colors.SchemeByName(c.Theme)
+		rt.Theme = c.Theme
+		rt.Background = scheme.GetBackgroundColor()
+	}
+	if c.Background != "" {
+		rt.Background, _ = parseColor(c.Background)
+	}
+	if c.OutputRoot != "" {
+		rt.OutputRoot = c.OutputRoot
+	}
+	rt.FontPaths = append(rt.FontPaths, c.FontPaths...)
+	if c.FFmpegPath != "" {
+		rt.FFmpegPath = c.FFmpegPath
+	}
+	if c.TextMinSize != 0 {
+		rt.TextMinSize = c.TextMinSize
+	}
+	if c.TextMaxSize != 0 {
+		rt.TextMaxSize = c.TextMaxSize
+	}
+	return rt, nil
+}
+
+// Apply 将配置设为全局运行时默认值；c 为 nil 时恢复内置默认值
+func (c *Config) Apply() error {
+	rt, err := c.Runtime()
+	if err != nil {
+		return err
+	}
+	defaults.SetRuntime(rt)
+	return nil
+}
+
+// parseColor 解析 "#RRGGBB" 或预定义颜色名
+func parseColor(s string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "#") {
+		if len(s) != 7 || strings.Trim(s[1:], "0123456789abcdefABCDEF") != "" {
+			return color.RGBA{}, i18n.Errorf("config.invalid_color", s)
+		}
+		return colors.HexToRGBA(s), nil
+	}
+	if c, ok := defaults.GetColorByName(strings.ToLower(s)); ok {
+		return c, nil
+	}
+	return color.RGBA{}, i18n.Errorf("config.unknown_color", s)
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"regexp"
+	"render2go/internal/i18n"
+	"strconv"
+	"strings"
+)
+
+// decodeTOML 解析配置文件用到的 TOML 子集：顶层的 key = value，
+// 值可以是字符串（"..." 或 '...'）、整数、浮点数以及字符串数组（可跨行），支持 # 注释。
+// 不支持表、内联表、布尔值、日期和多行字符串，遇到时报错并提示改用 JSON
+func decodeTOML(input string, cfg *Config) error {
+	values, err := parseTOML(input)
+	if err != nil {
+		return err
+	}
+
+	for key, v := range values {
+		var err error
+		switch key {
+		case "width":
+			cfg.Width, err = v.asInt(key)
+		case "height":
+			cfg.Height, err = v.asInt(key)
+		case "fps":
+			cfg.FPS, err = v.asInt(key)
+		case "background":
+			cfg.Background, err = v.asString(key)
+		case "output_root":
+			cfg.OutputRoot, err = v.asString(key)
+		case "font_paths":
+			cfg.FontPaths, err = v.asStrings(key)
+		case "ffmpeg_path":
+			cfg.FFmpegPath, err = v.asString(key)
+		case "theme":
+			cfg.Theme, err = v.asString(key)
+		case "text_min_size":
+			cfg.TextMinSize, err = v.asFloat(key)
+		case "text_max_size":
+			cfg.TextMaxSize, err = v.asFloat(key)
+		default:
+			err = i18n.Errorf("toml.unknown_key", v.line, key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tomlValue 解析出的值，kind 为 "string"、"int"、"float" 或 "array"
+type tomlValue struct {
+	kind  string
+	str   string
+	num   int
+	fnum  float64
+	array []string
+	line  int
+}
+
+func (v tomlValue) asInt(key string) (int, error) {
+	if v.kind != "int" {
+		return 0, i18n.Errorf("toml.not_integer", v.line, key)
+	}
+	return v.num, nil
+}
+
+func (v tomlValue) asFloat(key string) (float64, error) {
+	switch v.kind {
+	case "float":
+		return v.fnum, nil
+	case "int":
+		return float64(v.num), nil
+	default:
+		return 0, i18n.Errorf("toml.not_number", v.line, key)
+	}
+}
+
+func (v tomlValue) asString(key string) (string, error) {
+	if v.kind != "string" {
+		return "", i18n.Errorf("toml.not_string", v.line, key)
+	}
+	return v.str, nil
+}
+
+func (v tomlValue) asStrings(key string) ([]string, error) {
+	switch v.kind {
+	case "array":
+		return v.array, nil
+	case "string":
+		return []string{v.str}, nil
+	default:
+		return nil, i18n.Errorf("toml.not_string_array", v.line, key)
+	}
+}
+
+// tomlParser 逐字符扫描的简易解析器
+type tomlParser struct {
+	input string
+	pos   int
+	line  int
+}
+
+func parseTOML(input string) (map[string]tomlValue, error) {
+	p := &tomlParser{input: input, line: 1}
+	values := make(map[string]tomlValue)
+
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return values, nil
+		}
+
+		if p.peek() == '[' {
+			return nil, i18n.Errorf("toml.table", p.line)
+		}
+
+		line := p.line
+		key := p.readKey()
+		if key == "" {
+			return nil, i18n.Errorf("toml.expected_key", line)
+		}
+
+		p.skipBlank(false)
+		if p.eof() || p.peek() != '=' {
+			return nil, i18n.Errorf("toml.expected_assign", line, key)
+		}
+		p.pos++
+		p.skipBlank(false)
+
+		value, err := p.readValue()
+		if err != nil {
+			return nil, err
+		}
+		value.line = line
+
+		if _, exists := values[key]; exists {
+			return nil, i18n.Errorf("toml.duplicate_key", line, key)
+		}
+		values[key] = value
+
+		// 值之后只允许注释和换行
+		p.skipBlank(false)
+		if !p.eof() && p.peek() != '\n' {
+			return nil, i18n.Errorf("toml.after_value", p.line, p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tomlParser) peek() byte {
+	return p.input[p.pos]
+}
+
+// skipBlank 跳过空白和注释，newlines 为 true 时同时跳过换行
+func (p *tomlParser) skipBlank(newlines bool) {
+	for !p.eof() {
+		switch ch := p.peek(); {
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			p.pos++
+		case ch == '\n' && newlines:
+			p.pos++
+			p.line++
+		case ch == '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) readKey() string {
+	if !p.eof() && (p.peek() == '"' || p.peek() == '\'') {
+		s, err := p.readString()
+		if err != nil {
+			return ""
+		}
+		return s
+	}
+
+	start := p.pos
+	for !p.eof() {
+		ch := p.peek()
+		if ch == '_' || ch == '-' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *tomlParser) readValue() (tomlValue, error) {
+	if p.eof() {
+		return tomlValue{}, i18n.Errorf("toml.missing_value", p.line)
+	}
+
+	switch ch := p.peek(); {
+	case ch == '"' || ch == '\'':
+		s, err := p.readString()
+		return tomlValue{kind: "string", str: s}, err
+	case ch == '[':
+		return p.readArray()
+	case ch == '-' || ch == '+' || ch >= '0' && ch <= '9':
+		return p.readNumber()
+	default:
+		return tomlValue{}, i18n.Errorf("toml.unsupported_value", p.line, ch)
+	}
+}
+
+// tomlNumber TOML 的十进制整数和浮点数：整数部分不能有多余的前导 0，
+// 小数点两侧都要有数字，_ 只能出现在两个数字之间
+var tomlNumber = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+
+// readNumber 读取整数或浮点数，带小数点或指数的是浮点数
+func (p *tomlParser) readNumber() (tomlValue, error) {
+	start := p.pos
+	for !p.eof() {
+		ch := p.peek()
+		if ch >= '0' && ch <= '9' || strings.IndexByte("+-._eE", ch) >= 0 {
+			p.pos++
+			continue
+		}
+		break
+	}
+	text := p.input[start:p.pos]
+	if !tomlNumber.MatchString(text) {
+		return tomlValue{}, i18n.Errorf("toml.invalid_number", p.line, text)
+	}
+
+	digits := strings.ReplaceAll(text, "_", "")
+	if strings.ContainsAny(digits, ".eE") {
+		f, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return tomlValue{}, i18n.Errorf("toml.invalid_number", p.line, text)
+		}
+		return tomlValue{kind: "float", fnum: f}, nil
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return tomlValue{}, i18n.Errorf("toml.invalid_integer", p.line, text)
+	}
+	return tomlValue{kind: "int", num: n}, nil
+}
+
+func (p *tomlParser) readArray() (tomlValue, error) {
+	p.pos++ // 跳过 '['
+	var items []string
+
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return tomlValue{}, i18n.Errorf("toml.unterminated_array", p.line)
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return tomlValue{kind: "array", array: items}, nil
+		}
+
+		if ch := p.peek(); ch != '"' && ch != '\'' {
+			return tomlValue{}, i18n.Errorf("toml.array_item", p.line)
+		}
+		s, err := p.readString()
+		if err != nil {
+			return tomlValue{}, err
+		}
+		items = append(items, s)
+
+		p.skipBlank(true)
+		if !p.eof() && p.peek() == ',' {
+			p.pos++
+		}
+	}
+}
+
+// readString 读取基本字符串（支持常见转义）或字面量字符串
+func (p *tomlParser) readString() (string, error) {
+	quote := p.peek()
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		ch := p.peek()
+		p.pos++
+		switch {
+		case ch == quote:
+			return sb.String(), nil
+		case ch == '\n':
+			return "", i18n.Errorf("toml.unterminated_string", p.line)
+		case ch == '\\' && quote == '"':
+			if p.eof() {
+				return "", i18n.Errorf("toml.unterminated_string", p.line)
+			}
+			esc := p.peek()
+			p.pos++
+			switch esc {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\':
+				sb.WriteByte(esc)
+			default:
+				return "", i18n.Errorf("toml.unsupported_escape", p.line, esc)
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", i18n.Errorf("toml.unterminated_string", p.line)
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"render2go/internal/i18n"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Config
+	}{
+		{
+			name:  "integers and strings",
+			input: "width = 1280\nheight = 720\nfps = 30\nbackground = \"#FFFFFF\"\ntheme = 'classic'\n",
+			want:  Config{Width: 1280, Height: 720, FPS: 30, Background: "#FFFFFF", Theme: "classic"},
+		},
+		{
+			name:  "comments and blank lines",
+			input: "# project\n\nwidth = 1_920 # full hd\nheight = 1080\n",
+			want:  Config{Width: 1920, Height: 1080},
+		},
+		{
+			name:  "multi-line string array",
+			input: "font_paths = [\n  \"fonts\",\n  '/usr/share/fonts', # system\n]\n",
+			want:  Config{FontPaths: []string{"fonts", "/usr/share/fonts"}},
+		},
+		{
+			name:  "single string as array",
+			input: "font_paths = \"fonts\"\n",
+			want:  Config{FontPaths: []string{"fonts"}},
+		},
+		{
+			name:  "string escapes",
+			input: `ffmpeg_path = "C:\\ffmpeg\\bin\\ffmpeg.exe"` + "\n",
+			want:  Config{FFmpegPath: `C:\ffmpeg\bin\ffmpeg.exe`},
+		},
+		{
+			name:  "decimal float",
+			input: "text_min_size = 12.5\n",
+			want:  Config{TextMinSize: 12.5},
+		},
+		{
+			name:  "exponent float",
+			input: "text_max_size = 1.5e2\n",
+			want:  Config{TextMaxSize: 150},
+		},
+		{
+			name:  "negative integer for float key",
+			input: "text_max_size = -1\n",
+			want:  Config{TextMaxSize: -1},
+		},
+		{
+			name:  "signed exponent with underscores",
+			input: "text_min_size = +1_0.2_5e-1\n",
+			want:  Config{TextMinSize: 1.025},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Config
+			if err := decodeTOML(tt.input, &got); err != nil {
+				t.Fatalf("decodeTOML(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeTOML(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"float for integer key", "width = 1280.5\n", "line 1: width must be an integer"},
+		{"exponent for integer key", "fps = 3e1\n", "line 1: fps must be an integer"},
+		{"string for number key", "text_min_size = \"12\"\n", "line 1: text_min_size must be a number"},
+		{"number for string key", "theme = 1\n", "line 1: theme must be a string"},
+		{"trailing decimal point", "text_min_size = 12.\n", `line 1: invalid number "12."`},
+		{"leading decimal point", "text_min_size = .5\n", `unsupported value starting with '.'`},
+		{"leading zero", "fps = 030\n", `line 1: invalid number "030"`},
+		{"doubled underscore", "width = 1__280\n", `line 1: invalid number "1__280"`},
+		{"trailing underscore", "width = 1280_\n", `line 1: invalid number "1280_"`},
+		{"missing exponent digits", "text_max_size = 1e\n", `line 1: invalid number "1e"`},
+		{"date value", "width = 2024-01-01\n", `line 1: invalid number "2024-01-01"`},
+		{"integer overflow", "width = 99999999999999999999\n", `line 1: invalid integer "99999999999999999999"`},
+		{"unknown key", "depth = 3\n", `line 1: unknown key "depth"`},
+		{"duplicate key", "fps = 30\nfps = 60\n", `line 2: duplicate key "fps"`},
+		{"table", "[render]\nfps = 30\n", "line 1: tables are not supported"},
+		{"missing equals", "fps 30\n", `line 1: expected '=' after "fps"`},
+		{"missing value", "fps =", "line 1: missing value"},
+		{"junk after value", "fps = 30 60\n", `line 1: unexpected '6' after value`},
+		{"unterminated string", "theme = \"classic\n", "line 1: unterminated string"},
+		{"unterminated array", "font_paths = [\"a\",\n", "line 2: unterminated array"},
+		{"non-string array item", "font_paths = [1]\n", "line 1: arrays may only contain strings"},
+		{"unsupported escape", `theme = "a\q"` + "\n", `line 1: unsupported escape \q`},
+		{"boolean", "fps = true\n", "only strings, numbers and string arrays are supported"},
+	}
+
+	// 错误消息按英文比较
+	defer i18n.SetLocale(i18n.CurrentLocale())
+	i18n.SetLocale(i18n.En)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			err := decodeTOML(tt.input, &cfg)
+			if err == nil {
+				t.Fatalf("decodeTOML(%q) succeeded, want error containing %q", tt.input, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("decodeTOML(%q) error = %q, want it to contain %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeTOMLErrorsLocalized(t *testing.T) {
+	defer i18n.SetLocale(i18n.CurrentLocale())
+	i18n.SetLocale(i18n.ZhCN)
+
+	var cfg Config
+	err := decodeTOML("[render]\n", &cfg)
+	if err == nil || !strings.Contains(err.Error(), "第 1 行: 不支持表") {
+		t.Errorf("decodeTOML error = %v, want a Chinese message", err)
+	}
+}
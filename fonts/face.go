@@ -0,0 +1,110 @@
+package fonts
+
+import (
+	"container/list"
+	"image"
+	"image/draw"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// lockedFace 使 opentype 字形可以在多个渲染器之间并发共享。
+// opentype.Face 内部复用光栅化缓冲区，Glyph 返回的遮罩只在下一次调用前有效，
+// 因此在锁内复制一份遮罩。
+type lockedFace struct {
+	mu   sync.Mutex
+	face font.Face
+}
+
+func (f *lockedFace) Close() error {
+	return nil // 字形由注册表持有，不随使用者关闭
+}
+
+func (f *lockedFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dr, mask, maskp, advance, ok := f.face.Glyph(dot, r)
+	if !ok || mask == nil {
+		return dr, mask, maskp, advance, ok
+	}
+	copied := image.NewAlpha(image.Rect(0, 0, dr.Dx(), dr.Dy()))
+	draw.Draw(copied, copied.Bounds(), mask, maskp, draw.Src)
+	return dr, copied, image.Point{}, advance, ok
+}
+
+func (f *lockedFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.face.GlyphBounds(r)
+}
+
+func (f *lockedFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.face.GlyphAdvance(r)
+}
+
+func (f *lockedFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.face.Kern(r0, r1)
+}
+
+func (f *lockedFace) Metrics() font.Metrics {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.face.Metrics()
+}
+
+// maxCachedFaces 注册表最多缓存的字形数。动画中连续变化的字号会产生大量不同的键，
+// 超出时淘汰最久未使用的字形，已交给渲染器的字形仍然可用
+const maxCachedFaces = 256
+
+// faceCache 按最近使用顺序淘汰的字形缓存，调用方需持有注册表锁
+type faceCache struct {
+	max   int
+	order *list.List // 元素为 *faceCacheItem，最近使用的在前
+	items map[faceKey]*list.Element
+}
+
+type faceCacheItem struct {
+	key  faceKey
+	face font.Face
+}
+
+func newFaceCache(max int) *faceCache {
+	return &faceCache{max: max, order: list.New(), items: make(map[faceKey]*list.Element)}
+}
+
+// get 查找字形并标记为最近使用
+func (c *faceCache) get(key faceKey) (font.Face, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*faceCacheItem).face, true
+}
+
+// put 加入字形，超出容量时淘汰最久未使用的字形
+func (c *faceCache) put(key faceKey, face font.Face) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*faceCacheItem).face = face
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&faceCacheItem{key: key, face: face})
+	for c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*faceCacheItem).key)
+	}
+}
+
+// len 返回缓存的字形数
+func (c *faceCache) len() int {
+	return c.order.Len()
+}
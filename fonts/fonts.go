@@ -0,0 +1,657 @@
+// Package fonts 管理渲染文本使用的字体。
+//
+// 注册表内置 Go 字体族（gofont），并加载配置文件 font_paths 中列出的
+// TTF/OTF/TTC 字体。文本指定的字体族缺少某些字符（例如中文）时，
+// 会依次在其他已注册字体和系统字体中查找能显示全部字符的字体。
+// 解析后的字体和按字号创建的字形缓存在全局注册表中，由所有渲染器共享。
+package fonts
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"render2go/internal/defaults"
+	"render2go/internal/i18n"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/gomediumitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// 内置字体族名称
+const (
+	DefaultFamily = "Go"
+	MonoFamily    = "Go Mono"
+)
+
+// Weight 字重，取值与 CSS font-weight 一致（100-900）
+type Weight int
+
+// 常用字重
+const (
+	Thin       Weight = 100
+	ExtraLight Weight = 200
+	Light      Weight = 300
+	Regular    Weight = 400
+	Medium     Weight = 500
+	SemiBold   Weight = 600
+	Bold       Weight = 700
+	ExtraBold  Weight = 800
+	Black      Weight = 900
+)
+
+// weightNames 字重名称，按匹配优先级排列（较长的名称在前）
+var weightNames = []struct {
+	name   string
+	weight Weight
+}{
+	{"extralight", ExtraLight},
+	{"ultralight", ExtraLight},
+	{"extrabold", ExtraBold},
+	{"ultrabold", ExtraBold},
+	{"semibold", SemiBold},
+	{"demibold", SemiBold},
+	{"thin", Thin},
+	{"hairline", Thin},
+	{"light", Light},
+	{"regular", Regular},
+	{"normal", Regular},
+	{"book", Regular},
+	{"medium", Medium},
+	{"bold", Bold},
+	{"black", Black},
+	{"heavy", Black},
+}
+
+// ParseWeight 解析字重名称（normal、bold、light 等）或 100-900 的数值
+func ParseWeight(s string) (Weight, error) {
+	name := normalizeName(s)
+	for _, w := range weightNames {
+		if name == w.name {
+			return w.weight, nil
+		}
+	}
+	var n int
+	if _, err := fmt.Sscanf(name, "%d", &n); err == nil && fmt.Sprint(n) == name {
+		return WeightFromNumber(float64(n))
+	}
+	return 0, i18n.Errorf("font.invalid_weight", s)
+}
+
+// WeightFromNumber 将数值转换为字重，必须在 1-1000 之间
+func WeightFromNumber(n float64) (Weight, error) {
+	if n < 1 || n > 1000 || n != math.Trunc(n) {
+		return 0, i18n.Errorf("font.invalid_weight", fmt.Sprint(n))
+	}
+	return Weight(n), nil
+}
+
+// String 返回字重的数值表示
+func (w Weight) String() string {
+	return fmt.Sprint(int(w))
+}
+
+// Style 字形样式
+type Style int
+
+// 字形样式取值
+const (
+	Normal Style = iota
+	Italic
+)
+
+// ParseStyle 解析字形样式：normal、italic 或 oblique（按 italic 处理）
+func ParseStyle(s string) (Style, error) {
+	switch normalizeName(s) {
+	case "normal", "regular", "upright":
+		return Normal, nil
+	case "italic", "oblique":
+		return Italic, nil
+	}
+	return Normal, i18n.Errorf("font.invalid_style", s)
+}
+
+// String 返回样式名称
+func (s Style) String() string {
+	if s == Italic {
+		return "italic"
+	}
+	return "normal"
+}
+
+// Spec 描述文本需要的字体
+type Spec struct {
+	Family string // 字体族，为空时使用默认字体族
+	Weight Weight // 字重，为 0 时视为 Regular
+	Style  Style
+}
+
+// Info 已注册字体的描述
+type Info struct {
+	Family string
+	Weight Weight
+	Style  Style
+	Source string // 字体文件路径，内置字体为 "builtin"
+}
+
+// entry 已解析的字体
+type entry struct {
+	Info
+	font *sfnt.Font
+	buf  sfnt.Buffer // GlyphIndex 使用的缓冲区，持有注册表锁时使用
+}
+
+// faceKey 字形缓存的键，字号按 1/100 像素取整
+type faceKey struct {
+	entry *entry
+	size  int
+}
+
+// Registry 字体注册表，可并发使用
+type Registry struct {
+	mu       sync.Mutex
+	entries  []*entry
+	loaded   map[string]bool // 已尝试加载的路径
+	fallback []string        // 缺字时才加载的系统字体
+	faces    *faceCache      // 按字体和字号缓存的字形
+	missing  map[rune]bool   // 已检查过的缺失字符
+	warned   map[string]bool // 已警告过缺字的 Unicode 文字
+}
+
+// NewRegistry 创建只包含内置 Go 字体的注册表
+func NewRegistry() *Registry {
+	r := &Registry{
+		loaded:  make(map[string]bool),
+		faces:   newFaceCache(maxCachedFaces),
+		missing: make(map[rune]bool),
+		warned:  make(map[string]bool),
+	}
+	builtin := [][]byte{
+		goregular.TTF, gobold.TTF, goitalic.TTF, gobolditalic.TTF,
+		gomedium.TTF, gomediumitalic.TTF,
+		gomono.TTF, gomonobold.TTF, gomonoitalic.TTF, gomonobolditalic.TTF,
+	}
+	for _, data := range builtin {
+		if err := r.register(data, "builtin"); err != nil {
+			panic(fmt.Sprintf("fonts: invalid builtin font: %v", err))
+		}
+	}
+	return r
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry *Registry
+)
+
+// Default 返回全局共享的注册表，并加载当前运行时配置中新增的字体路径
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		defaultRegistry = NewRegistry()
+		defaultRegistry.SetFallback(defaults.SystemFontPaths())
+	})
+	for _, err := range defaultRegistry.LoadPaths(defaults.CurrentRuntime().FontPaths) {
+		slog.Warn(i18n.T("log.font_load_failed"), "error", err)
+	}
+	return defaultRegistry
+}
+
+// SetFallback 设置缺字时按顺序尝试的字体文件，只在需要时加载
+func (r *Registry) SetFallback(paths []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = append([]string(nil), paths...)
+}
+
+// LoadPaths 加载字体文件或目录（不递归），已加载过的路径会被跳过，返回每个失败路径的错误
+func (r *Registry) LoadPaths(paths []string) []error {
+	var errs []error
+	for _, p := range paths {
+		r.mu.Lock()
+		done := r.loaded[p]
+		r.loaded[p] = true
+		r.mu.Unlock()
+		if done {
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !info.IsDir() {
+			if err := r.LoadFile(p); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		dirEntries, err := os.ReadDir(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, de := range dirEntries {
+			if de.IsDir() || !IsFontFile(de.Name()) {
+				continue
+			}
+			if err := r.LoadFile(filepath.Join(p, de.Name())); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// LoadFile 加载一个 TTF/OTF 字体或 TTC/OTC 字体集合
+func (r *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := r.register(data, path); err != nil {
+		return i18n.Errorf("font.parse_failed", path, err)
+	}
+	return nil
+}
+
+// register 解析字体数据（单个字体或字体集合）并加入注册表
+func (r *Registry) register(data []byte, source string) error {
+	collection, err := opentype.ParseCollection(data)
+	if err != nil {
+		return err
+	}
+
+	var added []*entry
+	for i := 0; i < collection.NumFonts(); i++ {
+		f, err := collection.Font(i)
+		if err != nil {
+			return err
+		}
+		e := &entry{font: f}
+		e.Source = source
+		e.Family, e.Weight, e.Style = describe(f, &e.buf)
+		added = append(added, e)
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, added...)
+	r.mu.Unlock()
+	return nil
+}
+
+// describe 从 name 表中读取字体族、字重和样式
+func describe(f *sfnt.Font, buf *sfnt.Buffer) (string, Weight, Style) {
+	family, err := f.Name(buf, sfnt.NameIDTypographicFamily)
+	if err != nil || family == "" {
+		family, _ = f.Name(buf, sfnt.NameIDFamily)
+	}
+	subfamily, err := f.Name(buf, sfnt.NameIDTypographicSubfamily)
+	if err != nil || subfamily == "" {
+		subfamily, _ = f.Name(buf, sfnt.NameIDSubfamily)
+	}
+
+	weight := Regular
+	desc := normalizeName(subfamily)
+	for _, w := range weightNames {
+		if strings.Contains(desc, w.name) {
+			weight = w.weight
+			break
+		}
+	}
+
+	// 旧式字体把字重写在字体族名称末尾（如 "Go Medium"、"Noto Sans Light"），
+	// 去掉后归入同一字体族
+	if i := strings.LastIndex(family, " "); i > 0 {
+		last := normalizeName(family[i+1:])
+		for _, w := range weightNames {
+			if last == w.name && w.weight != Regular {
+				family, weight = family[:i], w.weight
+				break
+			}
+		}
+	}
+
+	style := Normal
+	if strings.Contains(desc, "italic") || strings.Contains(desc, "oblique") {
+		style = Italic
+	}
+	return family, weight, style
+}
+
+// Families 返回已注册的字体族名称（排序、去重）
+func (r *Registry) Families() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var families []string
+	for _, e := range r.entries {
+		if !seen[e.Family] {
+			seen[e.Family] = true
+			families = append(families, e.Family)
+		}
+	}
+	sort.Strings(families)
+	return families
+}
+
+// Fonts 返回所有已注册字体的描述
+func (r *Registry) Fonts() []Info {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make([]Info, len(r.entries))
+	for i, e := range r.entries {
+		infos[i] = e.Info
+	}
+	return infos
+}
+
+// HasFamily 判断字体族是否已注册（忽略大小写，支持 sans、mono 等别名）
+func (r *Registry) HasFamily(family string) bool {
+	family = resolveAlias(family)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if strings.EqualFold(e.Family, family) {
+			return true
+		}
+	}
+	return false
+}
+
+// Face 返回用于绘制 text 的字形，size 为像素字号。
+// 优先使用 spec 指定的字体族；该字体缺少 text 中的字符时改用能显示全部字符的字体。
+// 返回的字形可在多个渲染器和 goroutine 之间共享。
+func (r *Registry) Face(spec Spec, size float64, text string) (font.Face, error) {
+	if size <= 0 {
+		return nil, i18n.Errorf("font.invalid_size", size)
+	}
+	if spec.Weight == 0 {
+		spec.Weight = Regular
+	}
+
+	e := r.resolve(spec, text)
+	if e == nil {
+		return nil, i18n.Errorf("render.no_font")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := faceKey{entry: e, size: int(math.Round(size * 100))}
+	if face, ok := r.faces.get(key); ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(e.font, &opentype.FaceOptions{
+		Size:    float64(key.size) / 100,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shared := &lockedFace{face: face}
+	r.faces.put(key, shared)
+	return shared, nil
+}
+
+// Extent 一行文本的排版尺寸（像素），纵向使用字体的行框而不是字形的实际墨迹
+type Extent struct {
+	Width   float64 // 前进宽度
+	Ascent  float64 // 基线以上的高度
+	Descent float64 // 基线以下的深度
+}
+
+// Height 返回行框高度
+func (e Extent) Height() float64 {
+	return e.Ascent + e.Descent
+}
+
+// Measure 测量 text 用 Face 选出的字体绘制时的尺寸
+func (r *Registry) Measure(spec Spec, size float64, text string) (Extent, error) {
+	face, err := r.Face(spec, size, text)
+	if err != nil {
+		return Extent{}, err
+	}
+	metrics := face.Metrics()
+	return Extent{
+		Width:   fixedToFloat(font.MeasureString(face, text)),
+		Ascent:  fixedToFloat(metrics.Ascent),
+		Descent: fixedToFloat(metrics.Descent),
+	}, nil
+}
+
+// fixedToFloat 将 26.6 定点数转换为浮点数
+func fixedToFloat(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+// resolve 选择绘制 text 的字体。已注册的字体都缺字时，在锁外依次加载系统字体再重新选择；
+// 仍然缺字时对每种缺失的 Unicode 文字警告一次，缺失的字符绘制为字体的占位方框
+func (r *Registry) resolve(spec Spec, text string) *entry {
+	for {
+		r.mu.Lock()
+		e, covered := r.match(spec, text)
+		path := ""
+		if !covered {
+			path = r.nextFallback()
+		}
+		r.mu.Unlock()
+
+		if covered {
+			return e
+		}
+		if path == "" {
+			r.warnMissing(e, text)
+			return e
+		}
+		// 加载失败的字体直接跳过，继续尝试下一个
+		_ = r.LoadFile(path)
+	}
+}
+
+// nextFallback 取出下一个尚未加载的系统字体，没有时返回空字符串，调用方需持有 r.mu
+func (r *Registry) nextFallback() string {
+	for len(r.fallback) > 0 {
+		path := r.fallback[0]
+		r.fallback = r.fallback[1:]
+		if !r.loaded[path] {
+			r.loaded[path] = true
+			return path
+		}
+	}
+	return ""
+}
+
+// warnMissing 对 e 无法显示的字符，按所属的 Unicode 文字各警告一次
+func (r *Registry) warnMissing(e *entry, text string) {
+	if e == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chars := make(map[string][]rune)
+	var scripts []string
+	for _, ch := range text {
+		if ch < ' ' || unicode.IsSpace(ch) {
+			continue
+		}
+		if r.missing[ch] {
+			continue
+		}
+		if idx, err := e.font.GlyphIndex(&e.buf, ch); err == nil && idx != 0 {
+			continue
+		}
+		r.missing[ch] = true
+		script := scriptOf(ch)
+		if r.warned[script] {
+			continue
+		}
+		if _, ok := chars[script]; !ok {
+			scripts = append(scripts, script)
+		}
+		if len(chars[script]) < maxMissingSample && !containsRune(chars[script], ch) {
+			chars[script] = append(chars[script], ch)
+		}
+	}
+	for _, script := range scripts {
+		r.warned[script] = true
+		slog.Warn(i18n.T("log.font_missing_glyphs"), "script", script, "chars", string(chars[script]))
+	}
+}
+
+// maxMissingSample 缺字警告中列出的每种文字的字符数
+const maxMissingSample = 8
+
+// scriptOf 返回字符所属的 Unicode 文字名称，不属于任何文字时返回所在的 U+XX00 区块
+func scriptOf(ch rune) string {
+	for name, table := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" && unicode.Is(table, ch) {
+			return name
+		}
+	}
+	return fmt.Sprintf("U+%04X", ch&^0xFF)
+}
+
+// containsRune 判断 runes 中是否有 ch
+func containsRune(runes []rune, ch rune) bool {
+	for _, r := range runes {
+		if r == ch {
+			return true
+		}
+	}
+	return false
+}
+
+// match 选择字体，调用方需持有 r.mu。covered 表示选出的字体能显示 text 的全部字符，
+// 为 false 时返回指定字体族中最接近的字体
+func (r *Registry) match(spec Spec, text string) (e *entry, covered bool) {
+	family := resolveAlias(spec.Family)
+
+	var inFamily []*entry
+	for _, e := range r.entries {
+		if strings.EqualFold(e.Family, family) {
+			inFamily = append(inFamily, e)
+		}
+	}
+	if len(inFamily) == 0 {
+		// 未知字体族回退到默认字体族
+		for _, e := range r.entries {
+			if e.Family == DefaultFamily {
+				inFamily = append(inFamily, e)
+			}
+		}
+	}
+
+	best := closest(inFamily, spec)
+	if best == nil || r.covers(best, text) {
+		return best, true
+	}
+
+	// 缺字：在其他已注册字体中查找
+	if e := r.coveringEntry(spec, text); e != nil {
+		return e, true
+	}
+	return best, false
+}
+
+// coveringEntry 在所有已注册字体中选出能显示 text 的最接近 spec 的字体
+func (r *Registry) coveringEntry(spec Spec, text string) *entry {
+	return closest(coveringAll(r, r.entries, text), spec)
+}
+
+// coveringAll 过滤出能显示 text 中全部字符的字体
+func coveringAll(r *Registry, entries []*entry, text string) []*entry {
+	var result []*entry
+	for _, e := range entries {
+		if r.covers(e, text) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// covers 判断字体是否包含 text 中所有可见字符
+func (r *Registry) covers(e *entry, text string) bool {
+	for _, ch := range text {
+		if ch < ' ' {
+			continue
+		}
+		if idx, err := e.font.GlyphIndex(&e.buf, ch); err != nil || idx == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// closest 选出与 spec 最接近的字体：样式一致优先，其次字重差最小
+func closest(entries []*entry, spec Spec) *entry {
+	var best *entry
+	bestScore := math.MaxInt
+	for _, e := range entries {
+		score := int(e.Weight - spec.Weight)
+		if score < 0 {
+			// 与 CSS 规则类似，需要的字重缺失时略微偏向更粗的字体
+			score = -score + 1
+		}
+		if e.Style != spec.Style {
+			score += 10000
+		}
+		if score < bestScore {
+			best, bestScore = e, score
+		}
+	}
+	return best
+}
+
+// resolveAlias 将通用字体族名称映射为内置字体族
+func resolveAlias(family string) string {
+	switch normalizeName(family) {
+	case "", "default", "sans", "sansserif", "serif":
+		return DefaultFamily
+	case "mono", "monospace":
+		return MonoFamily
+	}
+	return strings.TrimSpace(family)
+}
+
+// normalizeName 转小写并去掉空格、连字符和下划线
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// IsFontFile 根据扩展名判断是否为字体文件
+func IsFontFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ttf", ".ttc", ".otf", ".otc":
+		return true
+	}
+	return false
+}
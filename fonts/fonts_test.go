@@ -0,0 +1,107 @@
+package fonts
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFaceCacheBounded(t *testing.T) {
+	r := NewRegistry()
+	first, err := r.Face(Spec{}, 10, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 动画中连续变化的字号
+	for i := 0; i < 3*maxCachedFaces; i++ {
+		if _, err := r.Face(Spec{}, 10+float64(i)/10, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := r.faces.len(); n != maxCachedFaces {
+		t.Errorf("cached faces = %d, want %d", n, maxCachedFaces)
+	}
+
+	// 被淘汰的字形仍然可用，再次请求时重新创建
+	if _, ok := first.GlyphAdvance('x'); !ok {
+		t.Error("evicted face no longer works")
+	}
+	again, err := r.Face(Spec{}, 10, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == first {
+		t.Error("evicted face returned from the cache")
+	}
+	if cached, _ := r.Face(Spec{}, 10, "x"); cached != again {
+		t.Error("recently used face not cached")
+	}
+}
+
+func TestFaceWarnsMissingGlyphsOnce(t *testing.T) {
+	var buf bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(old)
+
+	r := NewRegistry()
+	r.SetFallback([]string{"/nonexistent/a.ttf", "/nonexistent/b.ttc"})
+	texts := []string{"你好", "世界 hello", "你好", "𓀀", "ok"}
+	for _, text := range texts {
+		face, err := r.Face(Spec{}, 12, text)
+		if err != nil {
+			t.Fatalf("Face(%q) error: %v", text, err)
+		}
+		if face == nil {
+			t.Fatalf("Face(%q) returned nil", text)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d warnings, want one for Han and one for Egyptian hieroglyphs:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "script=Han") || !strings.Contains(lines[0], "你好") {
+		t.Errorf("first warning = %q, want the missing Han characters", lines[0])
+	}
+	if !strings.Contains(lines[1], "script=Egyptian_Hieroglyphs") {
+		t.Errorf("second warning = %q, want Egyptian hieroglyphs", lines[1])
+	}
+}
+
+func TestOutlinesUseNotdefForMissingGlyphs(t *testing.T) {
+	r := NewRegistry()
+	r.SetFallback(nil)
+	outlines, err := r.Outlines(Spec{}, 20, "a你b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outlines) != 3 || outlines[1].Rune != '你' || len(outlines[1].Segments) == 0 {
+		t.Errorf("outlines = %+v, want a placeholder outline for the missing character", outlines)
+	}
+}
+
+func TestFaceConcurrentFallback(t *testing.T) {
+	r := NewRegistry()
+	fallback := make([]string, 20)
+	for i := range fallback {
+		fallback[i] = "/nonexistent/font.ttf" + strings.Repeat("x", i)
+	}
+	r.SetFallback(fallback)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text := []string{"abc", "中文", "αβγ"}[i%3]
+			if _, err := r.Measure(Spec{Weight: Bold}, 10+float64(i), text); err != nil {
+				t.Errorf("Measure(%q) error: %v", text, err)
+			}
+			r.Families()
+		}(i)
+	}
+	wg.Wait()
+}
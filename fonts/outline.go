@@ -0,0 +1,71 @@
+package fonts
+
+import (
+	"render2go/internal/i18n"
+	"unicode"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// GlyphOutline 单个字符的矢量轮廓，坐标单位为像素，y 轴向下，
+// 原点为该字符在基线上的起笔点
+type GlyphOutline struct {
+	Rune     rune
+	X        float64 // 起笔点相对文本起点的偏移，已计入字距调整
+	Segments sfnt.Segments
+}
+
+// Outlines 返回 text 按 Face 选出的字体绘制时每个可见字符的轮廓。
+// 字符的水平位置与 Face 绘制时一致，因此轮廓可以与位图文本精确重合。
+func (r *Registry) Outlines(spec Spec, size float64, text string) ([]GlyphOutline, error) {
+	face, err := r.Face(spec, size, text)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Weight == 0 {
+		spec.Weight = Regular
+	}
+
+	e := r.resolve(spec, text)
+	if e == nil {
+		return nil, i18n.Errorf("render.no_font")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var outlines []GlyphOutline
+	var pen fixed.Int26_6
+	prev := rune(-1)
+	ppem := fixed.Int26_6(size * 64)
+	for _, ch := range text {
+		if prev >= 0 {
+			pen += face.Kern(prev, ch)
+		}
+		prev = ch
+
+		// 字体缺少的字符使用 .notdef（索引 0）的轮廓，与位图文本中的占位方框一致
+		if !unicode.IsSpace(ch) {
+			index, err := e.font.GlyphIndex(&e.buf, ch)
+			if err == nil {
+				segments, err := e.font.LoadGlyph(&e.buf, index, ppem, nil)
+				if err != nil {
+					return nil, err
+				}
+				// LoadGlyph 返回的切片在下次调用时会被覆盖，需要复制
+				outlines = append(outlines, GlyphOutline{
+					Rune:     ch,
+					X:        fixedToFloat(pen),
+					Segments: append(sfnt.Segments(nil), segments...),
+				})
+			}
+		}
+
+		advance, ok := face.GlyphAdvance(ch)
+		if ok {
+			pen += advance
+		}
+	}
+	return outlines, nil
+}
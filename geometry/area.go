@@ -0,0 +1,312 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/colors"
+	"render2go/core"
+	gmMath "render2go/math"
+	"strings"
+)
+
+// 面积和黎曼和的绘制参数
+const (
+	areaSamples        = 200 // 区域沿 x 方向的均匀采样区间数
+	areaFillOpacity    = 0.5 // 面积的默认填充透明度
+	riemannFillOpacity = 0.5 // 矩形的填充透明度，描边不透明以区分相邻的矩形
+	riemannMaxCount    = 10000
+)
+
+// Area 坐标系中曲线与 x 轴之间（或两条曲线之间）在 [xMin, xMax] 上的区域。
+// 超出坐标系 y 范围的部分被截到边界上，函数无定义处区域断开
+type Area struct {
+	*Path
+	axes   *CoordinateSystem
+	upper  func(x float64) float64
+	lower  func(x float64) float64 // 为 nil 时使用 x 轴 y = 0
+	xRange [2]float64
+}
+
+// NewArea 创建 upper 与 lower 之间的区域，lower 为 nil 时为 upper 与 x 轴之间的区域；
+// x 范围会被限制在坐标系的 x 范围内
+func NewArea(axes *CoordinateSystem, upper, lower func(x float64) float64, xMin, xMax float64) *Area {
+	area := &Area{
+		Path:   NewPath(),
+		axes:   axes,
+		upper:  upper,
+		lower:  lower,
+		xRange: [2]float64{math.Min(xMin, xMax), math.Max(xMin, xMax)},
+	}
+	area.SetColor(colors.PurpleBlue)
+	area.SetStrokeWidth(0)
+	area.SetFillOpacity(areaFillOpacity)
+	area.Generate()
+	return area
+}
+
+// Axes 获取所在的坐标系
+func (a *Area) Axes() *CoordinateSystem {
+	return a.axes
+}
+
+// XRange 获取区域的 x 范围
+func (a *Area) XRange() [2]float64 {
+	return a.xRange
+}
+
+// Generate 按坐标系当前的范围和单位重新生成区域
+func (a *Area) Generate() *Area {
+	axesX, yRange := a.axes.XRange(), a.axes.YRange()
+	xMin, xMax := math.Max(a.xRange[0], axesX[0]), math.Min(a.xRange[1], axesX[1])
+	if xMin >= xMax {
+		a.setPolylines(nil)
+		return a
+	}
+
+	// 两条曲线都有定义的连续区间各自构成一个闭合多边形：上边界从左到右，下边界从右到左
+	var polygons [][]gmMath.Vector2
+	var top, bottom []gmMath.Vector2
+	flush := func() {
+		if len(top) >= 2 {
+			polygon := append([]gmMath.Vector2(nil), top...)
+			for i := len(bottom) - 1; i >= 0; i-- {
+				polygon = append(polygon, bottom[i])
+			}
+			polygons = append(polygons, append(polygon, polygon[0]))
+		}
+		top, bottom = nil, nil
+	}
+	xScale, _ := a.axes.Scales()
+	t0, t1 := xScale.forward(xMin), xScale.forward(xMax)
+	for i := 0; i <= areaSamples; i++ {
+		x := xScale.inverse(t0 + (t1-t0)*float64(i)/areaSamples)
+		yTop, yBottom := a.upper(x), 0.0
+		if a.lower != nil {
+			yBottom = a.lower(x)
+		}
+		if !finite(yTop) || !finite(yBottom) {
+			flush()
+			continue
+		}
+		top = append(top, a.axes.CoordinateToPoint(gmMath.Vector2{X: x, Y: clampRange(yTop, yRange)}))
+		bottom = append(bottom, a.axes.CoordinateToPoint(gmMath.Vector2{X: x, Y: clampRange(yBottom, yRange)}))
+	}
+	flush()
+	a.setPolylines(polygons)
+	return a
+}
+
+// Copy 复制区域，与原区域共享坐标系和函数
+func (a *Area) Copy() core.Mobject {
+	return &Area{
+		Path:   a.Path.Copy().(*Path),
+		axes:   a.axes,
+		upper:  a.upper,
+		lower:  a.lower,
+		xRange: a.xRange,
+	}
+}
+
+// finite 数值是否有限
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// RiemannMethod 黎曼和在每个小区间上取高度的方式
+type RiemannMethod int
+
+const (
+	RiemannLeft      RiemannMethod = iota // 左端点
+	RiemannRight                          // 右端点
+	RiemannMidpoint                       // 中点
+	RiemannTrapezoid                      // 梯形，两端点连线
+)
+
+var riemannMethodNames = []string{"left", "right", "mid", "trap"}
+
+// String 返回取样方式的名称
+func (m RiemannMethod) String() string {
+	if m >= 0 && int(m) < len(riemannMethodNames) {
+		return riemannMethodNames[m]
+	}
+	return "unknown"
+}
+
+// ParseRiemannMethod 根据名称解析取样方式，midpoint 和 trapezoid 分别是 mid 和 trap 的别名
+func ParseRiemannMethod(name string) (RiemannMethod, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "left":
+		return RiemannLeft, true
+	case "right":
+		return RiemannRight, true
+	case "mid", "midpoint":
+		return RiemannMidpoint, true
+	case "trap", "trapezoid":
+		return RiemannTrapezoid, true
+	}
+	return RiemannLeft, false
+}
+
+// RiemannMethodNames 返回所有取样方式的名称
+func RiemannMethodNames() []string {
+	return append([]string(nil), riemannMethodNames...)
+}
+
+// RiemannSum 黎曼和：把 [xMin, xMax] 等分为 n 个小区间，每个区间画一个矩形（梯形法时为梯形）。
+// 矩形从 x 轴画到取样高度，函数为负时画在 x 轴下方，超出坐标系 y 范围的部分被截到边界上
+type RiemannSum struct {
+	*PathGroup
+	axes        *CoordinateSystem
+	function    func(x float64) float64
+	xRange      [2]float64
+	n           int
+	method      RiemannMethod
+	color       color.Color
+	strokeWidth float64
+}
+
+// NewRiemannSum 创建 function 在 [xMin, xMax] 上分成 n 个区间的黎曼和
+func NewRiemannSum(axes *CoordinateSystem, function func(x float64) float64, xMin, xMax float64, n int, method RiemannMethod) *RiemannSum {
+	sum := &RiemannSum{
+		PathGroup:   NewPathGroup(nil),
+		axes:        axes,
+		function:    function,
+		xRange:      [2]float64{math.Min(xMin, xMax), math.Max(xMin, xMax)},
+		method:      method,
+		color:       colors.PurpleBlue,
+		strokeWidth: 1.0,
+	}
+	sum.SetN(n)
+	return sum
+}
+
+// Axes 获取所在的坐标系
+func (r *RiemannSum) Axes() *CoordinateSystem {
+	return r.axes
+}
+
+// XRange 获取求和的 x 范围
+func (r *RiemannSum) XRange() [2]float64 {
+	return r.xRange
+}
+
+// N 获取区间数
+func (r *RiemannSum) N() int {
+	return r.n
+}
+
+// SetN 设置区间数并重新生成矩形，n 被限制在 1 到 10000 之间
+func (r *RiemannSum) SetN(n int) *RiemannSum {
+	r.n = int(math.Max(1, math.Min(float64(n), riemannMaxCount)))
+	r.Generate()
+	return r
+}
+
+// Method 获取取样方式
+func (r *RiemannSum) Method() RiemannMethod {
+	return r.method
+}
+
+// SetMethod 设置取样方式并重新生成矩形
+func (r *RiemannSum) SetMethod(method RiemannMethod) *RiemannSum {
+	r.method = method
+	r.Generate()
+	return r
+}
+
+// heights 返回第 i 个区间左右两边的高度，除梯形法外两者相同
+func (r *RiemannSum) heights(x0, x1 float64) (float64, float64) {
+	switch r.method {
+	case RiemannRight:
+		h := r.function(x1)
+		return h, h
+	case RiemannMidpoint:
+		h := r.function((x0 + x1) / 2)
+		return h, h
+	case RiemannTrapezoid:
+		return r.function(x0), r.function(x1)
+	default:
+		h := r.function(x0)
+		return h, h
+	}
+}
+
+// Sum 返回黎曼和的值，无定义的区间不计入
+func (r *RiemannSum) Sum() float64 {
+	dx := (r.xRange[1] - r.xRange[0]) / float64(r.n)
+	total := 0.0
+	for i := 0; i < r.n; i++ {
+		x0 := r.xRange[0] + dx*float64(i)
+		left, right := r.heights(x0, x0+dx)
+		if finite(left) && finite(right) {
+			total += (left + right) / 2 * dx
+		}
+	}
+	return total
+}
+
+// Generate 按坐标系当前的范围和单位重新生成矩形
+func (r *RiemannSum) Generate() *RiemannSum {
+	xRange, yRange := r.axes.XRange(), r.axes.YRange()
+	base := clampRange(0, yRange)
+	dx := (r.xRange[1] - r.xRange[0]) / float64(r.n)
+	r.parts = r.parts[:0]
+	for i := 0; i < r.n; i++ {
+		x0 := r.xRange[0] + dx*float64(i)
+		x1 := x0 + dx
+		left, right := r.heights(x0, x1)
+		// 超出坐标系 x 范围的部分不画；梯形被截断时高度取截断处的值
+		c0, c1 := clampRange(x0, xRange), clampRange(x1, xRange)
+		if !finite(left) || !finite(right) || c0 >= c1 {
+			continue
+		}
+		height := func(x float64) float64 {
+			return clampRange(left+(right-left)*(x-x0)/dx, yRange)
+		}
+		corners := []gmMath.Vector2{
+			{X: c0, Y: base},
+			{X: c1, Y: base},
+			{X: c1, Y: height(c1)},
+			{X: c0, Y: height(c0)},
+		}
+		for j, corner := range corners {
+			corners[j] = r.axes.CoordinateToPoint(corner)
+		}
+		part := NewPath()
+		part.setPolylines([][]gmMath.Vector2{append(corners, corners[0])})
+		part.SetColor(r.color)
+		part.SetFillOpacity(riemannFillOpacity)
+		part.SetStrokeWidth(r.strokeWidth)
+		r.parts = append(r.parts, part)
+	}
+	return r
+}
+
+// GetColor 获取矩形的颜色
+func (r *RiemannSum) GetColor() color.Color {
+	return r.color
+}
+
+// SetColor 设置所有矩形的填充和描边颜色
+func (r *RiemannSum) SetColor(c color.Color) {
+	r.color = c
+	r.PathGroup.SetColor(c)
+}
+
+// GetStrokeWidth 获取矩形的描边宽度
+func (r *RiemannSum) GetStrokeWidth() float64 {
+	return r.strokeWidth
+}
+
+// SetStrokeWidth 设置所有矩形的描边宽度
+func (r *RiemannSum) SetStrokeWidth(width float64) {
+	r.strokeWidth = width
+	r.PathGroup.SetStrokeWidth(width)
+}
+
+// Copy 复制黎曼和，与原对象共享坐标系和函数
+func (r *RiemannSum) Copy() core.Mobject {
+	c := *r
+	c.PathGroup = r.copyGroup()
+	return &c
+}
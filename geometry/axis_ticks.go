@@ -0,0 +1,242 @@
+package geometry
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	gmMath "render2go/math"
+	"strconv"
+)
+
+// Axis 坐标系中的一条坐标轴
+type Axis int
+
+const (
+	AxisX Axis = iota // X轴
+	AxisY             // Y轴
+)
+
+// 刻度标签和坐标轴标题的默认参数
+const (
+	defaultTickSize  = 12.0 // 刻度标签字号
+	axisTitleSize    = 16.0 // 坐标轴标题字号
+	maxPiDenominator = 12   // 以 π 为单位标注时分母的上限
+)
+
+// axisTicks 一条坐标轴的刻度标签设置
+type axisTicks struct {
+	step     float64 // 标签间距，0 表示按范围自动选择；以 π 为单位标注时以 π 计
+	format   string  // fmt 格式串，为空时按 formatNumber 显示
+	pi       bool    // 以 π 的倍数标注
+	showZero bool    // 标注坐标轴交点处的刻度，默认由原点标签 O 代替
+	rotation float64 // 标签旋转角度（弧度）
+	size     float64 // 标签字号
+	title    string  // 坐标轴标题
+}
+
+// defaultAxisTicks 默认的刻度标签设置
+func defaultAxisTicks() axisTicks {
+	return axisTicks{size: defaultTickSize}
+}
+
+// ticks 获取坐标轴的刻度标签设置
+func (cs *CoordinateSystem) ticks(axis Axis) *axisTicks {
+	if axis == AxisY {
+		return &cs.yTicks
+	}
+	return &cs.xTicks
+}
+
+// axisRange 获取坐标轴的范围、刻度方式和网格间距
+func (cs *CoordinateSystem) axisRange(axis Axis) ([2]float64, AxisScale, float64) {
+	if axis == AxisY {
+		return cs.yRange, cs.yScale, cs.ySpacing
+	}
+	return cs.xRange, cs.xScale, cs.xSpacing
+}
+
+// tickValues 返回坐标轴上需要标注的刻度值。
+// 未设置间距时按网格间距标注，范围较大时放稀；对数轴只标注 10 的整数次幂
+func (cs *CoordinateSystem) tickValues(axis Axis) []float64 {
+	r, scale, spacing := cs.axisRange(axis)
+	if scale == ScaleLog {
+		return decadeLabelValues(r)
+	}
+	t := cs.ticks(axis)
+	step := t.step
+	if t.pi {
+		if step <= 0 {
+			step = 0.5
+			if n := (r[1] - r[0]) / math.Pi; n > 16 {
+				step = 2
+			} else if n > 8 {
+				step = 1
+			}
+		}
+		return gridValues(r, step*math.Pi)
+	}
+	if step <= 0 {
+		step = spacing
+		if rangeSize := r[1] - r[0]; rangeSize > 30 {
+			step = spacing * 5 // 超大范围时进一步减少
+		} else if rangeSize > 15 {
+			step = spacing * 2 // 大范围时减少标签密度
+		}
+	}
+	return gridValues(r, step)
+}
+
+// tickText 按坐标轴的设置格式化刻度值
+func (cs *CoordinateSystem) tickText(axis Axis, v float64) string {
+	_, scale, _ := cs.axisRange(axis)
+	t := cs.ticks(axis)
+	switch {
+	case t.pi && scale == ScaleLinear:
+		return formatPiMultiple(v / math.Pi)
+	case t.format != "":
+		return fmt.Sprintf(t.format, v)
+	}
+	return formatTick(scale, v)
+}
+
+// tickLabel 创建刻度标签，p 为刻度在坐标轴上的场景位置。
+// X轴标签在坐标轴下方，旋转时以靠近刻度的一端对齐；Y轴标签在坐标轴左侧
+func (cs *CoordinateSystem) tickLabel(axis Axis, v float64, p gmMath.Vector2) *Text {
+	t := cs.ticks(axis)
+	label := cs.newLabel(cs.tickText(axis, v), t.size)
+	label.SetColor(color.RGBA{0, 0, 0, 255}) // 黑色
+	if axis == AxisY {
+		label.SetPosition(p.X-axisLabelOffset, p.Y) // 稍微偏左
+	} else {
+		switch {
+		case t.rotation > 0:
+			label.SetAnchor(AnchorRight)
+		case t.rotation < 0:
+			label.SetAnchor(AnchorLeft)
+		}
+		label.SetPosition(p.X, p.Y-axisLabelOffset) // 稍微偏下
+	}
+	label.SetRotation(t.rotation)
+	return label
+}
+
+// generateTitles 生成坐标轴标题：X轴标题在箭头上方靠右，Y轴标题在箭头右侧
+func (cs *CoordinateSystem) generateTitles() {
+	axisX, axisY := cs.axisPosition()
+	if title := cs.xTicks.title; title != "" {
+		end := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[1], Y: axisY})
+		label := cs.newLabel(title, axisTitleSize)
+		label.SetAnchor(AnchorBottomRight)
+		label.SetPosition(end.X, end.Y+axisLabelOffset*0.5)
+		label.SetColor(color.RGBA{0, 0, 0, 255})
+		cs.labels = append(cs.labels, label)
+	}
+	if title := cs.yTicks.title; title != "" {
+		end := cs.CoordinateToPoint(gmMath.Vector2{X: axisX, Y: cs.yRange[1]})
+		label := cs.newLabel(title, axisTitleSize)
+		label.SetAnchor(AnchorTopLeft)
+		label.SetPosition(end.X+axisLabelOffset*0.5, end.Y)
+		label.SetColor(color.RGBA{0, 0, 0, 255})
+		cs.labels = append(cs.labels, label)
+	}
+}
+
+// formatPiMultiple 把 c·π 格式化为 π/2、-3π/4、2π 的形式，c 不是分母不超过 12 的分数时写成小数
+func formatPiMultiple(c float64) string {
+	for q := 1; q <= maxPiDenominator; q++ {
+		p := math.Round(c * float64(q))
+		if math.Abs(p-c*float64(q)) > 1e-9 {
+			continue
+		}
+		sign := ""
+		if p < 0 {
+			sign, p = "-", -p
+		}
+		numerator := "π"
+		switch {
+		case p == 0:
+			return "0"
+		case p != 1:
+			numerator = strconv.FormatFloat(p, 'f', -1, 64) + "π"
+		}
+		if q == 1 {
+			return sign + numerator
+		}
+		return sign + numerator + "/" + strconv.Itoa(q)
+	}
+	return formatNumber(c) + "π"
+}
+
+// 刻度设置方法
+
+// SetTickStep 设置刻度标签的间距，0 表示按范围自动选择；以 π 为单位标注时间距以 π 计
+func (cs *CoordinateSystem) SetTickStep(axis Axis, step float64) *CoordinateSystem {
+	if step >= 0 {
+		cs.ticks(axis).step = step
+		cs.generateComponents()
+	}
+	return cs
+}
+
+// SetTickFormat 设置刻度标签的 fmt 格式串（如 "%.2f"），为空时恢复默认格式
+func (cs *CoordinateSystem) SetTickFormat(axis Axis, format string) *CoordinateSystem {
+	cs.ticks(axis).format = format
+	cs.generateComponents()
+	return cs
+}
+
+// SetPiTicks 设置是否以 π 的倍数标注刻度，只对线性轴有效
+func (cs *CoordinateSystem) SetPiTicks(axis Axis, pi bool) *CoordinateSystem {
+	cs.ticks(axis).pi = pi
+	cs.generateComponents()
+	return cs
+}
+
+// SetHideZero 设置是否省略坐标轴交点处（通常是 0）的刻度标签。
+// 默认省略，两条轴都省略时在原点标注 O
+func (cs *CoordinateSystem) SetHideZero(axis Axis, hide bool) *CoordinateSystem {
+	cs.ticks(axis).showZero = !hide
+	cs.generateComponents()
+	return cs
+}
+
+// SetTickRotation 设置刻度标签的旋转角度（弧度）
+func (cs *CoordinateSystem) SetTickRotation(axis Axis, angle float64) *CoordinateSystem {
+	cs.ticks(axis).rotation = angle
+	cs.generateComponents()
+	return cs
+}
+
+// SetTickSize 设置刻度标签的字号
+func (cs *CoordinateSystem) SetTickSize(axis Axis, size float64) *CoordinateSystem {
+	if size > 0 {
+		cs.ticks(axis).size = size
+		cs.generateComponents()
+	}
+	return cs
+}
+
+// SetAxisTitle 设置坐标轴标题，为空时不显示
+func (cs *CoordinateSystem) SetAxisTitle(axis Axis, title string) *CoordinateSystem {
+	cs.ticks(axis).title = title
+	cs.generateComponents()
+	return cs
+}
+
+// AxisTitle 获取坐标轴标题
+func (cs *CoordinateSystem) AxisTitle(axis Axis) string {
+	return cs.ticks(axis).title
+}
+
+// SetAxisSpacing 单独设置一条坐标轴的网格间距
+func (cs *CoordinateSystem) SetAxisSpacing(axis Axis, spacing float64) *CoordinateSystem {
+	if spacing > 0 {
+		if axis == AxisY {
+			cs.ySpacing = spacing
+		} else {
+			cs.xSpacing = spacing
+		}
+		cs.generateComponents()
+	}
+	return cs
+}
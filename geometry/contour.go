@@ -0,0 +1,332 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/colors"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// defaultContourResolution 网格在较长一边上的默认单元数
+const defaultContourResolution = 120
+
+// ContourPlot 隐函数曲线与等高线：在坐标系的范围内用 marching squares 追踪 f(x, y) = c，
+// 每个值对应一条路径，相邻单元的线段连接成折线。指定色图时各值按大小取不同颜色
+type ContourPlot struct {
+	*PathGroup
+	axes       *CoordinateSystem
+	function   func(x, y float64) float64
+	levels     []float64
+	resolution int
+	colormap   *colors.Colormap
+}
+
+// NewContourPlot 在坐标系 axes 的范围内追踪 function 的各个等值线
+func NewContourPlot(axes *CoordinateSystem, function func(x, y float64) float64, levels []float64) *ContourPlot {
+	parts := make([]*Path, len(levels))
+	for i := range parts {
+		parts[i] = NewPath()
+	}
+	plot := &ContourPlot{
+		PathGroup:  NewPathGroup(parts),
+		axes:       axes,
+		function:   function,
+		levels:     append([]float64(nil), levels...),
+		resolution: defaultContourResolution,
+	}
+	plot.Generate()
+	return plot
+}
+
+// Axes 获取所在的坐标系
+func (c *ContourPlot) Axes() *CoordinateSystem {
+	return c.axes
+}
+
+// Levels 获取等值线的值
+func (c *ContourPlot) Levels() []float64 {
+	return c.levels
+}
+
+// Resolution 获取网格在较长一边上的单元数
+func (c *ContourPlot) Resolution() int {
+	return c.resolution
+}
+
+// SetResolution 设置网格在较长一边上的单元数并重新追踪，曲线细小或相互靠近时调大
+func (c *ContourPlot) SetResolution(resolution int) *ContourPlot {
+	if resolution < 2 {
+		resolution = 2
+	}
+	c.resolution = resolution
+	c.Generate()
+	return c
+}
+
+// Colormap 获取色图，未设置时为 nil
+func (c *ContourPlot) Colormap() *colors.Colormap {
+	return c.colormap
+}
+
+// SetColormap 按色图给各值着色：最小值取色图起点，最大值取终点；传入 nil 取消
+func (c *ContourPlot) SetColormap(colormap *colors.Colormap) *ContourPlot {
+	c.colormap = colormap
+	c.applyColormap()
+	return c
+}
+
+// SetColor 所有等值线使用同一颜色，并取消色图
+func (c *ContourPlot) SetColor(col color.Color) {
+	c.colormap = nil
+	c.PathGroup.SetColor(col)
+}
+
+func (c *ContourPlot) applyColormap() {
+	if c.colormap == nil || len(c.levels) == 0 {
+		return
+	}
+	lo, hi := c.levels[0], c.levels[0]
+	for _, level := range c.levels {
+		lo, hi = math.Min(lo, level), math.Max(hi, level)
+	}
+	for i, part := range c.parts {
+		t := 0.5
+		if hi > lo {
+			t = (c.levels[i] - lo) / (hi - lo)
+		}
+		part.SetColor(c.colormap.At(t))
+	}
+}
+
+// Generate 按坐标系当前的范围和单位重新追踪所有等值线
+func (c *ContourPlot) Generate() *ContourPlot {
+	xRange, yRange := c.axes.XRange(), c.axes.YRange()
+	xUnit, yUnit := c.axes.Units()
+
+	// 网格单元在场景中接近正方形
+	width := (xRange[1] - xRange[0]) * xUnit
+	height := (yRange[1] - yRange[0]) * yUnit
+	nx, ny := c.resolution, c.resolution
+	if width > height && width > 0 {
+		ny = int(math.Max(2, math.Round(float64(c.resolution)*height/width)))
+	} else if height > 0 {
+		nx = int(math.Max(2, math.Round(float64(c.resolution)*width/height)))
+	}
+
+	grid := &contourGrid{
+		nx: nx, ny: ny,
+		x0: xRange[0], y0: yRange[0],
+		dx: (xRange[1] - xRange[0]) / float64(nx),
+		dy: (yRange[1] - yRange[0]) / float64(ny),
+	}
+	grid.values = make([]float64, (nx+1)*(ny+1))
+	for j := 0; j <= ny; j++ {
+		for i := 0; i <= nx; i++ {
+			grid.values[j*(nx+1)+i] = c.function(grid.x0+float64(i)*grid.dx, grid.y0+float64(j)*grid.dy)
+		}
+	}
+
+	for k, level := range c.levels {
+		lines := grid.trace(level)
+		for _, line := range lines {
+			for i, p := range line {
+				line[i] = c.axes.CoordinateToPoint(p)
+			}
+		}
+		c.parts[k].setPolylines(lines)
+	}
+	c.applyColormap()
+	return c
+}
+
+// Copy 复制等高线，与原对象共享坐标系和函数
+func (c *ContourPlot) Copy() core.Mobject {
+	return &ContourPlot{
+		PathGroup:  c.copyGroup(),
+		axes:       c.axes,
+		function:   c.function,
+		levels:     append([]float64(nil), c.levels...),
+		resolution: c.resolution,
+		colormap:   c.colormap,
+	}
+}
+
+// contourGrid marching squares 使用的采样网格，values 按行存储 (nx+1)×(ny+1) 个格点的函数值
+type contourGrid struct {
+	nx, ny int
+	x0, y0 float64
+	dx, dy float64
+	values []float64
+}
+
+func (g *contourGrid) value(i, j int) float64 {
+	return g.values[j*(g.nx+1)+i]
+}
+
+// 单元的四条边：下、右、上、左
+const (
+	edgeBottom = iota
+	edgeRight
+	edgeTop
+	edgeLeft
+)
+
+// 交点所在位置的种类，与格点编号一起组成交点的编号
+const (
+	keyHorizontal = iota // 水平边内部
+	keyVertical          // 竖直边内部
+	keyVertex            // 恰好落在格点上
+	keyKinds
+)
+
+// crossing 等值线与单元 (i, j) 某条边的交点，按两端函数值线性插值。
+// 返回的编号在相邻单元间一致：公共边上的交点编号相同，落在格点上的交点使用格点的编号，
+// 使经过格点的曲线也能连接起来
+func (g *contourGrid) crossing(i, j, edge int, level float64) (gmMath.Vector2, int) {
+	// 边的两个端点（格点坐标）
+	var a, b [2]int
+	switch edge {
+	case edgeBottom:
+		a, b = [2]int{i, j}, [2]int{i + 1, j}
+	case edgeRight:
+		a, b = [2]int{i + 1, j}, [2]int{i + 1, j + 1}
+	case edgeTop:
+		a, b = [2]int{i, j + 1}, [2]int{i + 1, j + 1}
+	default:
+		a, b = [2]int{i, j}, [2]int{i, j + 1}
+	}
+	va, vb := g.value(a[0], a[1]), g.value(b[0], b[1])
+	t := 0.5
+	if va != vb {
+		t = math.Max(0, math.Min(1, (level-va)/(vb-va)))
+	}
+	// 与格点只差舍入误差的交点算作落在格点上，避免同一位置出现两个交点
+	if t < 1e-9 {
+		t = 0
+	} else if t > 1-1e-9 {
+		t = 1
+	}
+	x := float64(a[0]) + t*float64(b[0]-a[0])
+	y := float64(a[1]) + t*float64(b[1]-a[1])
+	point := gmMath.Vector2{X: g.x0 + x*g.dx, Y: g.y0 + y*g.dy}
+
+	index := func(p [2]int) int { return p[1]*(g.nx+1) + p[0] }
+	switch {
+	case t == 0:
+		return point, index(a)*keyKinds + keyVertex
+	case t == 1:
+		return point, index(b)*keyKinds + keyVertex
+	case a[1] == b[1]:
+		return point, index(a)*keyKinds + keyHorizontal
+	default:
+		return point, index(a)*keyKinds + keyVertical
+	}
+}
+
+// trace 追踪 f = level，返回坐标系坐标中的折线，闭合曲线首尾相同
+func (g *contourGrid) trace(level float64) [][]gmMath.Vector2 {
+	points := make(map[int]gmMath.Vector2)
+	neighbors := make(map[int][]int)
+	var order []int // 按出现顺序记录交点，使结果稳定
+
+	connect := func(i, j, e1, e2 int) {
+		p1, k1 := g.crossing(i, j, e1, level)
+		p2, k2 := g.crossing(i, j, e2, level)
+		if k1 == k2 {
+			return // 两个交点都落在同一格点上，线段退化
+		}
+		for _, p := range [2]struct {
+			key   int
+			point gmMath.Vector2
+		}{{k1, p1}, {k2, p2}} {
+			if _, ok := points[p.key]; !ok {
+				points[p.key] = p.point
+				order = append(order, p.key)
+			}
+		}
+		neighbors[k1] = append(neighbors[k1], k2)
+		neighbors[k2] = append(neighbors[k2], k1)
+	}
+
+	for j := 0; j < g.ny; j++ {
+		for i := 0; i < g.nx; i++ {
+			v0, v1 := g.value(i, j), g.value(i+1, j)
+			v2, v3 := g.value(i+1, j+1), g.value(i, j+1)
+			if math.IsNaN(v0+v1+v2+v3) || math.IsInf(v0+v1+v2+v3, 0) {
+				continue
+			}
+			// 四个角（左下、右下、右上、左上）是否不低于 level
+			b0, b1, b2, b3 := v0 >= level, v1 >= level, v2 >= level, v3 >= level
+			var crossed []int
+			if b0 != b1 {
+				crossed = append(crossed, edgeBottom)
+			}
+			if b1 != b2 {
+				crossed = append(crossed, edgeRight)
+			}
+			if b2 != b3 {
+				crossed = append(crossed, edgeTop)
+			}
+			if b3 != b0 {
+				crossed = append(crossed, edgeLeft)
+			}
+
+			switch len(crossed) {
+			case 2:
+				connect(i, j, crossed[0], crossed[1])
+			case 4:
+				// 鞍点：按单元中心的值判断对角的两个角是否连通
+				center := (v0+v1+v2+v3)/4 >= level
+				if center == b0 {
+					// 左下与右上连通，曲线把右下角和左上角分别切开
+					connect(i, j, edgeBottom, edgeRight)
+					connect(i, j, edgeTop, edgeLeft)
+				} else {
+					connect(i, j, edgeLeft, edgeBottom)
+					connect(i, j, edgeRight, edgeTop)
+				}
+			}
+		}
+	}
+
+	used := make(map[[2]int]bool)
+	segment := func(a, b int) [2]int {
+		if a > b {
+			a, b = b, a
+		}
+		return [2]int{a, b}
+	}
+	walk := func(start int) []gmMath.Vector2 {
+		line := []gmMath.Vector2{points[start]}
+		for current := start; ; {
+			next := -1
+			for _, n := range neighbors[current] {
+				if !used[segment(current, n)] {
+					next = n
+					break
+				}
+			}
+			if next < 0 {
+				return line
+			}
+			used[segment(current, next)] = true
+			line = append(line, points[next])
+			current = next
+		}
+	}
+
+	var lines [][]gmMath.Vector2
+	// 先从端点出发追踪不闭合的曲线（在网格边界或无定义处终止），再追踪剩下的闭合曲线
+	for _, open := range []bool{true, false} {
+		for _, key := range order {
+			if open && len(neighbors[key]) != 1 {
+				continue
+			}
+			if line := walk(key); len(line) >= 2 {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,88 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+// sampleGrid 在 [x0, x1]×[y0, y1] 上按 n×n 个单元采样函数
+func sampleGrid(f func(x, y float64) float64, x0, x1, y0, y1 float64, n int) *contourGrid {
+	g := &contourGrid{
+		nx: n, ny: n,
+		x0: x0, y0: y0,
+		dx: (x1 - x0) / float64(n),
+		dy: (y1 - y0) / float64(n),
+	}
+	g.values = make([]float64, (n+1)*(n+1))
+	for j := 0; j <= n; j++ {
+		for i := 0; i <= n; i++ {
+			g.values[j*(n+1)+i] = f(x0+float64(i)*g.dx, y0+float64(j)*g.dy)
+		}
+	}
+	return g
+}
+
+func TestContourTrace(t *testing.T) {
+	circle := func(x, y float64) float64 { return math.Hypot(x, y) }
+	tests := []struct {
+		name      string
+		f         func(x, y float64) float64
+		level     float64
+		n         int
+		lines     int
+		closed    bool
+		tolerance float64 // 折线上的点到等值线的距离上限（以函数值计）
+	}{
+		{"circle", circle, 1, 40, 1, true, 1e-2},
+		{"level outside range", circle, -1, 40, 0, false, 0},
+		{"vertical line", func(x, y float64) float64 { return x }, 0.3, 20, 1, false, 1e-9},
+		{"line through grid vertices", func(x, y float64) float64 { return x }, 0, 20, 1, false, 1e-9},
+		{"two circles", func(x, y float64) float64 {
+			return math.Min(math.Hypot(x-1, y), math.Hypot(x+1, y))
+		}, 0.5, 80, 2, true, 1e-2},
+		{"saddle through the origin", func(x, y float64) float64 { return x * y }, 0, 20, 2, false, 1e-9},
+		{"undefined outside the unit disk", func(x, y float64) float64 {
+			return math.Sqrt(1 - x*x - y*y)
+		}, 0.5, 80, 1, true, 1e-2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := sampleGrid(tt.f, -2, 2, -2, 2, tt.n).trace(tt.level)
+			if len(lines) != tt.lines {
+				t.Fatalf("trace found %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, line := range lines {
+				if len(line) < 2 {
+					t.Fatalf("line %d has %d points", i, len(line))
+				}
+				if closed := line[0] == line[len(line)-1]; closed != tt.closed {
+					t.Errorf("line %d closed = %v, want %v", i, closed, tt.closed)
+				}
+				for k, p := range line {
+					if v := tt.f(p.X, p.Y); math.Abs(v-tt.level) > tt.tolerance {
+						t.Errorf("line %d point (%g, %g): f = %g, want %g", i, p.X, p.Y, v, tt.level)
+					}
+					if k > 0 && p == line[k-1] {
+						t.Errorf("line %d repeats point (%g, %g)", i, p.X, p.Y)
+					}
+				}
+				if !tt.closed {
+					for _, end := range []gmMath.Vector2{line[0], line[len(line)-1]} {
+						if !onGridBoundary(end, -2, 2) {
+							t.Errorf("line %d ends at (%g, %g) inside the grid", i, end.X, end.Y)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// onGridBoundary 点是否在正方形网格的边界上
+func onGridBoundary(p gmMath.Vector2, lo, hi float64) bool {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	return near(p.X, lo) || near(p.X, hi) || near(p.Y, lo) || near(p.Y, hi)
+}
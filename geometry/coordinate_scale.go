@@ -0,0 +1,249 @@
+package geometry
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	gmMath "render2go/math"
+	"strconv"
+)
+
+// AxisScale 坐标轴的刻度方式
+type AxisScale int
+
+const (
+	ScaleLinear AxisScale = iota // 线性刻度
+	ScaleLog                     // 以 10 为底的对数刻度，范围必须为正数
+)
+
+// forward 把坐标值变换到轴上的线性位置
+func (s AxisScale) forward(v float64) float64 {
+	if s == ScaleLog {
+		return math.Log10(v)
+	}
+	return v
+}
+
+// inverse forward 的逆变换
+func (s AxisScale) inverse(t float64) float64 {
+	if s == ScaleLog {
+		return math.Pow(10, t)
+	}
+	return t
+}
+
+// span 范围在轴上的线性长度
+func (s AxisScale) span(r [2]float64) float64 {
+	return s.forward(r[1]) - s.forward(r[0])
+}
+
+// AngleUnit 极坐标网格角度标签的单位
+type AngleUnit int
+
+const (
+	AngleDegrees AngleUnit = iota // 角度制，如 30°
+	AngleRadians                  // 弧度制，如 π/6
+)
+
+// 极坐标网格的默认参数
+const (
+	defaultAngleDivisions = 12   // 默认每 30° 一条射线
+	polarCircleSegments   = 96   // 同心圆的折线段数
+	polarLabelOffset      = 18.0 // 角度标签与最外层圆的距离
+)
+
+var (
+	gridColor      = color.RGBA{200, 200, 200, 128} // 网格线颜色
+	minorGridColor = color.RGBA{200, 200, 200, 64}  // 对数轴次网格线颜色
+)
+
+// axisGridValues 返回坐标轴上的主、次网格坐标值。
+// 线性轴按间距取值，没有次网格；对数轴的主网格为 10 的整数次幂，次网格为其 2~9 倍
+func axisGridValues(scale AxisScale, r [2]float64, spacing float64) (major, minor []float64) {
+	if scale != ScaleLog {
+		return gridValues(r, spacing), nil
+	}
+	if r[0] <= 0 || r[1] < r[0] {
+		return nil, nil
+	}
+	for k := math.Floor(math.Log10(r[0])); k <= math.Ceil(math.Log10(r[1])); k++ {
+		decade := math.Pow(10, k)
+		for m := 1.0; m <= 9; m++ {
+			v := m * decade
+			if v < r[0]*(1-1e-9) || v > r[1]*(1+1e-9) {
+				continue
+			}
+			if m == 1 {
+				major = append(major, v)
+			} else {
+				minor = append(minor, v)
+			}
+		}
+	}
+	return major, minor
+}
+
+// decadeLabelValues 对数轴上需要标注的 10 的整数次幂，超过 10 个时隔几个标注一次
+func decadeLabelValues(r [2]float64) []float64 {
+	decades, _ := axisGridValues(ScaleLog, r, 0)
+	stride := (len(decades) + 9) / 10
+	if stride <= 1 {
+		return decades
+	}
+	values := make([]float64, 0, len(decades)/stride+1)
+	for i := 0; i < len(decades); i += stride {
+		values = append(values, decades[i])
+	}
+	return values
+}
+
+// formatDecade 格式化 10 的整数次幂：较小的指数直接写出数字，否则写成 1e7 的形式
+func formatDecade(v float64) string {
+	k := math.Round(math.Log10(v))
+	if k >= -3 && k <= 6 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("1e%.0f", k)
+}
+
+// formatAngle 格式化把圆周 n 等分后第 k 条射线的角度
+func formatAngle(k, n int, unit AngleUnit) string {
+	if unit == AngleDegrees {
+		return formatNumber(360*float64(k)/float64(n)) + "°"
+	}
+	return formatPiMultiple(2 * float64(k) / float64(n))
+}
+
+// polarRadius 极坐标网格的最大半径：原点到坐标范围边界的最近距离，原点不在范围内时为 0
+func (cs *CoordinateSystem) polarRadius() float64 {
+	if !cs.originVisible() {
+		return 0
+	}
+	return math.Min(math.Min(cs.xRange[1], -cs.xRange[0]), math.Min(cs.yRange[1], -cs.yRange[0]))
+}
+
+// generatePolarGrid 生成极坐标网格：间距整数倍半径的同心圆和等分圆周的射线，
+// 与坐标轴重合的射线不重复绘制
+func (cs *CoordinateSystem) generatePolarGrid() {
+	rMax := cs.polarRadius()
+	if rMax <= 0 {
+		return
+	}
+	for _, r := range gridValues([2]float64{0, rMax}, cs.xSpacing) {
+		if r <= 0 {
+			continue
+		}
+		circle := NewPath()
+		for i := 0; i <= polarCircleSegments; i++ {
+			p := cs.PolarToPoint(r, 2*math.Pi*float64(i)/polarCircleSegments)
+			if i == 0 {
+				circle.NewSubpath(p)
+			} else {
+				circle.LineTo(p)
+			}
+		}
+		circle.SetStrokeColor(gridColor)
+		circle.SetStrokeWidth(0.5)
+		circle.SetFillOpacity(0)
+		cs.gridCurves = append(cs.gridCurves, circle)
+	}
+
+	n := cs.angleDivs
+	for k := 0; k < n; k++ {
+		if (4*k)%n == 0 { // 0°、90°、180°、270° 与坐标轴重合
+			continue
+		}
+		end := cs.PolarToPoint(rMax, 2*math.Pi*float64(k)/float64(n))
+		spoke := NewLine(gmMath.Vector2{X: cs.originX, Y: cs.originY}, end)
+		spoke.SetColor(gridColor)
+		spoke.SetStrokeWidth(0.5)
+		spoke.SetFillOpacity(1.0)
+		cs.gridLines = append(cs.gridLines, spoke)
+	}
+}
+
+// generatePolarLabels 生成极坐标标签：半径标在正 x 轴下方，角度标在最外层圆外侧
+func (cs *CoordinateSystem) generatePolarLabels() {
+	rMax := cs.polarRadius()
+	if rMax <= 0 {
+		return
+	}
+	for _, r := range gridValues([2]float64{0, rMax}, cs.xSpacing) {
+		if r <= 0 {
+			continue
+		}
+		cs.labels = append(cs.labels, cs.tickLabel(AxisX, r, cs.PolarToPoint(r, 0)))
+	}
+
+	n := cs.angleDivs
+	for k := 0; k < n; k++ {
+		theta := 2 * math.Pi * float64(k) / float64(n)
+		edge := cs.PolarToPoint(rMax, theta)
+		label := cs.newLabel(formatAngle(k, n, cs.angleUnit), cs.xTicks.size)
+		label.SetPosition(edge.X+polarLabelOffset*math.Cos(theta), edge.Y+polarLabelOffset*math.Sin(theta))
+		label.SetColor(color.RGBA{0, 0, 0, 255})
+		cs.labels = append(cs.labels, label)
+	}
+}
+
+// PolarToPoint 把极坐标 (r, θ) 转换为场景中的点，θ 为弧度，从正 x 轴逆时针计
+func (cs *CoordinateSystem) PolarToPoint(r, theta float64) gmMath.Vector2 {
+	return cs.CoordinateToPoint(gmMath.Vector2{X: r * math.Cos(theta), Y: r * math.Sin(theta)})
+}
+
+// PointToPolar 把场景中的点转换为极坐标 (r, θ)，θ 在 [0, 2π) 内
+func (cs *CoordinateSystem) PointToPolar(point gmMath.Vector2) (r, theta float64) {
+	c := cs.PointToCoordinate(point)
+	theta = math.Atan2(c.Y, c.X)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+	return math.Hypot(c.X, c.Y), theta
+}
+
+// SetScales 设置两条坐标轴的刻度方式，对数轴的范围必须为正数
+func (cs *CoordinateSystem) SetScales(xScale, yScale AxisScale) *CoordinateSystem {
+	cs.xScale, cs.yScale = xScale, yScale
+	cs.updateAxisLengths()
+	cs.generateComponents()
+	return cs
+}
+
+// Scales 获取两条坐标轴的刻度方式
+func (cs *CoordinateSystem) Scales() (xScale, yScale AxisScale) {
+	return cs.xScale, cs.yScale
+}
+
+// SetPolar 设置是否使用极坐标网格。坐标仍是直角坐标 (x, y)，
+// 用 PolarToPoint 按 (r, θ) 定位；极坐标网格只用于线性刻度
+func (cs *CoordinateSystem) SetPolar(polar bool) *CoordinateSystem {
+	cs.polar = polar
+	cs.generateComponents()
+	return cs
+}
+
+// Polar 是否使用极坐标网格
+func (cs *CoordinateSystem) Polar() bool {
+	return cs.polar
+}
+
+// SetAngleUnit 设置极坐标角度标签的单位
+func (cs *CoordinateSystem) SetAngleUnit(unit AngleUnit) *CoordinateSystem {
+	cs.angleUnit = unit
+	cs.generateComponents()
+	return cs
+}
+
+// SetAngleDivisions 设置极坐标射线把圆周等分的份数
+func (cs *CoordinateSystem) SetAngleDivisions(n int) *CoordinateSystem {
+	if n > 0 {
+		cs.angleDivs = n
+		cs.generateComponents()
+	}
+	return cs
+}
+
+// GetGridCurves 获取曲线网格（极坐标网格的同心圆）
+func (cs *CoordinateSystem) GetGridCurves() []*Path {
+	return cs.gridCurves
+}
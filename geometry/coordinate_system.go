@@ -11,23 +11,36 @@ import (
 // CoordinateSystem 坐标系组件
 type CoordinateSystem struct {
 	*core.BaseMobject
-	originX     float64    // 原点X坐标
-	originY     float64    // 原点Y坐标
-	xAxisLength float64    // X轴长度
-	yAxisLength float64    // Y轴长度
-	gridSpacing float64    // 网格间距
-	showGrid    bool       // 是否显示网格
-	showLabels  bool       // 是否显示标签
-	showOrigin  bool       // 是否显示原点
-	xRange      [2]float64 // X轴范围 [min, max]
-	yRange      [2]float64 // Y轴范围 [min, max]
+	originX     float64       // 坐标 (0, 0) 在场景中的X位置
+	originY     float64       // 坐标 (0, 0) 在场景中的Y位置
+	xUnit       float64       // X方向每单位对应的场景长度
+	yUnit       float64       // Y方向每单位对应的场景长度
+	xAxisLength float64       // X轴长度
+	yAxisLength float64       // Y轴长度
+	xSpacing    float64       // X方向网格间距
+	ySpacing    float64       // Y方向网格间距
+	showGrid    bool          // 是否显示网格
+	showLabels  bool          // 是否显示标签
+	showOrigin  bool          // 是否显示原点
+	xRange      [2]float64    // X轴范围 [min, max]
+	yRange      [2]float64    // Y轴范围 [min, max]
+	xScale      AxisScale     // X轴刻度方式
+	yScale      AxisScale     // Y轴刻度方式
+	polar       bool          // 是否使用极坐标网格
+	angleUnit   AngleUnit     // 极坐标角度标签的单位
+	angleDivs   int           // 极坐标射线等分圆周的份数
+	xTicks      axisTicks     // X轴刻度标签设置
+	yTicks      axisTicks     // Y轴刻度标签设置
+	labelSizes  textSizeRange // 标签字号的范围
+	revision    int           // 每次重新生成组件后递增
 
 	// 组件
-	xAxis     *Arrow  // X轴箭头
-	yAxis     *Arrow  // Y轴箭头
-	gridLines []*Line // 网格线
-	labels    []*Text // 标签
-	origin    *Circle // 原点标记
+	xAxis      *Arrow  // X轴箭头
+	yAxis      *Arrow  // Y轴箭头
+	gridLines  []*Line // 网格线
+	gridCurves []*Path // 曲线网格（极坐标同心圆）
+	labels     []*Text // 标签
+	origin     *Circle // 原点标记
 }
 
 // NewCoordinateSystem 创建坐标系
@@ -36,14 +49,21 @@ func NewCoordinateSystem(xRange, yRange [2]float64, spacing float64) *Coordinate
 		BaseMobject: core.NewBaseMobject(),
 		originX:     0,
 		originY:     0,
+		xUnit:       1,
+		yUnit:       1,
 		xAxisLength: xRange[1] - xRange[0],
 		yAxisLength: yRange[1] - yRange[0],
-		gridSpacing: spacing,
+		xSpacing:    spacing,
+		ySpacing:    spacing,
 		showGrid:    true,
 		showLabels:  true,
 		showOrigin:  true,
 		xRange:      xRange,
 		yRange:      yRange,
+		angleDivs:   defaultAngleDivisions,
+		xTicks:      defaultAxisTicks(),
+		yTicks:      defaultAxisTicks(),
+		labelSizes:  builtinSizeRange(),
 		gridLines:   make([]*Line, 0),
 		labels:      make([]*Text, 0),
 	}
@@ -78,7 +98,9 @@ func NewViewportCoordinateSystem(viewportWidth, viewportHeight float64) *Coordin
 
 // generateComponents 生成坐标系组件
 func (cs *CoordinateSystem) generateComponents() {
+	cs.revision++
 	cs.generateAxes()
+	cs.gridLines, cs.gridCurves = nil, nil
 	if cs.showGrid {
 		cs.generateGrid()
 	}
@@ -93,104 +115,191 @@ func (cs *CoordinateSystem) generateComponents() {
 
 // generateAxes 生成坐标轴
 func (cs *CoordinateSystem) generateAxes() {
+	axisX, axisY := cs.axisPosition()
+
 	// X轴
-	xStart := gmMath.Vector2{X: cs.xRange[0], Y: cs.originY}
-	xEnd := gmMath.Vector2{X: cs.xRange[1], Y: cs.originY}
-	cs.xAxis = NewArrow(xStart, xEnd)
+	xStart := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[0], Y: axisY})
+	xEnd := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[1], Y: axisY})
+	cs.xAxis = NewArrow(xStart, xEnd).SetHeadSize(axisHeadSize)
 	cs.xAxis.SetColor(cs.GetColor())
 	cs.xAxis.SetStrokeWidth(cs.GetStrokeWidth() * 1.5) // X轴稍粗一些
+	cs.xAxis.SetFillOpacity(1.0)                       // 线条以透明度作为描边不透明度
 
 	// Y轴
-	yStart := gmMath.Vector2{X: cs.originX, Y: cs.yRange[0]}
-	yEnd := gmMath.Vector2{X: cs.originX, Y: cs.yRange[1]}
-	cs.yAxis = NewArrow(yStart, yEnd)
+	yStart := cs.CoordinateToPoint(gmMath.Vector2{X: axisX, Y: cs.yRange[0]})
+	yEnd := cs.CoordinateToPoint(gmMath.Vector2{X: axisX, Y: cs.yRange[1]})
+	cs.yAxis = NewArrow(yStart, yEnd).SetHeadSize(axisHeadSize)
 	cs.yAxis.SetColor(cs.GetColor())
 	cs.yAxis.SetStrokeWidth(cs.GetStrokeWidth() * 1.5) // Y轴稍粗一些
+	cs.yAxis.SetFillOpacity(1.0)
+}
+
+// 坐标轴部件的场景尺寸，不随单位长度变化
+const (
+	axisHeadSize    = 10.0 // 箭头长度
+	axisLabelOffset = 14.0 // 刻度标签与坐标轴的距离
+	originRadius    = 3.0  // 原点标记半径
+)
+
+// axisPosition 返回两条坐标轴所在的坐标：0 在范围内时为 0，否则取最接近 0 的边界。
+// 对数轴上没有 0，另一条坐标轴放在范围的最小值处
+func (cs *CoordinateSystem) axisPosition() (x, y float64) {
+	x, y = clampRange(0, cs.xRange), clampRange(0, cs.yRange)
+	if cs.xScale == ScaleLog {
+		x = cs.xRange[0]
+	}
+	if cs.yScale == ScaleLog {
+		y = cs.yRange[0]
+	}
+	return x, y
+}
+
+func clampRange(v float64, r [2]float64) float64 {
+	return math.Max(r[0], math.Min(r[1], v))
+}
+
+// gridValues 返回范围内间距整数倍的坐标值
+func gridValues(r [2]float64, spacing float64) []float64 {
+	if spacing <= 0 || r[1] < r[0] {
+		return nil
+	}
+	values := make([]float64, 0)
+	for i := math.Ceil(r[0]/spacing - 1e-9); i*spacing <= r[1]+1e-9; i++ {
+		v := i * spacing
+		if v == 0 {
+			v = 0 // 避免 -0 显示为 "-0"
+		}
+		values = append(values, v)
+	}
+	return values
 }
 
 // generateGrid 生成网格线
 func (cs *CoordinateSystem) generateGrid() {
 	cs.gridLines = make([]*Line, 0)
+	if cs.polar {
+		cs.generatePolarGrid()
+		return
+	}
+
+	axisX, axisY := cs.axisPosition()
+	addLine := func(start, end gmMath.Vector2, c color.Color) {
+		gridLine := NewLine(cs.CoordinateToPoint(start), cs.CoordinateToPoint(end))
+		gridLine.SetColor(c)
+		gridLine.SetStrokeWidth(0.5)
+		gridLine.SetFillOpacity(1.0)
+		cs.gridLines = append(cs.gridLines, gridLine)
+	}
 
 	// 垂直网格线 (平行于Y轴)
-	for x := cs.xRange[0]; x <= cs.xRange[1]; x += cs.gridSpacing {
-		if math.Abs(x-cs.originX) < 1e-9 { // 跳过坐标轴
+	major, minor := axisGridValues(cs.xScale, cs.xRange, cs.xSpacing)
+	for _, x := range major {
+		if math.Abs(x-axisX) < 1e-9 { // 跳过坐标轴
 			continue
 		}
-		start := gmMath.Vector2{X: x, Y: cs.yRange[0]}
-		end := gmMath.Vector2{X: x, Y: cs.yRange[1]}
-		gridLine := NewLine(start, end)
-		gridLine.SetColor(color.RGBA{200, 200, 200, 128}) // 淡灰色半透明
-		gridLine.SetStrokeWidth(0.5)
-		cs.gridLines = append(cs.gridLines, gridLine)
+		addLine(gmMath.Vector2{X: x, Y: cs.yRange[0]}, gmMath.Vector2{X: x, Y: cs.yRange[1]}, gridColor)
+	}
+	for _, x := range minor {
+		addLine(gmMath.Vector2{X: x, Y: cs.yRange[0]}, gmMath.Vector2{X: x, Y: cs.yRange[1]}, minorGridColor)
 	}
 
 	// 水平网格线 (平行于X轴)
-	for y := cs.yRange[0]; y <= cs.yRange[1]; y += cs.gridSpacing {
-		if math.Abs(y-cs.originY) < 1e-9 { // 跳过坐标轴
+	major, minor = axisGridValues(cs.yScale, cs.yRange, cs.ySpacing)
+	for _, y := range major {
+		if math.Abs(y-axisY) < 1e-9 { // 跳过坐标轴
 			continue
 		}
-		start := gmMath.Vector2{X: cs.xRange[0], Y: y}
-		end := gmMath.Vector2{X: cs.xRange[1], Y: y}
-		gridLine := NewLine(start, end)
-		gridLine.SetColor(color.RGBA{200, 200, 200, 128}) // 淡灰色半透明
-		gridLine.SetStrokeWidth(0.5)
-		cs.gridLines = append(cs.gridLines, gridLine)
+		addLine(gmMath.Vector2{X: cs.xRange[0], Y: y}, gmMath.Vector2{X: cs.xRange[1], Y: y}, gridColor)
+	}
+	for _, y := range minor {
+		addLine(gmMath.Vector2{X: cs.xRange[0], Y: y}, gmMath.Vector2{X: cs.xRange[1], Y: y}, minorGridColor)
 	}
 }
 
-// generateLabels 生成坐标标签
+// generateLabels 生成坐标标签和坐标轴标题
 func (cs *CoordinateSystem) generateLabels() {
 	cs.labels = make([]*Text, 0)
-
-	// 计算标签间距 - 根据范围大小自动调整
-	labelSpacing := cs.gridSpacing
-	rangeSize := cs.xRange[1] - cs.xRange[0]
-	if rangeSize > 15 {
-		labelSpacing = cs.gridSpacing * 2 // 大范围时减少标签密度
-	} else if rangeSize > 30 {
-		labelSpacing = cs.gridSpacing * 5 // 超大范围时进一步减少
+	if cs.polar {
+		cs.generatePolarLabels()
+		cs.generateTitles()
+		return
 	}
+	axisX, axisY := cs.axisPosition()
 
-	// X轴标签
-	for x := cs.xRange[0]; x <= cs.xRange[1]; x += labelSpacing {
-		if math.Abs(x-cs.originX) < 1e-9 { // 跳过原点
+	// X轴标签，坐标轴交点处默认由原点标签代替
+	for _, x := range cs.tickValues(AxisX) {
+		if !cs.xTicks.showZero && cs.xScale == ScaleLinear && math.Abs(x-axisX) < 1e-9 {
 			continue
 		}
-		label := NewText(formatNumber(x), 12)
-		label.SetPosition(x, cs.originY-0.5)     // 稍微偏下
-		label.SetColor(color.RGBA{0, 0, 0, 255}) // 黑色
-		cs.labels = append(cs.labels, label)
+		p := cs.CoordinateToPoint(gmMath.Vector2{X: x, Y: axisY})
+		cs.labels = append(cs.labels, cs.tickLabel(AxisX, x, p))
 	}
 
 	// Y轴标签
-	for y := cs.yRange[0]; y <= cs.yRange[1]; y += labelSpacing {
-		if math.Abs(y-cs.originY) < 1e-9 { // 跳过原点
+	for _, y := range cs.tickValues(AxisY) {
+		if !cs.yTicks.showZero && cs.yScale == ScaleLinear && math.Abs(y-axisY) < 1e-9 {
 			continue
 		}
-		label := NewText(formatNumber(y), 12)
-		label.SetPosition(cs.originX-0.5, y)     // 稍微偏左
-		label.SetColor(color.RGBA{0, 0, 0, 255}) // 黑色
-		cs.labels = append(cs.labels, label)
+		p := cs.CoordinateToPoint(gmMath.Vector2{X: axisX, Y: y})
+		cs.labels = append(cs.labels, cs.tickLabel(AxisY, y, p))
 	}
 
-	// 原点标签
-	if cs.showOrigin {
-		originLabel := NewText("O", 14)
-		originLabel.SetPosition(cs.originX-0.3, cs.originY-0.3)
+	// 原点标签放在原点左下角，刻度较密、会与相邻的刻度标签重叠时省略
+	if cs.showOrigin && cs.originVisible() && !cs.xTicks.showZero && !cs.yTicks.showZero {
+		originLabel := cs.newLabel("O", 14)
+		originLabel.SetAnchor(AnchorTopRight)
+		originLabel.SetPosition(cs.originX-axisLabelOffset*0.3, cs.originY-axisLabelOffset*0.3)
 		originLabel.SetColor(color.RGBA{0, 0, 0, 255})
-		cs.labels = append(cs.labels, originLabel)
+		if !overlapsAny(originLabel, cs.labels) {
+			cs.labels = append(cs.labels, originLabel)
+		}
 	}
+	cs.generateTitles()
+}
+
+// textBounds 返回文本边界框（旋转后）的外接矩形
+func textBounds(t *Text) (min, max gmMath.Vector2) {
+	points := t.GetPoints()
+	if len(points) == 0 {
+		return
+	}
+	min, max = points[0], points[0]
+	for _, p := range points[1:] {
+		min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+		max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+	}
+	return
+}
+
+// overlapsAny 文本的外接矩形是否与其他任一文本的外接矩形相交
+func overlapsAny(t *Text, others []*Text) bool {
+	min, max := textBounds(t)
+	for _, other := range others {
+		lo, hi := textBounds(other)
+		if min.X < hi.X && lo.X < max.X && min.Y < hi.Y && lo.Y < max.Y {
+			return true
+		}
+	}
+	return false
 }
 
 // generateOrigin 生成原点标记
 func (cs *CoordinateSystem) generateOrigin() {
-	cs.origin = NewCircle(0.1)
+	cs.origin = nil
+	if !cs.originVisible() {
+		return
+	}
+	cs.origin = NewCircle(originRadius)
 	cs.origin.MoveTo(gmMath.Vector2{X: cs.originX, Y: cs.originY})
 	cs.origin.SetColor(color.RGBA{0, 0, 0, 255}) // 黑色
 	cs.origin.SetFillOpacity(1.0)                // 填充
 }
 
+// originVisible 坐标 (0, 0) 是否在范围内
+func (cs *CoordinateSystem) originVisible() bool {
+	return cs.IsInRange(0, 0)
+}
+
 // generatePoints 生成用于渲染的点集
 func (cs *CoordinateSystem) generatePoints() {
 	points := make([]gmMath.Vector2, 0)
@@ -206,12 +315,23 @@ func (cs *CoordinateSystem) generatePoints() {
 	for _, gridLine := range cs.gridLines {
 		points = append(points, gridLine.GetPoints()...)
 	}
+	for _, curve := range cs.gridCurves {
+		points = append(points, curve.Polyline()...)
+	}
 
 	if cs.origin != nil {
 		points = append(points, cs.origin.GetPoints()...)
 	}
 
-	cs.SetPoints(points)
+	cs.BaseMobject.SetPoints(points)
+}
+
+// formatTick 按刻度方式格式化刻度值
+func formatTick(scale AxisScale, v float64) string {
+	if scale == ScaleLog {
+		return formatDecade(v)
+	}
+	return formatNumber(v)
 }
 
 // formatNumber 格式化数字显示
@@ -272,14 +392,26 @@ func (cs *CoordinateSystem) SetShowOrigin(show bool) *CoordinateSystem {
 	return cs
 }
 
-// SetGridSpacing 设置网格间距
+// SetLabelSizeRange 设置刻度标签和坐标轴标题字号的范围，小于等于 0 的一端不限制，默认使用内置范围
+func (cs *CoordinateSystem) SetLabelSizeRange(min, max float64) *CoordinateSystem {
+	cs.labelSizes = textSizeRange{min, max}
+	cs.generateComponents()
+	return cs
+}
+
+// newLabel 创建使用坐标系标签字号范围的文本
+func (cs *CoordinateSystem) newLabel(text string, size float64) *Text {
+	return NewText(text, size).SetSizeRange(cs.labelSizes.min, cs.labelSizes.max)
+}
+
+// SetGridSpacing 设置两个方向的网格间距
 func (cs *CoordinateSystem) SetGridSpacing(spacing float64) *CoordinateSystem {
-	cs.gridSpacing = spacing
+	cs.xSpacing, cs.ySpacing = spacing, spacing
 	cs.generateComponents()
 	return cs
 }
 
-// SetOrigin 设置原点位置
+// SetOrigin 设置坐标 (0, 0) 在场景中的位置
 func (cs *CoordinateSystem) SetOrigin(x, y float64) *CoordinateSystem {
 	cs.originX = x
 	cs.originY = y
@@ -291,27 +423,187 @@ func (cs *CoordinateSystem) SetOrigin(x, y float64) *CoordinateSystem {
 func (cs *CoordinateSystem) SetRange(xMin, xMax, yMin, yMax float64) *CoordinateSystem {
 	cs.xRange = [2]float64{xMin, xMax}
 	cs.yRange = [2]float64{yMin, yMax}
-	cs.xAxisLength = xMax - xMin
-	cs.yAxisLength = yMax - yMin
+	cs.updateAxisLengths()
+	cs.generateComponents()
+	return cs
+}
+
+// updateAxisLengths 按范围和刻度方式更新两轴的长度（以单位长度计，对数轴以十倍程计）
+func (cs *CoordinateSystem) updateAxisLengths() {
+	cs.xAxisLength = cs.xScale.span(cs.xRange)
+	cs.yAxisLength = cs.yScale.span(cs.yRange)
+}
+
+// SetUnits 设置每单位对应的场景长度
+func (cs *CoordinateSystem) SetUnits(xUnit, yUnit float64) *CoordinateSystem {
+	if xUnit > 0 && yUnit > 0 {
+		cs.xUnit, cs.yUnit = xUnit, yUnit
+		cs.generateComponents()
+	}
+	return cs
+}
+
+// FitTo 调整单位长度使坐标范围连同伸出范围的标签占满 width×height 的区域，
+// 并把整体的中心放在场景原点。为标签留出空间时两轴按同一比例缩小，单位长度之比不变
+func (cs *CoordinateSystem) FitTo(width, height float64) *CoordinateSystem {
+	if cs.xAxisLength <= 0 || cs.yAxisLength <= 0 || width <= 0 || height <= 0 {
+		return cs
+	}
+	xCenter := (cs.xScale.forward(cs.xRange[0]) + cs.xScale.forward(cs.xRange[1])) / 2
+	yCenter := (cs.yScale.forward(cs.yRange[0]) + cs.yScale.forward(cs.yRange[1])) / 2
+	place := func(k, dx, dy float64) {
+		cs.xUnit = width / cs.xAxisLength * k
+		cs.yUnit = height / cs.yAxisLength * k
+		cs.originX = -xCenter*cs.xUnit + dx
+		cs.originY = -yCenter*cs.yUnit + dy
+		cs.generateComponents()
+	}
+	place(1, 0, 0)
+
+	// 标签离坐标轴的距离固定，缩小后伸出的长度基本不变，迭代两次即可收敛
+	for i := 0; i < 2; i++ {
+		left, right, bottom, top := cs.labelOverhang()
+		if left+right+bottom+top == 0 {
+			break
+		}
+		k := math.Min((width-left-right)/width, (height-bottom-top)/height)
+		if k <= 0 {
+			break
+		}
+		place(k, (left-right)/2, (bottom-top)/2)
+	}
+	return cs
+}
+
+// labelOverhang 返回标签伸出坐标范围四边的长度，没有伸出的一边为 0
+func (cs *CoordinateSystem) labelOverhang() (left, right, bottom, top float64) {
+	lo := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[0], Y: cs.yRange[0]})
+	hi := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[1], Y: cs.yRange[1]})
+	for _, label := range cs.labels {
+		min, max := textBounds(label)
+		left = math.Max(left, lo.X-min.X)
+		right = math.Max(right, max.X-hi.X)
+		bottom = math.Max(bottom, lo.Y-min.Y)
+		top = math.Max(top, max.Y-hi.Y)
+	}
+	return
+}
+
+// Units 获取每单位对应的场景长度，对数轴为每个十倍程对应的场景长度
+func (cs *CoordinateSystem) Units() (xUnit, yUnit float64) {
+	return cs.xUnit, cs.yUnit
+}
+
+// XRange 获取X轴范围
+func (cs *CoordinateSystem) XRange() [2]float64 {
+	return cs.xRange
+}
+
+// YRange 获取Y轴范围
+func (cs *CoordinateSystem) YRange() [2]float64 {
+	return cs.yRange
+}
+
+// GridSpacing 获取网格间距，两个方向不同时取较小的
+func (cs *CoordinateSystem) GridSpacing() float64 {
+	return math.Min(cs.xSpacing, cs.ySpacing)
+}
+
+// Revision 获取坐标系的版本号，原点、单位长度、范围或样式改变后递增，
+// 画在坐标系上的对象据此判断是否需要重新生成
+func (cs *CoordinateSystem) Revision() int {
+	return cs.revision
+}
+
+// GridSpacings 获取两个方向的网格间距
+func (cs *CoordinateSystem) GridSpacings() (xSpacing, ySpacing float64) {
+	return cs.xSpacing, cs.ySpacing
+}
+
+// 变换方法：坐标系由原点和单位长度决定，平移和缩放都换算为它们的变化，
+// 网格、刻度和用坐标系坐标定位的对象随之重新放置
+
+// GetCenter 返回坐标范围矩形的中心
+func (cs *CoordinateSystem) GetCenter() gmMath.Vector2 {
+	lo := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[0], Y: cs.yRange[0]})
+	hi := cs.CoordinateToPoint(gmMath.Vector2{X: cs.xRange[1], Y: cs.yRange[1]})
+	return lo.Add(hi).Scale(0.5)
+}
+
+// Shift 平移坐标系
+func (cs *CoordinateSystem) Shift(offset gmMath.Vector2) core.Mobject {
+	cs.originX += offset.X
+	cs.originY += offset.Y
 	cs.generateComponents()
 	return cs
 }
 
+// MoveTo 平移坐标系，使坐标范围的中心位于指定位置
+func (cs *CoordinateSystem) MoveTo(pos gmMath.Vector2) core.Mobject {
+	return cs.Shift(pos.Sub(cs.GetCenter()))
+}
+
+// Scale 以坐标范围的中心为基准缩放单位长度，刻度标签的字号不变
+func (cs *CoordinateSystem) Scale(factor float64) core.Mobject {
+	if factor <= 0 {
+		return cs
+	}
+	center := cs.GetCenter()
+	cs.originX = center.X + (cs.originX-center.X)*factor
+	cs.originY = center.Y + (cs.originY-center.Y)*factor
+	cs.xUnit *= factor
+	cs.yUnit *= factor
+	cs.generateComponents()
+	return cs
+}
+
+// Rotate 坐标系不支持旋转，保持不变
+func (cs *CoordinateSystem) Rotate(angle float64) core.Mobject {
+	return cs
+}
+
+// SetPoints 把坐标系变换到给定点集所在的位置和大小。
+// 缩放动画每帧先恢复初始点集再缩放，这里按新旧点集中X轴两端点之间的平移和缩放换算原点和单位长度；
+// 箭头、原点标记和刻度标签的大小不随单位长度变化，不参与换算
+func (cs *CoordinateSystem) SetPoints(points []gmMath.Vector2) {
+	old := cs.GetPoints()
+	if len(points) != len(old) || len(old) < 2 {
+		cs.BaseMobject.SetPoints(points)
+		return
+	}
+	// generatePoints 把X轴的起点和终点放在点集最前面
+	oldStart, newStart := old[0], points[0]
+	length := old[1].Distance(oldStart)
+	if length <= 0 {
+		cs.BaseMobject.SetPoints(points)
+		return
+	}
+	factor := points[1].Distance(newStart) / length
+	if factor <= 0 {
+		return
+	}
+	cs.originX = newStart.X + (cs.originX-oldStart.X)*factor
+	cs.originY = newStart.Y + (cs.originY-oldStart.Y)*factor
+	cs.xUnit *= factor
+	cs.yUnit *= factor
+	cs.generateComponents()
+}
+
 // 实用方法
 
 // PointToCoordinate 将屏幕点转换为坐标系坐标
 func (cs *CoordinateSystem) PointToCoordinate(point gmMath.Vector2) gmMath.Vector2 {
 	return gmMath.Vector2{
-		X: point.X - cs.originX,
-		Y: point.Y - cs.originY,
+		X: cs.xScale.inverse((point.X - cs.originX) / cs.xUnit),
+		Y: cs.yScale.inverse((point.Y - cs.originY) / cs.yUnit),
 	}
 }
 
-// CoordinateToPoint 将坐标系坐标转换为屏幕点
+// CoordinateToPoint 将坐标系坐标转换为屏幕点，对数轴上的非正坐标没有对应的点
 func (cs *CoordinateSystem) CoordinateToPoint(coord gmMath.Vector2) gmMath.Vector2 {
 	return gmMath.Vector2{
-		X: coord.X + cs.originX,
-		Y: coord.Y + cs.originY,
+		X: cs.xScale.forward(coord.X)*cs.xUnit + cs.originX,
+		Y: cs.yScale.forward(coord.Y)*cs.yUnit + cs.originY,
 	}
 }
 
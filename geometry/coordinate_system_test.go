@@ -0,0 +1,113 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+func TestCoordinateSystemLabelSizeRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		polar    bool
+		min, max float64
+	}{
+		{"raises small tick labels", false, 20, 40},
+		{"limits large labels", false, 4, 10},
+		{"polar labels", true, 20, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCoordinateSystem([2]float64{-3, 3}, [2]float64{-3, 3}, 1)
+			cs.SetAxisTitle(AxisX, "x").SetAxisTitle(AxisY, "y")
+			if tt.polar {
+				cs.SetPolar(true)
+			}
+			cs.SetLabelSizeRange(tt.min, tt.max)
+
+			if len(cs.labels) == 0 {
+				t.Fatal("coordinate system has no labels")
+			}
+			for _, label := range cs.labels {
+				if size := label.RenderSize(); size < tt.min || size > tt.max {
+					t.Errorf("label %q size = %g, want within [%g, %g]", label.GetText(), size, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinateSystemOriginLabel(t *testing.T) {
+	tests := []struct {
+		unit     float64
+		wantOrig bool
+	}{
+		{60, true},
+		{30, true},
+		{20, false},
+		{10, false},
+	}
+
+	for _, tt := range tests {
+		cs := NewCoordinateSystem([2]float64{-3, 3}, [2]float64{-3, 3}, 1)
+		cs.SetUnits(tt.unit, tt.unit)
+
+		var origin *Text
+		for _, label := range cs.labels {
+			if label.GetText() == "O" {
+				origin = label
+			}
+		}
+		if (origin != nil) != tt.wantOrig {
+			t.Errorf("unit %g: origin label shown = %v, want %v", tt.unit, origin != nil, tt.wantOrig)
+		}
+		if origin == nil {
+			continue
+		}
+		for _, label := range cs.labels {
+			if label != origin && overlapsAny(origin, []*Text{label}) {
+				lo, hi := textBounds(label)
+				t.Errorf("unit %g: origin label overlaps %q at %v-%v", tt.unit, label.GetText(), lo, hi)
+			}
+		}
+	}
+}
+
+func TestCoordinateSystemFitToIncludesLabels(t *testing.T) {
+	const size = 400.0
+	tests := []struct {
+		name  string
+		polar bool
+		x, y  [2]float64
+	}{
+		{"polar", true, [2]float64{-3, 3}, [2]float64{-3, 3}},
+		{"first quadrant", false, [2]float64{0, 5}, [2]float64{0, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCoordinateSystem(tt.x, tt.y, 1)
+			cs.SetPolar(tt.polar)
+			cs.FitTo(size, size)
+
+			if xUnit, yUnit := cs.Units(); math.Abs(xUnit-yUnit) > 1e-9 {
+				t.Errorf("units = %g, %g, want equal units for a square range", xUnit, yUnit)
+			}
+			lo, hi := gmMath.Vector2{X: math.Inf(1), Y: math.Inf(1)}, gmMath.Vector2{X: math.Inf(-1), Y: math.Inf(-1)}
+			for _, label := range cs.labels {
+				min, max := textBounds(label)
+				lo.X, lo.Y = math.Min(lo.X, min.X), math.Min(lo.Y, min.Y)
+				hi.X, hi.Y = math.Max(hi.X, max.X), math.Max(hi.Y, max.Y)
+				if min.X < -size/2-1e-6 || max.X > size/2+1e-6 || min.Y < -size/2-1e-6 || max.Y > size/2+1e-6 {
+					t.Errorf("label %q at %v-%v is outside the fitted %gx%g area", label.GetText(), min, max, size, size)
+				}
+			}
+			// 标签伸出最多的一侧贴近区域边缘，空间没有浪费
+			if spare := size/2 - math.Max(math.Max(-lo.X, hi.X), math.Max(-lo.Y, hi.Y)); spare > 2 {
+				t.Errorf("labels leave %g of the fitted area unused", spare)
+			}
+		})
+	}
+}
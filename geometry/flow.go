@@ -0,0 +1,184 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/colors"
+	gmMath "render2go/math"
+	"sort"
+)
+
+// 沿向量场积分和流动粒子的参数
+const (
+	flowStepsPerSpacing = 8    // 积分时每个网格间距内的步数，步长随速度调整使每步移动的距离大致相同
+	flowParticleLife    = 3.0  // 向量场中的粒子从出发点运动的时长（秒），之后回到出发点重新出发
+	flowTrailTime       = 0.4  // 粒子拖尾覆盖的时长（秒）
+	flowTrailWidth      = 1.5  // 拖尾线宽相对于对象线宽的倍数
+	flowMaxSteps        = 4000 // 每条轨迹单向积分的最多步数
+)
+
+// flowField 坐标系中的二维向量场，积分在坐标系坐标中进行
+type flowField struct {
+	axes     *CoordinateSystem
+	function func(x, y float64) gmMath.Vector2
+}
+
+// at 返回 p 处的向量，无定义时返回 false
+func (f flowField) at(p gmMath.Vector2) (gmMath.Vector2, bool) {
+	v := f.function(p.X, p.Y)
+	ok := !math.IsNaN(v.X) && !math.IsNaN(v.Y) && !math.IsInf(v.X, 0) && !math.IsInf(v.Y, 0)
+	return v, ok
+}
+
+// rk4 从 p 出发以时间步长 h 做一步四阶 Runge-Kutta 积分
+func (f flowField) rk4(p gmMath.Vector2, h float64) (gmMath.Vector2, bool) {
+	k1, ok1 := f.at(p)
+	k2, ok2 := f.at(p.Add(k1.Scale(h / 2)))
+	k3, ok3 := f.at(p.Add(k2.Scale(h / 2)))
+	k4, ok4 := f.at(p.Add(k3.Scale(h)))
+	if !(ok1 && ok2 && ok3 && ok4) {
+		return gmMath.Vector2{}, false
+	}
+	sum := k1.Add(k2.Scale(2)).Add(k3.Scale(2)).Add(k4)
+	return p.Add(sum.Scale(h / 6)), true
+}
+
+// flowSample 积分轨迹上的一点，p 为场景坐标，speed 为该处向量的长度
+type flowSample struct {
+	t     float64
+	p     gmMath.Vector2
+	speed float64
+}
+
+// integrate 从坐标 start 出发沿场积分（direction 为 -1 时逆着场），每步在坐标系中移动约 step，
+// 直到离开坐标系范围、进入无定义处或驻点、超过 maxTime，或 stop 返回 true。
+// 返回的轨迹按时间排列，时间从 0 开始，点已转换为场景坐标
+func (f flowField) integrate(start gmMath.Vector2, direction, step, maxTime float64, stop func(p gmMath.Vector2, index int) bool) []flowSample {
+	const minSpeed = 1e-9
+	var samples []flowSample
+	p, t := start, 0.0
+	for index := 0; index < flowMaxSteps; index++ {
+		v, ok := f.at(p)
+		if !ok || !f.axes.IsInRange(p.X, p.Y) {
+			break
+		}
+		point := f.axes.CoordinateToPoint(p)
+		if stop != nil && stop(point, index) {
+			break
+		}
+		speed := v.Length()
+		samples = append(samples, flowSample{t: t, p: point, speed: speed})
+		if speed < minSpeed || t >= maxTime {
+			break
+		}
+		h := math.Min(step/speed, maxTime-t)
+		if p, ok = f.rk4(p, direction*h); !ok {
+			break
+		}
+		t += h
+	}
+	return samples
+}
+
+// flowParticles 沿向量场运动的粒子：每条轨迹预先积分，按流动时间截取其中一段作为带拖尾的粒子。
+// 轨迹比较长时同一条轨迹上有多个粒子，按时间等距排列
+type flowParticles struct {
+	paths    [][]flowSample
+	maxSpeed float64 // 所有轨迹上的最大速度，用于按色图着色
+	time     float64 // 当前流动时间（已乘以流动速度）
+	trails   []*Path
+}
+
+// add 加入一条轨迹
+func (f *flowParticles) add(path []flowSample) {
+	f.paths = append(f.paths, path)
+	for _, s := range path {
+		f.maxSpeed = math.Max(f.maxSpeed, s.speed)
+	}
+}
+
+// transform 对所有轨迹点做同一变换，使对象移动、缩放后粒子仍沿变换后的轨迹运动
+func (f *flowParticles) transform(fn func(gmMath.Vector2) gmMath.Vector2) {
+	for _, path := range f.paths {
+		for i := range path {
+			path[i].p = fn(path[i].p)
+		}
+	}
+	for _, trail := range f.trails {
+		points := trail.GetPoints()
+		for i := range points {
+			points[i] = fn(points[i])
+		}
+	}
+}
+
+// update 按流动时间 t 重新截取各粒子的拖尾，颜色取自色图（按粒子所在处的速度）或 fallback
+func (f *flowParticles) update(t float64, colormap *colors.Colormap, fallback color.Color, strokeWidth float64) {
+	f.time = t
+	f.trails = f.trails[:0]
+	for i, path := range f.paths {
+		if len(path) < 2 {
+			continue
+		}
+		duration := path[len(path)-1].t
+		period := duration + flowTrailTime
+		count := int(math.Max(1, math.Round(duration/flowParticleLife)))
+		// 不同轨迹的粒子错开出发，避免同时回到起点
+		phase := math.Mod(float64(i)*0.618033988749895, 1) * period
+		for j := 0; j < count; j++ {
+			head := math.Mod(t+phase+float64(j)*period/float64(count), period)
+			points, speed := trailPoints(path, head-flowTrailTime, head)
+			if len(points) < 2 {
+				continue
+			}
+			trail := NewPath()
+			trail.setPolylines([][]gmMath.Vector2{points})
+			if colormap != nil && f.maxSpeed > 0 {
+				trail.SetColor(colormap.At(speed / f.maxSpeed))
+			} else {
+				trail.SetColor(fallback)
+			}
+			trail.SetStrokeWidth(strokeWidth * flowTrailWidth)
+			f.trails = append(f.trails, trail)
+		}
+	}
+}
+
+// copyParticles 复制粒子轨迹和当前拖尾
+func (f *flowParticles) copyParticles() *flowParticles {
+	c := &flowParticles{maxSpeed: f.maxSpeed, time: f.time}
+	for _, path := range f.paths {
+		c.paths = append(c.paths, append([]flowSample(nil), path...))
+	}
+	for _, trail := range f.trails {
+		c.trails = append(c.trails, trail.Copy().(*Path))
+	}
+	return c
+}
+
+// trailPoints 截取轨迹在时间 [from, to] 内的部分，两端按时间插值；同时返回 to 处（粒子头部）的速度
+func trailPoints(path []flowSample, from, to float64) ([]gmMath.Vector2, float64) {
+	end := path[len(path)-1].t
+	from, to = math.Max(from, 0), math.Min(to, end)
+	if from >= to {
+		return nil, 0
+	}
+	at := func(t float64) (gmMath.Vector2, float64, int) {
+		i := sort.Search(len(path), func(i int) bool { return path[i].t >= t })
+		if i == 0 {
+			return path[0].p, path[0].speed, 0
+		}
+		a, b := path[i-1], path[i]
+		f := (t - a.t) / (b.t - a.t)
+		return gmMath.LerpVector2(a.p, b.p, f), a.speed + (b.speed-a.speed)*f, i
+	}
+	start, _, i := at(from)
+	head, speed, j := at(to)
+	points := []gmMath.Vector2{start}
+	for ; i < j; i++ {
+		if path[i].t > from {
+			points = append(points, path[i].p)
+		}
+	}
+	return append(points, head), speed
+}
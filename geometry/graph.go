@@ -0,0 +1,228 @@
+package geometry
+
+import (
+	"math"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// 自适应采样参数
+const (
+	curveInitialSamples = 64   // 默认的初始均匀采样区间数
+	curveMaxDepth       = 10   // 每个初始区间最多细分的层数
+	curveTolerance      = 0.25 // 区间中点偏离弦的容差（场景长度）
+	curveJumpLength     = 20.0 // 细分到最深仍长于此值的线段视为跳跃间断（场景长度）
+)
+
+// curveSample 曲线上的一个采样点
+type curveSample struct {
+	t  float64
+	p  gmMath.Vector2
+	ok bool // 该处有定义且在显示范围内
+}
+
+// curveSampler 自适应采样参数曲线，结果为若干条折线
+type curveSampler struct {
+	point   func(t float64) (gmMath.Vector2, bool)
+	lines   [][]gmMath.Vector2
+	current []gmMath.Vector2
+}
+
+// sampleCurve 在 [t0, t1] 上先均匀取 samples 个区间，再自适应采样参数曲线 point(t)，返回若干折线。
+// point 返回 false 表示该处无定义或超出显示范围，曲线在此断开；
+// 区间中点偏离弦较远（曲率大）时继续细分，细分到最深仍然很长的线段视为跳跃间断，不连接。
+func sampleCurve(point func(t float64) (gmMath.Vector2, bool), t0, t1 float64, samples int) [][]gmMath.Vector2 {
+	s := &curveSampler{point: point}
+	prev := s.sample(t0)
+	if prev.ok {
+		s.start(prev.p)
+	}
+	for i := 1; i <= samples; i++ {
+		next := s.sample(t0 + (t1-t0)*float64(i)/float64(samples))
+		s.refine(prev, next, 0)
+		prev = next
+	}
+	s.breakLine()
+	return s.lines
+}
+
+func (s *curveSampler) sample(t float64) curveSample {
+	p, ok := s.point(t)
+	ok = ok && !math.IsNaN(p.X) && !math.IsNaN(p.Y) && !math.IsInf(p.X, 0) && !math.IsInf(p.Y, 0)
+	return curveSample{t: t, p: p, ok: ok}
+}
+
+// refine 处理区间 (a, b]：需要时二分，否则把 b 接到当前折线上
+func (s *curveSampler) refine(a, b curveSample, depth int) {
+	if depth < curveMaxDepth {
+		m := s.sample((a.t + b.t) / 2)
+		if needsSplit(a, m, b) {
+			s.refine(a, m, depth+1)
+			s.refine(m, b, depth+1)
+			return
+		}
+	}
+
+	switch {
+	case a.ok && b.ok:
+		if depth >= curveMaxDepth && b.p.Sub(a.p).Length() > curveJumpLength {
+			s.start(b.p)
+		} else {
+			s.lineTo(b.p)
+		}
+	case b.ok:
+		s.start(b.p) // 重新进入定义域或显示范围
+	case a.ok:
+		s.breakLine() // 离开定义域或显示范围
+	}
+}
+
+// needsSplit 区间两端与中点的有效性不一致（用于定位断点）、中点偏离弦超过容差，
+// 或弦过长（连续曲线细分后弦会变短，跳跃间断则不会）
+func needsSplit(a, m, b curveSample) bool {
+	if a.ok != b.ok || a.ok != m.ok {
+		return true
+	}
+	if !a.ok {
+		return false
+	}
+	return b.p.Sub(a.p).Length() > curveJumpLength || distanceToSegment(m.p, a.p, b.p) > curveTolerance
+}
+
+// distanceToSegment 点 p 到线段 ab 的距离
+func distanceToSegment(p, a, b gmMath.Vector2) float64 {
+	ab := b.Sub(a)
+	lengthSq := ab.X*ab.X + ab.Y*ab.Y
+	if lengthSq == 0 {
+		return p.Sub(a).Length()
+	}
+	t := math.Max(0, math.Min(1, ((p.X-a.X)*ab.X+(p.Y-a.Y)*ab.Y)/lengthSq))
+	return p.Sub(a.Add(ab.Scale(t))).Length()
+}
+
+func (s *curveSampler) start(p gmMath.Vector2) {
+	s.breakLine()
+	s.current = []gmMath.Vector2{p}
+}
+
+func (s *curveSampler) lineTo(p gmMath.Vector2) {
+	if s.current == nil {
+		s.current = []gmMath.Vector2{p}
+		return
+	}
+	s.current = append(s.current, p)
+}
+
+// breakLine 结束当前折线，只有一个点的折线被丢弃
+func (s *curveSampler) breakLine() {
+	if len(s.current) >= 2 {
+		s.lines = append(s.lines, s.current)
+	}
+	s.current = nil
+}
+
+// setPolylines 用若干折线替换路径的全部内容，每条折线为一个子路径，首尾相同的折线闭合
+func (p *Path) setPolylines(lines [][]gmMath.Vector2) {
+	p.commands = p.commands[:0]
+	var points []gmMath.Vector2
+	for _, line := range lines {
+		closed := len(line) > 2 && line[0] == line[len(line)-1]
+		if closed {
+			line = line[:len(line)-1]
+		}
+		p.commands = append(p.commands, PathMove)
+		for range line[1:] {
+			p.commands = append(p.commands, PathLine)
+		}
+		if closed {
+			p.commands = append(p.commands, PathClose)
+		}
+		points = append(points, line...)
+	}
+	p.SetPoints(points)
+}
+
+// FunctionGraph 坐标系中的函数图像 y = f(x)。曲线按坐标系的 CoordinateToPoint 放置，
+// 超出坐标系 y 范围或无定义的部分不绘制，间断处断开而不画竖直连线
+type FunctionGraph struct {
+	*Path
+	axes     *CoordinateSystem
+	function func(x float64) float64
+	xRange   [2]float64
+	opacity  float64 // 整体透明度，曲线本身从不填充
+}
+
+// NewFunctionGraph 在坐标系 axes 中绘制 function 在 [xMin, xMax] 上的图像，
+// x 范围会被限制在坐标系的 x 范围内
+func NewFunctionGraph(axes *CoordinateSystem, function func(x float64) float64, xMin, xMax float64) *FunctionGraph {
+	graph := &FunctionGraph{
+		Path:     NewPath(),
+		axes:     axes,
+		function: function,
+		xRange:   [2]float64{xMin, xMax},
+		opacity:  1.0,
+	}
+	graph.Generate()
+	return graph
+}
+
+// Axes 获取所在的坐标系
+func (g *FunctionGraph) Axes() *CoordinateSystem {
+	return g.axes
+}
+
+// Function 获取函数
+func (g *FunctionGraph) Function() func(x float64) float64 {
+	return g.function
+}
+
+// XRange 获取绘制的 x 范围
+func (g *FunctionGraph) XRange() [2]float64 {
+	return g.xRange
+}
+
+// Generate 按坐标系当前的范围和单位重新采样曲线
+func (g *FunctionGraph) Generate() *FunctionGraph {
+	axesX, yRange := g.axes.XRange(), g.axes.YRange()
+	xMin := math.Max(math.Min(g.xRange[0], g.xRange[1]), axesX[0])
+	xMax := math.Min(math.Max(g.xRange[0], g.xRange[1]), axesX[1])
+	if xMin >= xMax {
+		g.setPolylines(nil)
+		return g
+	}
+
+	// 允许极小的越界，避免恰好落在边界上的点被舍弃。
+	// 在 x 轴的线性位置上均匀采样，对数轴上每个十倍程的采样同样密
+	margin := (yRange[1] - yRange[0]) * 1e-9
+	xScale, _ := g.axes.Scales()
+	g.setPolylines(sampleCurve(func(t float64) (gmMath.Vector2, bool) {
+		x := xScale.inverse(t)
+		y := g.function(x)
+		if !(y >= yRange[0]-margin && y <= yRange[1]+margin) {
+			return gmMath.Vector2{}, false
+		}
+		return g.axes.CoordinateToPoint(gmMath.Vector2{X: x, Y: y}), true
+	}, xScale.forward(xMin), xScale.forward(xMax), curveInitialSamples))
+	return g
+}
+
+// GetFillOpacity 获取整体透明度
+func (g *FunctionGraph) GetFillOpacity() float64 {
+	return g.opacity
+}
+
+// SetFillOpacity 设置整体透明度，淡入淡出动画通过它改变曲线的不透明度
+func (g *FunctionGraph) SetFillOpacity(opacity float64) {
+	g.opacity = opacity
+}
+
+// Copy 复制图像，与原图像共享坐标系和函数
+func (g *FunctionGraph) Copy() core.Mobject {
+	return &FunctionGraph{
+		Path:     g.Path.Copy().(*Path),
+		axes:     g.axes,
+		function: g.function,
+		xRange:   g.xRange,
+		opacity:  g.opacity,
+	}
+}
@@ -0,0 +1,162 @@
+package geometry
+
+import (
+	"image"
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"os"
+	"render2go/core"
+	"render2go/internal/i18n"
+	gmMath "render2go/math"
+	"strings"
+)
+
+// ImageFilter 图像缩放时的采样方式
+type ImageFilter int
+
+// 采样方式
+const (
+	FilterBilinear ImageFilter = iota // 双线性插值，适合照片
+	FilterNearest                     // 最近邻，像素画和截图放大时保持锐利
+)
+
+// ParseImageFilter 解析采样方式名称：bilinear 或 nearest
+func ParseImageFilter(name string) (ImageFilter, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "bilinear", "linear", "smooth":
+		return FilterBilinear, nil
+	case "nearest", "pixel":
+		return FilterNearest, nil
+	}
+	return FilterBilinear, i18n.Errorf("image.invalid_filter", name)
+}
+
+// String 返回采样方式名称
+func (f ImageFilter) String() string {
+	if f == FilterNearest {
+		return "nearest"
+	}
+	return "bilinear"
+}
+
+// LoadImage 读取 PNG 或 JPEG 文件并创建图像对象。
+// width 和 height 都为 0 时使用像素尺寸，只给出一个时按原图比例计算另一个。
+func LoadImage(filename string, width, height float64) (*Image, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, i18n.Errorf("image.read_failed", filename, err)
+	}
+	defer file.Close()
+
+	data, _, err := image.Decode(file)
+	if err != nil {
+		return nil, i18n.Errorf("image.decode_failed", filename, err)
+	}
+
+	width, height = fitImageSize(data.Bounds(), width, height)
+	img := NewImageFromFile(filename, width, height)
+	img.SetImageData(data)
+	return img, nil
+}
+
+// fitImageSize 按像素区域的比例补全缺省的宽度或高度
+func fitImageSize(bounds image.Rectangle, width, height float64) (float64, float64) {
+	pw, ph := float64(bounds.Dx()), float64(bounds.Dy())
+	switch {
+	case pw <= 0 || ph <= 0:
+	case width <= 0 && height <= 0:
+		width, height = pw, ph
+	case width <= 0:
+		width = height * pw / ph
+	case height <= 0:
+		height = width * ph / pw
+	}
+	return width, height
+}
+
+// Source 返回要显示的像素区域：裁剪区域与图像范围的交集
+func (img *Image) Source() image.Rectangle {
+	if img.imageData == nil {
+		return image.Rectangle{}
+	}
+	bounds := img.imageData.Bounds()
+	if img.crop.Empty() {
+		return bounds
+	}
+	return img.crop.Add(bounds.Min).Intersect(bounds)
+}
+
+// Crop 获取裁剪区域（像素坐标，相对图像左上角），空表示不裁剪
+func (img *Image) Crop() image.Rectangle {
+	return img.crop
+}
+
+// SetCrop 只显示图像的一部分，区域为相对左上角的像素坐标。
+// 图像保持高度和中心，宽度按裁剪区域的比例调整；传入空区域取消裁剪。
+func (img *Image) SetCrop(crop image.Rectangle) *Image {
+	img.crop = crop.Canon()
+	if source := img.Source(); !source.Empty() {
+		_, height := img.GetDimensions()
+		img.SetSize(fitImageSize(source, 0, height))
+	}
+	return img
+}
+
+// Filter 获取采样方式
+func (img *Image) Filter() ImageFilter {
+	return img.filter
+}
+
+// SetFilter 设置采样方式
+func (img *Image) SetFilter(filter ImageFilter) *Image {
+	img.filter = filter
+	return img
+}
+
+// SetSize 设置显示宽高，保持中心和旋转角度
+func (img *Image) SetSize(width, height float64) *Image {
+	img.width, img.height = width, height
+	points := img.GetPoints()
+	if len(points) < 4 {
+		img.generateBounds()
+		return img
+	}
+
+	center := img.GetCenter()
+	axisX := points[1].Sub(points[0]).Normalize()
+	axisY := points[3].Sub(points[0]).Normalize()
+	if axisX.Length() == 0 || axisY.Length() == 0 {
+		axisX, axisY = gmMath.Vector2{X: 1}, gmMath.Vector2{Y: 1}
+	}
+	dx, dy := axisX.Scale(width/2), axisY.Scale(height/2)
+	img.SetPoints([]gmMath.Vector2{
+		center.Sub(dx).Sub(dy), // 左下
+		center.Add(dx).Sub(dy), // 右下
+		center.Add(dx).Add(dy), // 右上
+		center.Sub(dx).Add(dy), // 左上
+	})
+	return img
+}
+
+// Corners 返回左上、右上、左下三个角的逻辑坐标，旋转和缩放后同样适用
+func (img *Image) Corners() (topLeft, topRight, bottomLeft gmMath.Vector2) {
+	points := img.GetPoints()
+	if len(points) < 4 {
+		return
+	}
+	return points[3], points[2], points[0]
+}
+
+// Copy 复制图像对象，共享像素数据
+func (img *Image) Copy() core.Mobject {
+	width, height := img.GetDimensions()
+	copied := NewImageFromData(img.imageData, 0, 0, width, height)
+	copied.filename = img.filename
+	copied.crop = img.crop
+	copied.filter = img.filter
+	copied.SetPoints(img.GetPoints())
+	copied.SetColor(img.GetColor())
+	copied.SetStrokeWidth(img.GetStrokeWidth())
+	copied.SetFillOpacity(img.GetFillOpacity())
+	return copied
+}
@@ -0,0 +1,543 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"regexp"
+	"render2go/core"
+	"render2go/fonts"
+	gmMath "render2go/math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+)
+
+// Markdown 按 Markdown 子集排版的文本对象：标题、粗体、斜体、行内代码、
+// 无序和有序列表以及换行，适合幻灯片式的要点文本
+type Markdown struct {
+	*core.BaseMobject
+	source      string
+	size        float64 // 正文字号
+	position    gmMath.Vector2
+	font        string // 正文字体族，行内代码固定使用等宽字体
+	maxWidth    float64
+	lineSpacing float64
+	anchor      TextAnchor
+	sizeRange   textSizeRange // 正文字号的范围
+}
+
+// NewMarkdown 创建 Markdown 文本对象
+func NewMarkdown(source string, size float64) *Markdown {
+	md := &Markdown{
+		BaseMobject: core.NewBaseMobject(),
+		source:      source,
+		size:        size,
+		lineSpacing: 1.2,
+		anchor:      AnchorCenter,
+		sizeRange:   builtinSizeRange(),
+	}
+	md.SetColor(color.RGBA{0, 0, 0, 255})
+	md.SetFillOpacity(1.0)
+	md.generateBounds()
+	return md
+}
+
+// MarkdownRun 排版后的一段同样式文本
+type MarkdownRun struct {
+	Text     string
+	Face     font.Face
+	X        float64 // 相对边界框左边缘
+	Baseline float64 // 相对边界框上边缘，向下为正
+	Width    float64
+	Ascent   float64
+	Descent  float64
+	Code     bool // 行内代码，渲染时绘制底色
+}
+
+// MarkdownLayout Markdown 排版结果，尺寸单位为像素
+type MarkdownLayout struct {
+	Runs   []MarkdownRun
+	Width  float64
+	Height float64
+}
+
+// mdSpan 行内样式片段
+type mdSpan struct {
+	text         string
+	bold, italic bool
+	code         bool
+	lineBreak    bool // 强制换行
+}
+
+// mdBlock 块级元素
+type mdBlock struct {
+	kind   string // "paragraph"、"heading" 或 "item"
+	level  int    // 标题级别或列表缩进层级
+	marker string // 列表标记：• 或 "1."
+	spans  []mdSpan
+}
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrdered = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+)
+
+// parseMarkdownBlocks 按行解析块级元素，连续的非空行合并为同一段落或列表项
+func parseMarkdownBlocks(source string) []mdBlock {
+	var blocks []mdBlock
+	var current *mdBlock
+	hardBreak := false
+
+	appendText := func(text string) {
+		spans := parseMarkdownInline(text)
+		if len(current.spans) > 0 {
+			if hardBreak {
+				current.spans = append(current.spans, mdSpan{lineBreak: true})
+			} else {
+				current.spans = append(current.spans, mdSpan{text: " "})
+			}
+		}
+		current.spans = append(current.spans, spans...)
+	}
+
+	for _, raw := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+		endsWithBreak := strings.HasSuffix(raw, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimSuffix(line, `\`)
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			current = nil
+		case mdHeading.MatchString(strings.TrimSpace(line)):
+			m := mdHeading.FindStringSubmatch(strings.TrimSpace(line))
+			blocks = append(blocks, mdBlock{kind: "heading", level: len(m[1]), spans: parseMarkdownInline(m[2])})
+			current = nil
+		case mdBullet.MatchString(line):
+			m := mdBullet.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: "item", level: indentLevel(m[1]), marker: "•"})
+			current = &blocks[len(blocks)-1]
+			appendText(m[2])
+		case mdOrdered.MatchString(line):
+			m := mdOrdered.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: "item", level: indentLevel(m[1]), marker: m[2] + "."})
+			current = &blocks[len(blocks)-1]
+			appendText(m[3])
+		default:
+			if current == nil {
+				blocks = append(blocks, mdBlock{kind: "paragraph"})
+				current = &blocks[len(blocks)-1]
+				hardBreak = false
+			}
+			appendText(strings.TrimSpace(line))
+		}
+		hardBreak = endsWithBreak
+	}
+	return blocks
+}
+
+// indentLevel 列表缩进层级，每两个空格或一个制表符为一级
+func indentLevel(indent string) int {
+	width := 0
+	for _, r := range indent {
+		if r == '\t' {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width / 2
+}
+
+// parseMarkdownInline 解析行内样式：**粗体**、__粗体__、*斜体*、_斜体_、`代码` 和反斜杠转义。
+// 没有闭合的标记按普通字符处理。
+func parseMarkdownInline(text string) []mdSpan {
+	var spans []mdSpan
+	var sb strings.Builder
+	bold, italic := false, false
+
+	flush := func() {
+		if sb.Len() > 0 {
+			spans = append(spans, mdSpan{text: sb.String(), bold: bold, italic: italic})
+			sb.Reset()
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		rest := string(runes[i+1:])
+		switch {
+		case r == '\\' && i+1 < len(runes) && unicode.IsPunct(runes[i+1]):
+			sb.WriteRune(runes[i+1])
+			i++
+		case r == '`':
+			end := strings.IndexRune(rest, '`')
+			if end < 0 {
+				sb.WriteRune(r)
+				continue
+			}
+			flush()
+			spans = append(spans, mdSpan{text: rest[:end], code: true, bold: bold, italic: italic})
+			i += len([]rune(rest[:end])) + 1
+		case (r == '*' || r == '_') && i+1 < len(runes) && runes[i+1] == r:
+			marker := string([]rune{r, r})
+			if !bold && (!opensEmphasis(runes, i+1) || !strings.Contains(string(runes[i+2:]), marker)) ||
+				bold && !closesEmphasis(runes, i) {
+				sb.WriteString(marker)
+				i++
+				continue
+			}
+			flush()
+			bold = !bold
+			i++
+		case r == '*' || (r == '_' && (italic || i == 0 || !isWordRune(runes[i-1]))):
+			if !italic && (!opensEmphasis(runes, i) || !strings.ContainsRune(rest, r)) ||
+				italic && !closesEmphasis(runes, i) {
+				sb.WriteRune(r)
+				continue
+			}
+			flush()
+			italic = !italic
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	flush()
+	return spans
+}
+
+// opensEmphasis 标记后紧跟非空白字符时才能开始强调，避免把 2 * 3 当作斜体
+func opensEmphasis(runes []rune, i int) bool {
+	return i+1 < len(runes) && !unicode.IsSpace(runes[i+1])
+}
+
+// closesEmphasis 标记前紧挨非空白字符时才能结束强调
+func closesEmphasis(runes []rune, i int) bool {
+	return i > 0 && !unicode.IsSpace(runes[i-1])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// mdPiece 不可再分的排版片段
+type mdPiece struct {
+	text    string
+	face    font.Face
+	width   float64
+	ascent  float64
+	descent float64
+	code    bool
+	newline bool
+}
+
+// Layout 返回排版结果和边界框左上角的逻辑坐标，供渲染器逐段绘制
+func (md *Markdown) Layout() (MarkdownLayout, gmMath.Vector2) {
+	layout := md.layout()
+	left, top := md.topLeft(layout)
+	return layout, gmMath.Vector2{X: left, Y: top}
+}
+
+// layout 按块排版：标题放大加粗，列表项带标记并使用悬挂缩进，超过 maxWidth 时折行
+func (md *Markdown) layout() MarkdownLayout {
+	size := md.RenderSize()
+	registry := fonts.Default()
+	var result MarkdownLayout
+
+	y := 0.0
+	for bi, block := range parseMarkdownBlocks(md.source) {
+		blockSize := size
+		forceBold := false
+		if block.kind == "heading" {
+			blockSize = size * headingScale(block.level)
+			forceBold = true
+		}
+
+		// 段间距：标题前留出更多空间
+		if bi > 0 {
+			if block.kind == "heading" {
+				y += 0.6 * blockSize
+			} else if block.kind == "paragraph" {
+				y += 0.4 * size
+			} else {
+				y += 0.15 * size
+			}
+		}
+
+		indent := 0.0
+		var marker *MarkdownRun
+		if block.kind == "item" {
+			// 列表标记放在缩进处，正文悬挂缩进对齐到标记之后
+			indent = float64(block.level) * 1.2 * size
+			p := md.piece(registry, block.marker, mdSpan{}, blockSize, false)
+			marker = &MarkdownRun{Text: p.text, Face: p.face, X: indent, Width: p.width, Ascent: p.ascent, Descent: p.descent}
+			indent += math.Max(p.width+0.4*size, 1.2*size)
+		}
+		y = md.placeLines(&result, md.blockPieces(registry, block, blockSize, forceBold), indent, y, marker)
+	}
+	result.Height = y
+	return result
+}
+
+// headingScale 标题相对正文的字号倍数
+func headingScale(level int) float64 {
+	switch level {
+	case 1:
+		return 2.0
+	case 2:
+		return 1.6
+	case 3:
+		return 1.3
+	default:
+		return 1.1
+	}
+}
+
+// blockPieces 把块内样式片段切成可折行的片段
+func (md *Markdown) blockPieces(registry *fonts.Registry, block mdBlock, size float64, forceBold bool) []mdPiece {
+	var pieces []mdPiece
+	for _, span := range block.spans {
+		if span.lineBreak {
+			pieces = append(pieces, mdPiece{newline: true})
+			continue
+		}
+		if forceBold {
+			span.bold = true
+		}
+		if span.code {
+			// 行内代码整体不折行
+			pieces = append(pieces, md.piece(registry, span.text, span, size, true))
+			continue
+		}
+		for _, seg := range breakSegments(span.text) {
+			pieces = append(pieces, md.piece(registry, seg, span, size, false))
+		}
+	}
+	return pieces
+}
+
+// piece 测量单个片段
+func (md *Markdown) piece(registry *fonts.Registry, text string, span mdSpan, size float64, code bool) mdPiece {
+	spec := fonts.Spec{Family: md.font, Weight: fonts.Regular, Style: fonts.Normal}
+	if span.bold {
+		spec.Weight = fonts.Bold
+	}
+	if span.italic {
+		spec.Style = fonts.Italic
+	}
+	if code {
+		spec.Family = fonts.MonoFamily
+	}
+
+	face, err := registry.Face(spec, size, text)
+	if err != nil {
+		return mdPiece{text: text}
+	}
+	metrics := face.Metrics()
+	return mdPiece{
+		text:    text,
+		face:    face,
+		width:   float64(font.MeasureString(face, text)) / 64,
+		ascent:  float64(metrics.Ascent) / 64,
+		descent: float64(metrics.Descent) / 64,
+		code:    code,
+	}
+}
+
+// placeLines 贪心地把片段排成行并写入 result，返回下一块的起始位置。
+// marker 不为空时放在第一行行首（列表标记）。
+func (md *Markdown) placeLines(result *MarkdownLayout, pieces []mdPiece, indent, y float64, marker *MarkdownRun) float64 {
+	var lines [][]mdPiece
+	var line []mdPiece
+	x := indent
+	for _, p := range pieces {
+		if p.newline {
+			lines = append(lines, line)
+			line, x = nil, indent
+			continue
+		}
+		fitWidth := p.width
+		if trimmed := strings.TrimRight(p.text, " "); trimmed != p.text && p.face != nil {
+			fitWidth = float64(font.MeasureString(p.face, trimmed)) / 64
+		}
+		if md.maxWidth > 0 && len(line) > 0 && x+fitWidth > md.maxWidth {
+			lines = append(lines, line)
+			line, x = nil, indent
+			if p.text = strings.TrimLeft(p.text, " "); p.text == "" {
+				continue
+			}
+			if p.face != nil {
+				p.width = float64(font.MeasureString(p.face, p.text)) / 64
+			}
+		}
+		line = append(line, p)
+		x += p.width
+	}
+	lines = append(lines, line)
+
+	for i, line := range lines {
+		ascent, descent := 0.0, 0.0
+		if marker != nil && i == 0 {
+			ascent, descent = marker.Ascent, marker.Descent
+		}
+		for _, p := range line {
+			ascent = math.Max(ascent, p.ascent)
+			descent = math.Max(descent, p.descent)
+		}
+		if ascent == 0 && descent == 0 {
+			// 空行使用正文行高
+			ascent, descent = 0.8*md.RenderSize(), 0.2*md.RenderSize()
+		}
+
+		extra := (ascent + descent) * (md.lineSpacing - 1)
+		if i > 0 {
+			y += extra
+		}
+		baseline := y + ascent
+		if marker != nil && i == 0 {
+			m := *marker
+			m.Baseline = baseline
+			result.Runs = append(result.Runs, m)
+		}
+
+		x := indent
+		for _, p := range line {
+			if p.face != nil && p.text != "" {
+				result.Runs = append(result.Runs, MarkdownRun{
+					Text: p.text, Face: p.face, X: x, Baseline: baseline,
+					Width: p.width, Ascent: p.ascent, Descent: p.descent, Code: p.code,
+				})
+			}
+			x += p.width
+		}
+		// 行尾空格不计入宽度
+		if n := len(line); n > 0 && line[n-1].face != nil {
+			last := line[n-1]
+			x -= last.width - float64(font.MeasureString(last.face, strings.TrimRight(last.text, " ")))/64
+		}
+		result.Width = math.Max(result.Width, x)
+		y = baseline + descent
+	}
+	return y
+}
+
+// generateBounds 根据排版尺寸和锚点生成边界框点
+func (md *Markdown) generateBounds() {
+	layout := md.layout()
+	left, top := md.topLeft(layout)
+	right, bottom := left+layout.Width, top-layout.Height
+
+	md.SetPoints([]gmMath.Vector2{
+		{X: left, Y: bottom},  // 左下
+		{X: right, Y: bottom}, // 右下
+		{X: right, Y: top},    // 右上
+		{X: left, Y: top},     // 左上
+	})
+}
+
+// topLeft 返回边界框左上角的逻辑坐标
+func (md *Markdown) topLeft(layout MarkdownLayout) (float64, float64) {
+	return md.position.X - md.anchor.X*layout.Width, md.position.Y + md.anchor.Y*layout.Height
+}
+
+// RenderSize 返回正文实际使用的字号，与文本相同地受运行时字号范围限制
+func (md *Markdown) RenderSize() float64 {
+	size := md.size
+	if size <= 0 {
+		size = 12
+	}
+	return md.sizeRange.clamp(size)
+}
+
+// SetSizeRange 设置正文字号的范围，小于等于 0 的一端不限制，默认使用内置范围
+func (md *Markdown) SetSizeRange(min, max float64) *Markdown {
+	md.sizeRange = textSizeRange{min, max}
+	md.generateBounds()
+	return md
+}
+
+// GetSource 获取 Markdown 源文本
+func (md *Markdown) GetSource() string {
+	return md.source
+}
+
+// SetSource 设置 Markdown 源文本
+func (md *Markdown) SetSource(source string) *Markdown {
+	md.source = source
+	md.generateBounds()
+	return md
+}
+
+// GetSize 获取正文字号
+func (md *Markdown) GetSize() float64 {
+	return md.size
+}
+
+// SetSize 设置正文字号，标题按比例放大
+func (md *Markdown) SetSize(size float64) *Markdown {
+	md.size = size
+	md.generateBounds()
+	return md
+}
+
+// GetFont 获取正文字体族名称，为空表示默认字体
+func (md *Markdown) GetFont() string {
+	return md.font
+}
+
+// SetFont 设置正文字体族名称
+func (md *Markdown) SetFont(family string) *Markdown {
+	md.font = family
+	md.generateBounds()
+	return md
+}
+
+// GetMaxWidth 获取自动折行宽度
+func (md *Markdown) GetMaxWidth() float64 {
+	return md.maxWidth
+}
+
+// SetMaxWidth 设置自动折行宽度，0 表示不自动折行
+func (md *Markdown) SetMaxWidth(width float64) *Markdown {
+	md.maxWidth = math.Max(width, 0)
+	md.generateBounds()
+	return md
+}
+
+// GetLineSpacing 获取行距
+func (md *Markdown) GetLineSpacing() float64 {
+	return md.lineSpacing
+}
+
+// SetLineSpacing 设置行距（行框高度的倍数）
+func (md *Markdown) SetLineSpacing(spacing float64) *Markdown {
+	md.lineSpacing = spacing
+	md.generateBounds()
+	return md
+}
+
+// GetAnchor 获取锚点
+func (md *Markdown) GetAnchor() TextAnchor {
+	return md.anchor
+}
+
+// SetAnchor 设置锚点，位置保持不变，边界框随之移动
+func (md *Markdown) SetAnchor(anchor TextAnchor) *Markdown {
+	md.anchor = anchor
+	md.generateBounds()
+	return md
+}
+
+// MoveTo 移动锚点到指定位置
+func (md *Markdown) MoveTo(pos gmMath.Vector2) core.Mobject {
+	md.position = pos
+	md.generateBounds()
+	return md
+}
+
+// GetCenter 获取锚点位置
+func (md *Markdown) GetCenter() gmMath.Vector2 {
+	return md.position
+}
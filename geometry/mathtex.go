@@ -0,0 +1,139 @@
+package geometry
+
+import (
+	"image/color"
+	"render2go/core"
+	gmMath "render2go/math"
+	"render2go/mathtex"
+)
+
+// MathTex 数学公式对象，由内置排版器排版，不依赖外部 TeX
+type MathTex struct {
+	*core.BaseMobject
+	source    string
+	size      float64
+	position  gmMath.Vector2 // 公式边界框中心
+	textMode  bool           // 文本模式：只有 $...$ 内按公式排版
+	box       *mathtex.Box
+	sizeRange textSizeRange // 排版字号的范围
+}
+
+// NewMathTex 创建数学模式公式，如 `\frac{a}{b}`
+func NewMathTex(source string, size float64) (*MathTex, error) {
+	return newMathTex(source, size, false)
+}
+
+// NewTex 创建文本模式公式，如 `面积 $\pi r^2$`
+func NewTex(source string, size float64) (*MathTex, error) {
+	return newMathTex(source, size, true)
+}
+
+func newMathTex(source string, size float64, textMode bool) (*MathTex, error) {
+	m := &MathTex{
+		BaseMobject: core.NewBaseMobject(),
+		source:      source,
+		size:        size,
+		textMode:    textMode,
+		sizeRange:   builtinSizeRange(),
+	}
+	m.SetColor(color.RGBA{0, 0, 0, 255})
+	m.SetFillOpacity(1.0)
+
+	if err := m.typeset(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// typeset 重新排版并更新边界框
+func (m *MathTex) typeset() error {
+	box, err := mathtex.Layout(m.source, mathtex.Options{Size: m.RenderSize(), TextMode: m.textMode})
+	if err != nil {
+		return err
+	}
+	m.box = box
+	m.generateBounds()
+	return nil
+}
+
+// generateBounds 生成以位置为中心的边界框点
+func (m *MathTex) generateBounds() {
+	left, bottom := m.Origin().X, m.Origin().Y-m.box.Depth
+	right, top := left+m.box.Width, m.Origin().Y+m.box.Height
+
+	m.SetPoints([]gmMath.Vector2{
+		{X: left, Y: bottom},  // 左下
+		{X: right, Y: bottom}, // 右下
+		{X: right, Y: top},    // 右上
+		{X: left, Y: top},     // 左上
+	})
+}
+
+// Origin 返回公式基线左端的逻辑坐标，排版结果中的坐标都相对于该点
+func (m *MathTex) Origin() gmMath.Vector2 {
+	return gmMath.Vector2{
+		X: m.position.X - m.box.Width/2,
+		Y: m.position.Y - (m.box.Height-m.box.Depth)/2,
+	}
+}
+
+// Box 返回排版结果
+func (m *MathTex) Box() *mathtex.Box {
+	return m.box
+}
+
+// GetSource 获取公式源码
+func (m *MathTex) GetSource() string {
+	return m.source
+}
+
+// SetSource 设置公式源码并重新排版，解析失败时保持原内容
+func (m *MathTex) SetSource(source string) error {
+	old := m.source
+	m.source = source
+	if err := m.typeset(); err != nil {
+		m.source = old
+		return err
+	}
+	return nil
+}
+
+// GetSize 获取字号
+func (m *MathTex) GetSize() float64 {
+	return m.size
+}
+
+// SetSize 设置字号并重新排版
+func (m *MathTex) SetSize(size float64) *MathTex {
+	m.size = size
+	m.typeset() // 源码已成功解析过，重新排版不会出错
+	return m
+}
+
+// RenderSize 返回实际排版使用的字号，与文本相同地受运行时字号范围限制
+func (m *MathTex) RenderSize() float64 {
+	size := m.size
+	if size <= 0 {
+		size = 12
+	}
+	return m.sizeRange.clamp(size)
+}
+
+// SetSizeRange 设置排版字号的范围，小于等于 0 的一端不限制，默认使用内置范围
+func (m *MathTex) SetSizeRange(min, max float64) *MathTex {
+	m.sizeRange = textSizeRange{min, max}
+	m.typeset() // 源码已成功解析过，重新排版不会出错
+	return m
+}
+
+// MoveTo 移动公式中心到指定位置
+func (m *MathTex) MoveTo(pos gmMath.Vector2) core.Mobject {
+	m.position = pos
+	m.generateBounds()
+	return m
+}
+
+// GetCenter 获取公式中心位置
+func (m *MathTex) GetCenter() gmMath.Vector2 {
+	return m.position
+}
@@ -0,0 +1,111 @@
+package geometry
+
+import (
+	"math"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// DefaultCurveUnit 未指定坐标系时，参数曲线 1 个单位对应的场景长度
+const DefaultCurveUnit = 100.0
+
+// ParametricCurve 参数曲线 (x(t), y(t))，极坐标曲线 r(θ) 也以参数曲线表示。
+// 指定坐标系时按其 CoordinateToPoint 放置，否则 1 个单位对应 DefaultCurveUnit、原点在场景原点
+type ParametricCurve struct {
+	*Path
+	axes       *CoordinateSystem
+	function   func(t float64) gmMath.Vector2
+	tRange     [2]float64
+	resolution int     // 初始均匀采样的区间数，之后在弯曲处自适应加密
+	opacity    float64 // 整体透明度，曲线本身从不填充
+}
+
+// NewParametricCurve 创建 t 从 tMin 到 tMax 的参数曲线，axes 为 nil 时使用默认单位长度
+func NewParametricCurve(axes *CoordinateSystem, function func(t float64) gmMath.Vector2, tMin, tMax float64) *ParametricCurve {
+	curve := &ParametricCurve{
+		Path:       NewPath(),
+		axes:       axes,
+		function:   function,
+		tRange:     [2]float64{tMin, tMax},
+		resolution: curveInitialSamples,
+		opacity:    1.0,
+	}
+	curve.Generate()
+	return curve
+}
+
+// NewPolarCurve 创建极坐标曲线 r = radius(θ)，θ 从 thetaMin 到 thetaMax
+func NewPolarCurve(axes *CoordinateSystem, radius func(theta float64) float64, thetaMin, thetaMax float64) *ParametricCurve {
+	return NewParametricCurve(axes, func(theta float64) gmMath.Vector2 {
+		r := radius(theta)
+		return gmMath.Vector2{X: r * math.Cos(theta), Y: r * math.Sin(theta)}
+	}, thetaMin, thetaMax)
+}
+
+// Axes 获取所在的坐标系，未指定时为 nil
+func (c *ParametricCurve) Axes() *CoordinateSystem {
+	return c.axes
+}
+
+// TRange 获取参数范围
+func (c *ParametricCurve) TRange() [2]float64 {
+	return c.tRange
+}
+
+// Resolution 获取初始采样区间数
+func (c *ParametricCurve) Resolution() int {
+	return c.resolution
+}
+
+// SetResolution 设置初始采样区间数并重新采样，曲线保持当前的中心位置。
+// 参数变化很快（如高频振荡）时增大该值以免漏掉细节
+func (c *ParametricCurve) SetResolution(resolution int) *ParametricCurve {
+	if resolution < 2 {
+		resolution = 2
+	}
+	c.resolution = resolution
+	center, empty := c.GetCenter(), c.IsEmpty()
+	c.Generate()
+	if !empty {
+		c.MoveTo(center)
+	}
+	return c
+}
+
+// Generate 重新采样曲线
+func (c *ParametricCurve) Generate() *ParametricCurve {
+	c.setPolylines(sampleCurve(func(t float64) (gmMath.Vector2, bool) {
+		return c.toPoint(c.function(t)), true
+	}, c.tRange[0], c.tRange[1], c.resolution))
+	return c
+}
+
+// toPoint 曲线坐标转换为场景坐标
+func (c *ParametricCurve) toPoint(p gmMath.Vector2) gmMath.Vector2 {
+	if c.axes != nil {
+		return c.axes.CoordinateToPoint(p)
+	}
+	return p.Scale(DefaultCurveUnit)
+}
+
+// GetFillOpacity 获取整体透明度
+func (c *ParametricCurve) GetFillOpacity() float64 {
+	return c.opacity
+}
+
+// SetFillOpacity 设置整体透明度，淡入淡出动画通过它改变曲线的不透明度
+func (c *ParametricCurve) SetFillOpacity(opacity float64) {
+	c.opacity = opacity
+}
+
+// Copy 复制曲线，与原曲线共享坐标系和函数
+func (c *ParametricCurve) Copy() core.Mobject {
+	return &ParametricCurve{
+		Path:       c.Path.Copy().(*Path),
+		axes:       c.axes,
+		function:   c.function,
+		tRange:     c.tRange,
+		resolution: c.resolution,
+		opacity:    c.opacity,
+	}
+}
@@ -0,0 +1,343 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// PathCommand 路径命令类型
+type PathCommand int
+
+// 路径命令
+const (
+	PathMove  PathCommand = iota // 开始新的子路径，1 个点
+	PathLine                     // 直线，1 个点
+	PathQuad                     // 二次贝塞尔曲线，控制点和终点
+	PathCubic                    // 三次贝塞尔曲线，两个控制点和终点
+	PathClose                    // 闭合当前子路径，不使用点
+)
+
+// pointCount 返回命令使用的点数
+func (c PathCommand) pointCount() int {
+	switch c {
+	case PathQuad:
+		return 2
+	case PathCubic:
+		return 3
+	case PathClose:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// PathSegment 一条路径命令及其使用的点
+type PathSegment struct {
+	Command PathCommand
+	Points  []gmMath.Vector2
+}
+
+// Path 由直线和贝塞尔曲线组成的路径。
+// 各命令的控制点和终点依次存放在 BaseMobject 的点列表中，
+// 平移、缩放、旋转等变换直接作用于控制点，曲线形状保持精确。
+type Path struct {
+	*core.BaseMobject
+	commands    []PathCommand
+	strokeColor color.Color // 描边颜色，nil 表示与填充颜色相同
+	evenOdd     bool        // 使用奇偶规则填充，默认非零环绕规则
+}
+
+// NewPath 创建空路径，默认黑色描边、不填充
+func NewPath() *Path {
+	path := &Path{BaseMobject: core.NewBaseMobject()}
+	path.SetColor(color.RGBA{0, 0, 0, 255})
+	path.SetStrokeWidth(2.0)
+	path.SetFillOpacity(0.0)
+	return path
+}
+
+// add 追加一条命令及其点
+func (p *Path) add(command PathCommand, points ...gmMath.Vector2) *Path {
+	p.commands = append(p.commands, command)
+	p.SetPoints(append(p.GetPoints(), points...))
+	return p
+}
+
+// AppendSegments 一次追加多条命令，适合由字形轮廓等批量生成的路径
+func (p *Path) AppendSegments(segments []PathSegment) *Path {
+	points := append([]gmMath.Vector2(nil), p.GetPoints()...)
+	for _, seg := range segments {
+		p.commands = append(p.commands, seg.Command)
+		points = append(points, seg.Points[:seg.Command.pointCount()]...)
+	}
+	p.SetPoints(points)
+	return p
+}
+
+// NewSubpath 从指定点开始新的子路径
+func (p *Path) NewSubpath(pt gmMath.Vector2) *Path {
+	return p.add(PathMove, pt)
+}
+
+// LineTo 画直线到指定点
+func (p *Path) LineTo(pt gmMath.Vector2) *Path {
+	return p.add(PathLine, pt)
+}
+
+// QuadTo 画二次贝塞尔曲线
+func (p *Path) QuadTo(control, pt gmMath.Vector2) *Path {
+	return p.add(PathQuad, control, pt)
+}
+
+// CubicTo 画三次贝塞尔曲线
+func (p *Path) CubicTo(control1, control2, pt gmMath.Vector2) *Path {
+	return p.add(PathCubic, control1, control2, pt)
+}
+
+// ArcTo 从当前点画椭圆弧到 pt，参数与 SVG 的 A 命令相同（rotation 为角度），
+// 圆弧以三次贝塞尔曲线精确近似
+func (p *Path) ArcTo(rx, ry, rotation float64, largeArc, sweep bool, pt gmMath.Vector2) *Path {
+	points := p.GetPoints()
+	if len(points) == 0 {
+		return p.NewSubpath(pt)
+	}
+	return p.AppendSegments(ArcSegments(points[len(points)-1], rx, ry, rotation, largeArc, sweep, pt))
+}
+
+// SetColor 设置路径颜色，描边和填充都使用该颜色
+func (p *Path) SetColor(c color.Color) {
+	p.BaseMobject.SetColor(c)
+	p.strokeColor = nil
+}
+
+// GetStrokeColor 获取描边颜色
+func (p *Path) GetStrokeColor() color.Color {
+	if p.strokeColor == nil {
+		return p.GetColor()
+	}
+	return p.strokeColor
+}
+
+// SetStrokeColor 单独设置描边颜色，填充仍使用 GetColor 的颜色
+func (p *Path) SetStrokeColor(c color.Color) *Path {
+	p.strokeColor = c
+	return p
+}
+
+// EvenOdd 是否使用奇偶规则填充
+func (p *Path) EvenOdd() bool {
+	return p.evenOdd
+}
+
+// SetEvenOdd 设置填充规则，true 为奇偶规则，false 为非零环绕规则
+func (p *Path) SetEvenOdd(evenOdd bool) *Path {
+	p.evenOdd = evenOdd
+	return p
+}
+
+// Close 闭合当前子路径
+func (p *Path) Close() *Path {
+	return p.add(PathClose)
+}
+
+// Segments 返回路径的全部命令，点为当前（变换后）的坐标
+func (p *Path) Segments() []PathSegment {
+	points := p.GetPoints()
+	segments := make([]PathSegment, 0, len(p.commands))
+	i := 0
+	for _, command := range p.commands {
+		n := command.pointCount()
+		if i+n > len(points) {
+			break // 点被外部修改得少于命令所需
+		}
+		segments = append(segments, PathSegment{Command: command, Points: points[i : i+n]})
+		i += n
+	}
+	return segments
+}
+
+// Polyline 把路径展开为依次连接的折线点，曲线段按固定步数细分，
+// 子路径之间直接相连。用作运动路径时物体沿这些点移动
+func (p *Path) Polyline() []gmMath.Vector2 {
+	const steps = 16
+	var points []gmMath.Vector2
+	var current, start gmMath.Vector2
+	for _, seg := range p.Segments() {
+		switch seg.Command {
+		case PathMove:
+			start = seg.Points[0]
+			points = append(points, start)
+		case PathLine:
+			points = append(points, seg.Points[0])
+		case PathQuad:
+			for i := 1; i <= steps; i++ {
+				t := float64(i) / steps
+				a := gmMath.LerpVector2(current, seg.Points[0], t)
+				b := gmMath.LerpVector2(seg.Points[0], seg.Points[1], t)
+				points = append(points, gmMath.LerpVector2(a, b, t))
+			}
+		case PathCubic:
+			for i := 1; i <= steps; i++ {
+				t := float64(i) / steps
+				a := gmMath.LerpVector2(current, seg.Points[0], t)
+				b := gmMath.LerpVector2(seg.Points[0], seg.Points[1], t)
+				c := gmMath.LerpVector2(seg.Points[1], seg.Points[2], t)
+				ab, bc := gmMath.LerpVector2(a, b, t), gmMath.LerpVector2(b, c, t)
+				points = append(points, gmMath.LerpVector2(ab, bc, t))
+			}
+		case PathClose:
+			points = append(points, start)
+		}
+		if seg.Command == PathClose {
+			current = start
+		} else {
+			current = seg.Points[len(seg.Points)-1]
+		}
+	}
+	return points
+}
+
+// IsEmpty 路径是否没有任何命令
+func (p *Path) IsEmpty() bool {
+	return len(p.commands) == 0
+}
+
+// Bounds 返回路径实际经过的最小矩形，曲线段取端点和各坐标轴方向上的极值点，
+// 不包含曲线外侧的控制点
+func (p *Path) Bounds() (min, max gmMath.Vector2) {
+	first := true
+	include := func(pt gmMath.Vector2) {
+		if first {
+			min, max, first = pt, pt, false
+			return
+		}
+		min.X, min.Y = math.Min(min.X, pt.X), math.Min(min.Y, pt.Y)
+		max.X, max.Y = math.Max(max.X, pt.X), math.Max(max.Y, pt.Y)
+	}
+
+	var current, start gmMath.Vector2
+	for _, seg := range p.Segments() {
+		switch seg.Command {
+		case PathMove:
+			start = seg.Points[0]
+			include(start)
+		case PathLine:
+			include(seg.Points[0])
+		case PathQuad:
+			p0, p1, p2 := current, seg.Points[0], seg.Points[1]
+			include(p2)
+			for _, t := range quadExtrema(p0, p1, p2) {
+				a, b := gmMath.LerpVector2(p0, p1, t), gmMath.LerpVector2(p1, p2, t)
+				include(gmMath.LerpVector2(a, b, t))
+			}
+		case PathCubic:
+			p0, p1, p2, p3 := current, seg.Points[0], seg.Points[1], seg.Points[2]
+			include(p3)
+			for _, t := range cubicExtrema(p0, p1, p2, p3) {
+				a, b, c := gmMath.LerpVector2(p0, p1, t), gmMath.LerpVector2(p1, p2, t), gmMath.LerpVector2(p2, p3, t)
+				ab, bc := gmMath.LerpVector2(a, b, t), gmMath.LerpVector2(b, c, t)
+				include(gmMath.LerpVector2(ab, bc, t))
+			}
+		}
+		if seg.Command == PathClose {
+			current = start
+		} else {
+			current = seg.Points[len(seg.Points)-1]
+		}
+	}
+	return
+}
+
+// quadExtrema 返回二次贝塞尔曲线在 x、y 方向上取极值的参数，只保留 (0, 1) 内的
+func quadExtrema(p0, p1, p2 gmMath.Vector2) []float64 {
+	var ts []float64
+	for _, c := range [][3]float64{{p0.X, p1.X, p2.X}, {p0.Y, p1.Y, p2.Y}} {
+		// 导数 2[(p1-p0) + t(p0-2p1+p2)] 为零
+		if d := c[0] - 2*c[1] + c[2]; d != 0 {
+			ts = appendUnit(ts, (c[0]-c[1])/d)
+		}
+	}
+	return ts
+}
+
+// cubicExtrema 返回三次贝塞尔曲线在 x、y 方向上取极值的参数，只保留 (0, 1) 内的
+func cubicExtrema(p0, p1, p2, p3 gmMath.Vector2) []float64 {
+	var ts []float64
+	for _, c := range [][4]float64{{p0.X, p1.X, p2.X, p3.X}, {p0.Y, p1.Y, p2.Y, p3.Y}} {
+		// 导数除以 3 后为 a t² + b t + k
+		a := -c[0] + 3*c[1] - 3*c[2] + c[3]
+		b := 2 * (c[0] - 2*c[1] + c[2])
+		k := c[1] - c[0]
+		if math.Abs(a) < 1e-12 {
+			if b != 0 {
+				ts = appendUnit(ts, -k/b)
+			}
+			continue
+		}
+		disc := b*b - 4*a*k
+		if disc < 0 {
+			continue
+		}
+		sq := math.Sqrt(disc)
+		ts = appendUnit(ts, (-b+sq)/(2*a))
+		ts = appendUnit(ts, (-b-sq)/(2*a))
+	}
+	return ts
+}
+
+// appendUnit 参数在 (0, 1) 内时追加到列表
+func appendUnit(ts []float64, t float64) []float64 {
+	if t > 0 && t < 1 {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// GetCenter 返回边界框中心
+func (p *Path) GetCenter() gmMath.Vector2 {
+	min, max := p.Bounds()
+	return min.Add(max).Scale(0.5)
+}
+
+// MoveTo 平移路径，使边界框中心位于指定位置
+func (p *Path) MoveTo(pos gmMath.Vector2) core.Mobject {
+	p.Shift(pos.Sub(p.GetCenter()))
+	return p
+}
+
+// Scale 以边界框中心为基准缩放
+func (p *Path) Scale(factor float64) core.Mobject {
+	center := p.GetCenter()
+	points := p.GetPoints()
+	for i := range points {
+		points[i] = center.Add(points[i].Sub(center).Scale(factor))
+	}
+	return p
+}
+
+// Rotate 绕边界框中心旋转
+func (p *Path) Rotate(angle float64) core.Mobject {
+	center := p.GetCenter()
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	points := p.GetPoints()
+	for i := range points {
+		d := points[i].Sub(center)
+		points[i] = center.Add(gmMath.Vector2{X: d.X*cos - d.Y*sin, Y: d.X*sin + d.Y*cos})
+	}
+	return p
+}
+
+// Copy 复制路径，包括命令和样式
+func (p *Path) Copy() core.Mobject {
+	path := NewPath()
+	path.commands = append(path.commands, p.commands...)
+	path.SetPoints(p.GetPoints())
+	path.SetColor(p.GetColor())
+	path.strokeColor = p.strokeColor
+	path.evenOdd = p.evenOdd
+	path.SetStrokeWidth(p.GetStrokeWidth())
+	path.SetFillOpacity(p.GetFillOpacity())
+	return path
+}
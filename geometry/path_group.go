@@ -0,0 +1,195 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// PathGroup 由多条保留各自填充和描边样式的路径组成的图形，
+// 整体作为一个对象移动、缩放、旋转和做动画
+type PathGroup struct {
+	parts   []*Path
+	opacity float64 // 整体透明度，与各路径自身的透明度相乘
+}
+
+// NewPathGroup 由路径创建路径组
+func NewPathGroup(parts []*Path) *PathGroup {
+	return &PathGroup{parts: parts, opacity: 1.0}
+}
+
+// Parts 返回组成图形的路径，按文档中的绘制顺序排列
+func (s *PathGroup) Parts() []*Path {
+	return s.parts
+}
+
+// Bounds 返回所有路径的边界框
+func (s *PathGroup) Bounds() (min, max gmMath.Vector2) {
+	first := true
+	for _, part := range s.parts {
+		if len(part.GetPoints()) == 0 {
+			continue
+		}
+		lo, hi := part.Bounds()
+		if first {
+			min, max = lo, hi
+			first = false
+			continue
+		}
+		min.X, min.Y = math.Min(min.X, lo.X), math.Min(min.Y, lo.Y)
+		max.X, max.Y = math.Max(max.X, hi.X), math.Max(max.Y, hi.Y)
+	}
+	return
+}
+
+// Width 返回边界框宽度
+func (s *PathGroup) Width() float64 {
+	min, max := s.Bounds()
+	return max.X - min.X
+}
+
+// Height 返回边界框高度
+func (s *PathGroup) Height() float64 {
+	min, max := s.Bounds()
+	return max.Y - min.Y
+}
+
+// SetHeight 等比缩放图形使高度为 height，描边宽度随之缩放，中心保持不变
+func (s *PathGroup) SetHeight(height float64) *PathGroup {
+	if current := s.Height(); current > 0 && height > 0 {
+		factor := height / current
+		s.Scale(factor)
+		for _, part := range s.parts {
+			part.SetStrokeWidth(part.GetStrokeWidth() * factor)
+		}
+	}
+	return s
+}
+
+// GetPoints 返回所有路径的控制点，依次拼接
+func (s *PathGroup) GetPoints() []gmMath.Vector2 {
+	var points []gmMath.Vector2
+	for _, part := range s.parts {
+		points = append(points, part.GetPoints()...)
+	}
+	return points
+}
+
+// SetPoints 按各路径的点数把控制点分配回各路径
+func (s *PathGroup) SetPoints(points []gmMath.Vector2) {
+	for _, part := range s.parts {
+		n := len(part.GetPoints())
+		if n > len(points) {
+			n = len(points)
+		}
+		part.SetPoints(points[:n])
+		points = points[n:]
+	}
+}
+
+// GetColor 返回第一个可见路径的颜色
+func (s *PathGroup) GetColor() color.Color {
+	for _, part := range s.parts {
+		if part.GetFillOpacity() > 0 {
+			return part.GetColor()
+		}
+		if part.GetStrokeWidth() > 0 {
+			return part.GetStrokeColor()
+		}
+	}
+	return color.RGBA{0, 0, 0, 255}
+}
+
+// SetColor 把所有路径的填充和描边设置为同一颜色
+func (s *PathGroup) SetColor(c color.Color) {
+	for _, part := range s.parts {
+		part.SetColor(c)
+	}
+}
+
+// GetStrokeWidth 返回各路径中最大的描边宽度
+func (s *PathGroup) GetStrokeWidth() float64 {
+	width := 0.0
+	for _, part := range s.parts {
+		width = math.Max(width, part.GetStrokeWidth())
+	}
+	return width
+}
+
+// SetStrokeWidth 设置所有路径的描边宽度
+func (s *PathGroup) SetStrokeWidth(width float64) {
+	for _, part := range s.parts {
+		part.SetStrokeWidth(width)
+	}
+}
+
+// GetFillOpacity 获取整体透明度
+func (s *PathGroup) GetFillOpacity() float64 {
+	return s.opacity
+}
+
+// SetFillOpacity 设置整体透明度，渲染时与各路径自身的透明度相乘
+func (s *PathGroup) SetFillOpacity(opacity float64) {
+	s.opacity = opacity
+}
+
+// GetCenter 返回边界框中心
+func (s *PathGroup) GetCenter() gmMath.Vector2 {
+	min, max := s.Bounds()
+	return min.Add(max).Scale(0.5)
+}
+
+// Shift 平移所有路径
+func (s *PathGroup) Shift(offset gmMath.Vector2) core.Mobject {
+	for _, part := range s.parts {
+		part.Shift(offset)
+	}
+	return s
+}
+
+// MoveTo 平移图形，使边界框中心位于指定位置
+func (s *PathGroup) MoveTo(pos gmMath.Vector2) core.Mobject {
+	return s.Shift(pos.Sub(s.GetCenter()))
+}
+
+// Scale 以整体边界框中心为基准缩放
+func (s *PathGroup) Scale(factor float64) core.Mobject {
+	center := s.GetCenter()
+	for _, part := range s.parts {
+		points := part.GetPoints()
+		for i := range points {
+			points[i] = center.Add(points[i].Sub(center).Scale(factor))
+		}
+	}
+	return s
+}
+
+// Rotate 绕整体边界框中心旋转
+func (s *PathGroup) Rotate(angle float64) core.Mobject {
+	center := s.GetCenter()
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	for _, part := range s.parts {
+		points := part.GetPoints()
+		for i := range points {
+			d := points[i].Sub(center)
+			points[i] = center.Add(gmMath.Vector2{X: d.X*cos - d.Y*sin, Y: d.X*sin + d.Y*cos})
+		}
+	}
+	return s
+}
+
+// Copy 深拷贝图形及各路径的样式
+func (s *PathGroup) Copy() core.Mobject {
+	return s.copyGroup()
+}
+
+func (s *PathGroup) copyGroup() *PathGroup {
+	parts := make([]*Path, len(s.parts))
+	for i, part := range s.parts {
+		parts[i] = part.Copy().(*Path)
+	}
+	group := NewPathGroup(parts)
+	group.opacity = s.opacity
+	return group
+}
@@ -0,0 +1,63 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+func TestPathBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		min, max gmMath.Vector2
+	}{
+		{"line", "M0 0 L4 3", gmMath.Vector2{X: 0, Y: 0}, gmMath.Vector2{X: 4, Y: 3}},
+		// 控制点高 2，曲线最高点在 t = 0.5 处，高 1.5
+		{"cubic arch", "M0 0 C1 2 3 2 4 0", gmMath.Vector2{X: 0, Y: 0}, gmMath.Vector2{X: 4, Y: 1.5}},
+		{"quadratic arch", "M0 0 Q2 4 4 0", gmMath.Vector2{X: 0, Y: 0}, gmMath.Vector2{X: 4, Y: 2}},
+		// S 形曲线在 x 方向上有两个极值
+		{"cubic s-curve", "M0 0 C4 0 -4 4 0 4", gmMath.Vector2{X: -2 / math.Sqrt(3), Y: 0}, gmMath.Vector2{X: 2 / math.Sqrt(3), Y: 4}},
+		{"control points outside both ends", "M0 0 C-1 0 5 0 4 0", gmMath.Vector2{X: -0.1128856, Y: 0}, gmMath.Vector2{X: 4.1128856, Y: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ParseSVGPath(tt.d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			min, max := path.Bounds()
+			if min.Distance(tt.min) > 1e-6 || max.Distance(tt.max) > 1e-6 {
+				t.Errorf("Bounds() = %v, %v, want %v, %v", min, max, tt.min, tt.max)
+			}
+
+			// 边界框包含曲线上所有的采样点
+			for _, pt := range path.Polyline() {
+				if pt.X < min.X-1e-9 || pt.X > max.X+1e-9 || pt.Y < min.Y-1e-9 || pt.Y > max.Y+1e-9 {
+					t.Errorf("point %v outside bounds %v, %v", pt, min, max)
+				}
+			}
+		})
+	}
+}
+
+func TestPathCenterUsesCurveExtent(t *testing.T) {
+	path, err := ParseSVGPath("M0 0 C1 2 3 2 4 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := path.GetCenter(), (gmMath.Vector2{X: 2, Y: 0.75}); got.Distance(want) > 1e-9 {
+		t.Errorf("GetCenter() = %v, want %v", got, want)
+	}
+
+	path.MoveTo(gmMath.Vector2{X: 10, Y: 10})
+	min, max := path.Bounds()
+	if want := (gmMath.Vector2{X: 8, Y: 9.25}); min.Distance(want) > 1e-9 {
+		t.Errorf("after MoveTo min = %v, want %v", min, want)
+	}
+	if want := (gmMath.Vector2{X: 12, Y: 10.75}); max.Distance(want) > 1e-9 {
+		t.Errorf("after MoveTo max = %v, want %v", max, want)
+	}
+}
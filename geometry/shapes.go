@@ -5,6 +5,8 @@ import (
 	"image/color"
 	"math"
 	"render2go/core"
+	"render2go/fonts"
+	"render2go/internal/defaults"
 	gmMath "render2go/math"
 )
 
@@ -130,6 +132,13 @@ func NewArrow(start, end gmMath.Vector2) *Arrow {
 	return arrow
 }
 
+// SetHeadSize 设置箭头头部的长度
+func (a *Arrow) SetHeadSize(size float64) *Arrow {
+	a.headSize = size
+	a.generateArrowHead()
+	return a
+}
+
 func (a *Arrow) generateArrowHead() {
 	direction := a.end.Sub(a.start).Normalize()
 	perpendicular := gmMath.Vector2{X: -direction.Y, Y: direction.X}
@@ -196,9 +205,35 @@ func NewRegularPolygon(sides int, radius float64) *Polygon {
 // Text 文本对象
 type Text struct {
 	*core.BaseMobject
-	text     string
-	size     float64
-	position gmMath.Vector2
+	text        string
+	size        float64
+	position    gmMath.Vector2 // 锚点所在位置
+	font        string         // 字体族，为空时使用默认字体
+	weight      fonts.Weight   // 字重
+	style       fonts.Style    // 字形样式
+	maxWidth    float64        // 自动折行宽度，0 表示只在换行符处换行
+	align       TextAlign      // 多行文本的对齐方式
+	lineSpacing float64        // 行距，行框高度的倍数
+	anchor      TextAnchor     // 边界框上与位置重合的点
+	rotation    float64        // 绕锚点逆时针旋转的角度（弧度）
+	spans       []TextSpan     // 富文本片段，为空时整段文本使用同一样式
+	sizeRange   textSizeRange  // 渲染字号的范围
+}
+
+// textSizeRange 文本类对象渲染字号的范围，小于等于 0 的一端不限制
+type textSizeRange struct {
+	min, max float64
+}
+
+// builtinSizeRange 内置的字号范围，新建的文本类对象默认使用
+func builtinSizeRange() textSizeRange {
+	rt := defaults.BuiltinRuntime()
+	return textSizeRange{rt.TextMinSize, rt.TextMaxSize}
+}
+
+// clamp 将字号限制在范围内
+func (r textSizeRange) clamp(size float64) float64 {
+	return defaults.ClampSize(size, r.min, r.max)
 }
 
 // NewText 创建新的文本对象
@@ -208,6 +243,12 @@ func NewText(text string, size float64) *Text {
 		text:        text,
 		size:        size,
 		position:    gmMath.Vector2{X: 0, Y: 0}, // 默认位置为原点
+		weight:      fonts.Regular,
+		style:       fonts.Normal,
+		align:       AlignCenter,
+		lineSpacing: 1.2,
+		anchor:      AnchorCenter,
+		sizeRange:   builtinSizeRange(),
 	}
 
 	// 设置默认文本颜色为黑色，确保在白色背景上可见
@@ -220,31 +261,64 @@ func NewText(text string, size float64) *Text {
 	return textObj
 }
 
-// generateBounds 生成文本的边界框点
+// generateBounds 按排版后的实际尺寸生成边界框点
 func (t *Text) generateBounds() {
-	// 文本边界框应该很小，只用于渲染定位，不影响场景边界计算
-	// 使用极小的边界框，实际显示由渲染器处理
-	size := 0.1 // 固定的小边界框
+	layout := t.layout()
+	left, top := t.topLeft(layout)
+	right, bottom := left+layout.Width, top-layout.Height
 
-	// 创建文本的边界框四个角点（以文本位置为中心的小方块）
 	points := []gmMath.Vector2{
-		{X: t.position.X - size, Y: t.position.Y - size}, // 左下
-		{X: t.position.X + size, Y: t.position.Y - size}, // 右下
-		{X: t.position.X + size, Y: t.position.Y + size}, // 右上
-		{X: t.position.X - size, Y: t.position.Y + size}, // 左上
+		{X: left, Y: bottom},  // 左下
+		{X: right, Y: bottom}, // 右下
+		{X: right, Y: top},    // 右上
+		{X: left, Y: top},     // 左上
+	}
+	if t.rotation != 0 {
+		for i, p := range points {
+			points[i] = p.Sub(t.position).Rotate(t.rotation).Add(t.position)
+		}
 	}
 
 	t.SetPoints(points)
 }
 
+// topLeft 根据锚点计算边界框左上角（逻辑坐标，Y 轴向上）
+func (t *Text) topLeft(layout TextLayout) (float64, float64) {
+	return t.position.X - t.anchor.X*layout.Width, t.position.Y + t.anchor.Y*layout.Height
+}
+
+// RenderSize 返回实际渲染使用的字号：未设置时为 12，并限制在运行时配置的范围内
+func (t *Text) RenderSize() float64 {
+	size := t.size
+	if size <= 0 {
+		size = 12 // 默认字体大小
+	}
+	return t.sizeRange.clamp(size)
+}
+
+// SetSizeRange 设置渲染字号的范围，小于等于 0 的一端不限制，默认使用内置范围
+func (t *Text) SetSizeRange(min, max float64) *Text {
+	t.sizeRange = textSizeRange{min, max}
+	t.generateBounds()
+	return t
+}
+
+// Layout 返回排版结果和边界框左上角的逻辑坐标，供渲染器逐行绘制
+func (t *Text) Layout() (TextLayout, gmMath.Vector2) {
+	layout := t.layout()
+	left, top := t.topLeft(layout)
+	return layout, gmMath.Vector2{X: left, Y: top}
+}
+
 // GetText 获取文本内容
 func (t *Text) GetText() string {
 	return t.text
 }
 
-// SetText 设置文本内容
+// SetText 设置文本内容，已有的富文本片段样式被清除
 func (t *Text) SetText(text string) *Text {
 	t.text = text
+	t.spans = nil
 	t.generateBounds() // 重新生成边界框
 	return t
 }
@@ -261,21 +335,134 @@ func (t *Text) SetSize(size float64) *Text {
 	return t
 }
 
-// MoveTo 移动文本到指定位置
+// GetFont 获取字体族名称，为空表示默认字体
+func (t *Text) GetFont() string {
+	return t.font
+}
+
+// SetFont 设置字体族名称
+func (t *Text) SetFont(family string) *Text {
+	t.font = family
+	t.generateBounds()
+	return t
+}
+
+// GetWeight 获取字重
+func (t *Text) GetWeight() fonts.Weight {
+	return t.weight
+}
+
+// SetWeight 设置字重
+func (t *Text) SetWeight(weight fonts.Weight) *Text {
+	t.weight = weight
+	t.generateBounds()
+	return t
+}
+
+// GetStyle 获取字形样式
+func (t *Text) GetStyle() fonts.Style {
+	return t.style
+}
+
+// SetStyle 设置字形样式
+func (t *Text) SetStyle(style fonts.Style) *Text {
+	t.style = style
+	t.generateBounds()
+	return t
+}
+
+// FontSpec 返回渲染该文本所需的字体描述
+func (t *Text) FontSpec() fonts.Spec {
+	return fonts.Spec{Family: t.font, Weight: t.weight, Style: t.style}
+}
+
+// GetMaxWidth 获取自动折行宽度
+func (t *Text) GetMaxWidth() float64 {
+	return t.maxWidth
+}
+
+// SetMaxWidth 设置自动折行宽度，0 表示不自动折行
+func (t *Text) SetMaxWidth(width float64) *Text {
+	t.maxWidth = math.Max(width, 0)
+	t.generateBounds()
+	return t
+}
+
+// GetAlign 获取对齐方式
+func (t *Text) GetAlign() TextAlign {
+	return t.align
+}
+
+// SetAlign 设置多行文本的对齐方式
+func (t *Text) SetAlign(align TextAlign) *Text {
+	t.align = align
+	t.generateBounds()
+	return t
+}
+
+// GetLineSpacing 获取行距
+func (t *Text) GetLineSpacing() float64 {
+	return t.lineSpacing
+}
+
+// SetLineSpacing 设置行距（行框高度的倍数）
+func (t *Text) SetLineSpacing(spacing float64) *Text {
+	t.lineSpacing = spacing
+	t.generateBounds()
+	return t
+}
+
+// GetAnchor 获取锚点
+func (t *Text) GetAnchor() TextAnchor {
+	return t.anchor
+}
+
+// SetAnchor 设置锚点，文本位置保持不变，边界框随之移动
+func (t *Text) SetAnchor(anchor TextAnchor) *Text {
+	t.anchor = anchor
+	t.generateBounds()
+	return t
+}
+
+// GetRotation 获取绕锚点逆时针旋转的角度（弧度）
+func (t *Text) GetRotation() float64 {
+	return t.rotation
+}
+
+// SetRotation 设置绕锚点逆时针旋转的角度（弧度），边界框随之旋转
+func (t *Text) SetRotation(angle float64) *Text {
+	t.rotation = angle
+	t.generateBounds()
+	return t
+}
+
+// MoveTo 移动文本，使排版后边界框的中心位于指定位置
 func (t *Text) MoveTo(pos gmMath.Vector2) core.Mobject {
-	t.position = pos
+	t.position = t.position.Add(pos.Sub(t.GetCenter()))
 	t.generateBounds() // 重新生成边界框
 	return t
 }
 
-// GetCenter 获取文本中心位置
+// GetCenter 获取排版后边界框的中心，旋转时随边界框绕锚点旋转
 func (t *Text) GetCenter() gmMath.Vector2 {
+	layout := t.layout()
+	left, top := t.topLeft(layout)
+	center := gmMath.Vector2{X: left + layout.Width/2, Y: top - layout.Height/2}
+	if t.rotation != 0 {
+		center = center.Sub(t.position).Rotate(t.rotation).Add(t.position)
+	}
+	return center
+}
+
+// GetPosition 获取文本位置，即锚点所在的点
+func (t *Text) GetPosition() gmMath.Vector2 {
 	return t.position
 }
 
-// SetPosition 设置文本位置（别名方法）
+// SetPosition 设置文本位置，使锚点位于 (x, y)
 func (t *Text) SetPosition(x, y float64) *Text {
-	t.MoveTo(gmMath.Vector2{X: x, Y: y})
+	t.position = gmMath.Vector2{X: x, Y: y}
+	t.generateBounds() // 重新生成边界框
 	return t
 }
 
@@ -430,7 +617,9 @@ type Image struct {
 	width     float64
 	height    float64
 	position  gmMath.Vector2
-	imageData image.Image // 添加内存图像数据支持
+	imageData image.Image     // 添加内存图像数据支持
+	crop      image.Rectangle // 显示的像素区域，空表示整幅图像
+	filter    ImageFilter     // 缩放时的采样方式
 }
 
 // NewImageFromFile 从文件创建新的图像对象
@@ -494,15 +683,18 @@ func (img *Image) GetFilename() string {
 	return img.filename
 }
 
-// GetDimensions 获取图像尺寸
+// GetDimensions 获取图像尺寸，包括缩放之后的变化
 func (img *Image) GetDimensions() (float64, float64) {
-	return img.width, img.height
+	points := img.GetPoints()
+	if len(points) < 4 {
+		return img.width, img.height
+	}
+	return points[1].Sub(points[0]).Length(), points[3].Sub(points[0]).Length()
 }
 
-// SetPosition 设置图像位置
+// SetPosition 设置图像中心位置，保持旋转角度
 func (img *Image) SetPosition(x, y float64) *Image {
-	img.position = gmMath.Vector2{X: x, Y: y}
-	img.generateBounds()
+	img.MoveTo(gmMath.Vector2{X: x, Y: y})
 	return img
 }
 
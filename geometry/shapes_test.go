@@ -0,0 +1,50 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+func TestTextGetCenterMultiline(t *testing.T) {
+	tests := []struct {
+		name     string
+		anchor   TextAnchor
+		rotation float64
+	}{
+		{"top left anchor", AnchorTopLeft, 0},
+		{"bottom right anchor", AnchorBottomRight, 0},
+		{"rotated about the anchor", AnchorTopLeft, math.Pi / 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := NewText("short\na much longer line\nmid", 20)
+			text.SetAnchor(tt.anchor).SetRotation(tt.rotation).SetPosition(10, 20)
+
+			// 边界框四个角的平均值即排版后的中心
+			want := text.BaseMobject.GetCenter()
+			if got := text.GetCenter(); got.Distance(want) > 1e-9 {
+				t.Errorf("GetCenter() = %v, want the center of the laid-out bounds %v", got, want)
+			}
+			if anchor := (gmMath.Vector2{X: 10, Y: 20}); text.GetCenter().Distance(anchor) < 1 {
+				t.Errorf("GetCenter() = %v, still the anchor", text.GetCenter())
+			}
+			if pos := text.GetPosition(); pos != (gmMath.Vector2{X: 10, Y: 20}) {
+				t.Errorf("GetPosition() = %v, want the anchor (10, 20)", pos)
+			}
+
+			// MoveTo 移动中心，锚点跟着平移
+			target := gmMath.Vector2{X: -5, Y: 3}
+			shift := target.Sub(text.GetCenter())
+			text.MoveTo(target)
+			if got := text.GetCenter(); got.Distance(target) > 1e-9 {
+				t.Errorf("after MoveTo GetCenter() = %v, want %v", got, target)
+			}
+			if got, want := text.GetPosition(), (gmMath.Vector2{X: 10, Y: 20}).Add(shift); got.Distance(want) > 1e-9 {
+				t.Errorf("after MoveTo GetPosition() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package geometry
+
+import "render2go/core"
+
+// SVG 从 SVG 文件导入的图形。每个形状转换为保留自身填充和描边样式的路径，
+// 整体作为一个对象移动、缩放、旋转和做动画。坐标已转换为 y 轴向上。
+type SVG struct {
+	*PathGroup
+}
+
+// NewSVG 由路径创建 SVG 对象
+func NewSVG(parts []*Path) *SVG {
+	return &SVG{PathGroup: NewPathGroup(parts)}
+}
+
+// Copy 深拷贝图形及各路径的样式
+func (s *SVG) Copy() core.Mobject {
+	return &SVG{PathGroup: s.copyGroup()}
+}
@@ -0,0 +1,806 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io"
+	"math"
+	"render2go/internal/i18n"
+	gmMath "render2go/math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+)
+
+// ParseSVG 解析 SVG 文档，支持 path、rect、circle、ellipse、line、polyline、polygon 形状，
+// g 分组和 use 引用，transform 变换，以及填充、描边和透明度样式（属性、style 属性和
+// <style> 中的简单选择器）。渐变按第一个色标的纯色近似，文本、滤镜和裁剪不支持。
+// 文档坐标的 y 轴向下，转换后翻转为 y 轴向上，一个用户单位对应一个场景单位。
+func ParseSVG(data []byte) (*SVG, error) {
+	root, err := parseSVGTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil || root.name != "svg" {
+		return nil, i18n.Errorf("svg.not_svg")
+	}
+
+	c := &svgConverter{ids: make(map[string]*svgNode)}
+	c.index(root)
+
+	// 根元素的视口变换，之后翻转 y 轴
+	m := svgMatrix{1, 0, 0, -1, 0, 0}.mul(c.viewport(root))
+	style := svgStyle{
+		fill:          svgPaint{color: color.RGBA{0, 0, 0, 255}},
+		stroke:        svgPaint{none: true},
+		strokeWidth:   1,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+		current:       color.RGBA{0, 0, 0, 255},
+	}
+	style = c.computeStyle(style, c.declarations(root))
+	if err := c.children(root, m, style); err != nil {
+		return nil, err
+	}
+	if len(c.parts) == 0 {
+		return nil, i18n.Errorf("svg.empty")
+	}
+	return NewSVG(c.parts), nil
+}
+
+// svgNode XML 元素
+type svgNode struct {
+	name     string
+	attrs    map[string]string
+	children []*svgNode
+	text     strings.Builder
+}
+
+// parseSVGTree 把 XML 解析为元素树，返回根元素
+func parseSVGTree(data []byte) (*svgNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var root *svgNode
+	var stack []*svgNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, i18n.Errorf("svg.invalid_xml", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &svgNode{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = strings.TrimSpace(attr.Value)
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	return root, nil
+}
+
+// svgMatrix 仿射变换矩阵 [a b c d e f]，x' = a*x + c*y + e，y' = b*x + d*y + f
+type svgMatrix [6]float64
+
+var svgIdentity = svgMatrix{1, 0, 0, 1, 0, 0}
+
+// mul 返回先应用 n 再应用 m 的变换
+func (m svgMatrix) mul(n svgMatrix) svgMatrix {
+	return svgMatrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m svgMatrix) apply(p gmMath.Vector2) gmMath.Vector2 {
+	return gmMath.Vector2{X: m[0]*p.X + m[2]*p.Y + m[4], Y: m[1]*p.X + m[3]*p.Y + m[5]}
+}
+
+// scale 返回变换对长度的平均缩放比例，用于描边宽度
+func (m svgMatrix) scale() float64 {
+	return math.Sqrt(math.Abs(m[0]*m[3] - m[1]*m[2]))
+}
+
+// parseSVGTransform 解析 transform 属性，支持 matrix、translate、scale、rotate、skewX、skewY
+func parseSVGTransform(s string) (svgMatrix, error) {
+	m := svgIdentity
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		end := strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return m, i18n.Errorf("svg.invalid_transform", s)
+		}
+		name := strings.TrimSpace(rest[:open])
+		args, err := svgNumbers(rest[open+1 : end])
+		if err != nil {
+			return m, i18n.Errorf("svg.invalid_transform", s)
+		}
+		rest = strings.TrimLeft(rest[end+1:], " \t\r\n,")
+
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+		var t svgMatrix
+		switch {
+		case name == "matrix" && len(args) == 6:
+			copy(t[:], args)
+		case name == "translate" && len(args) >= 1:
+			t = svgMatrix{1, 0, 0, 1, args[0], arg(1, 0)}
+		case name == "scale" && len(args) >= 1:
+			t = svgMatrix{args[0], 0, 0, arg(1, args[0]), 0, 0}
+		case name == "rotate" && len(args) >= 1:
+			a := args[0] * math.Pi / 180
+			cos, sin := math.Cos(a), math.Sin(a)
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = svgMatrix{1, 0, 0, 1, cx, cy}.mul(svgMatrix{cos, sin, -sin, cos, 0, 0}).mul(svgMatrix{1, 0, 0, 1, -cx, -cy})
+		case name == "skewX" && len(args) == 1:
+			t = svgMatrix{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}
+		case name == "skewY" && len(args) == 1:
+			t = svgMatrix{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return m, i18n.Errorf("svg.invalid_transform", s)
+		}
+		m = m.mul(t)
+	}
+	return m, nil
+}
+
+// svgNumbers 解析以空白或逗号分隔的数字列表
+func svgNumbers(s string) ([]float64, error) {
+	p := &svgPathParser{data: s}
+	var numbers []float64
+	for {
+		p.skipSeparators()
+		if p.pos >= len(p.data) {
+			return numbers, nil
+		}
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, v)
+	}
+}
+
+// svgPaint 填充或描边的颜色
+type svgPaint struct {
+	none    bool
+	current bool // currentColor，使用 color 属性的颜色
+	color   color.RGBA
+}
+
+// svgStyle 元素的计算样式，子元素从父元素继承
+type svgStyle struct {
+	fill, stroke  svgPaint
+	strokeWidth   float64
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64 // 祖先元素 opacity 的乘积
+	evenOdd       bool
+	current       color.RGBA
+}
+
+// svgRule <style> 中的一条规则，只支持由标签名、类名和 id 组成的简单选择器
+type svgRule struct {
+	tag, id     string
+	classes     []string
+	specificity int
+	order       int
+	decls       map[string]string
+}
+
+func (r svgRule) matches(n *svgNode) bool {
+	if r.tag != "" && r.tag != "*" && r.tag != n.name {
+		return false
+	}
+	if r.id != "" && r.id != n.attrs["id"] {
+		return false
+	}
+	classes := strings.Fields(n.attrs["class"])
+	for _, want := range r.classes {
+		found := false
+		for _, class := range classes {
+			if class == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSVGRules 解析样式表，跳过 @ 规则和复杂选择器
+func parseSVGRules(css string, order int) []svgRule {
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			css = css[:start]
+			break
+		}
+		css = css[:start] + css[start+2+end+2:]
+	}
+
+	var rules []svgRule
+	for _, block := range strings.Split(css, "}") {
+		open := strings.IndexByte(block, '{')
+		if open < 0 {
+			continue
+		}
+		decls := parseSVGDeclarations(block[open+1:])
+		for _, selector := range strings.Split(block[:open], ",") {
+			selector = strings.TrimSpace(selector)
+			if selector == "" || strings.ContainsAny(selector, "@ >+~[:") {
+				continue
+			}
+			rule := svgRule{decls: decls, order: order}
+			order++
+			for i := 0; i < len(selector); {
+				j := i + 1
+				for j < len(selector) && selector[j] != '.' && selector[j] != '#' {
+					j++
+				}
+				switch part := selector[i:j]; part[0] {
+				case '.':
+					rule.classes = append(rule.classes, part[1:])
+					rule.specificity += 10
+				case '#':
+					rule.id = part[1:]
+					rule.specificity += 100
+				default:
+					rule.tag = part
+					if part != "*" {
+						rule.specificity++
+					}
+				}
+				i = j
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseSVGDeclarations 解析 "name: value; ..." 形式的声明
+func parseSVGDeclarations(s string) map[string]string {
+	decls := make(map[string]string)
+	for _, decl := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		decls[strings.TrimSpace(name)] = value
+	}
+	return decls
+}
+
+// svgPresentationAttrs 参与样式计算的表现属性
+var svgPresentationAttrs = []string{
+	"fill", "stroke", "stroke-width", "fill-opacity", "stroke-opacity",
+	"opacity", "fill-rule", "color", "display", "visibility",
+}
+
+// svgConverter 把元素树转换为路径
+type svgConverter struct {
+	ids   map[string]*svgNode
+	rules []svgRule
+	parts []*Path
+	depth int
+}
+
+// index 记录带 id 的元素并收集样式表
+func (c *svgConverter) index(n *svgNode) {
+	if id := n.attrs["id"]; id != "" {
+		if _, exists := c.ids[id]; !exists {
+			c.ids[id] = n
+		}
+	}
+	if n.name == "style" {
+		c.rules = append(c.rules, parseSVGRules(n.text.String(), len(c.rules))...)
+	}
+	for _, child := range n.children {
+		c.index(child)
+	}
+}
+
+// declarations 按表现属性、样式表、style 属性的优先级合并元素的样式声明
+func (c *svgConverter) declarations(n *svgNode) map[string]string {
+	decls := make(map[string]string)
+	for _, name := range svgPresentationAttrs {
+		if value, ok := n.attrs[name]; ok {
+			decls[name] = value
+		}
+	}
+
+	var matched []svgRule
+	for _, rule := range c.rules {
+		if rule.matches(n) {
+			matched = append(matched, rule)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].specificity != matched[j].specificity {
+			return matched[i].specificity < matched[j].specificity
+		}
+		return matched[i].order < matched[j].order
+	})
+	for _, rule := range matched {
+		for name, value := range rule.decls {
+			decls[name] = value
+		}
+	}
+
+	for name, value := range parseSVGDeclarations(n.attrs["style"]) {
+		decls[name] = value
+	}
+	return decls
+}
+
+// computeStyle 在父元素样式的基础上应用元素自身的声明
+func (c *svgConverter) computeStyle(parent svgStyle, decls map[string]string) svgStyle {
+	style := parent
+	if value, ok := decls["color"]; ok {
+		if paint, ok := c.parsePaint(value, parent); ok && !paint.none && !paint.current {
+			style.current = paint.color
+		}
+	}
+	if value, ok := decls["fill"]; ok {
+		if paint, ok := c.parsePaint(value, parent); ok {
+			style.fill = paint
+		}
+	}
+	if value, ok := decls["stroke"]; ok {
+		if paint, ok := c.parsePaint(value, parent); ok {
+			style.stroke = paint
+		}
+	}
+	if value, ok := decls["stroke-width"]; ok {
+		if width, ok := parseSVGLength(value, 0); ok {
+			style.strokeWidth = math.Max(width, 0)
+		}
+	}
+	if value, ok := decls["fill-opacity"]; ok {
+		style.fillOpacity = parseSVGOpacity(value, style.fillOpacity)
+	}
+	if value, ok := decls["stroke-opacity"]; ok {
+		style.strokeOpacity = parseSVGOpacity(value, style.strokeOpacity)
+	}
+	if value, ok := decls["opacity"]; ok {
+		style.opacity *= parseSVGOpacity(value, 1)
+	}
+	if value, ok := decls["fill-rule"]; ok {
+		style.evenOdd = value == "evenodd"
+	}
+	return style
+}
+
+// parsePaint 解析颜色值：none、currentColor、#rgb、#rrggbb、rgb()、rgba()、颜色名称，
+// 以及 url(#id) 引用的渐变（取第一个色标的颜色）
+func (c *svgConverter) parsePaint(value string, parent svgStyle) (svgPaint, bool) {
+	value = strings.TrimSpace(value)
+	switch lower := strings.ToLower(value); {
+	case lower == "none" || lower == "transparent":
+		return svgPaint{none: true}, true
+	case lower == "currentcolor":
+		return svgPaint{current: true}, true
+	case lower == "inherit":
+		return svgPaint{}, false
+	case strings.HasPrefix(lower, "url("):
+		end := strings.IndexByte(value, ')')
+		if end < 0 {
+			return svgPaint{}, false
+		}
+		id := strings.Trim(strings.TrimSpace(value[4:end]), "'\"")
+		if rgba, ok := c.gradientColor(strings.TrimPrefix(id, "#"), 0); ok {
+			return svgPaint{color: rgba}, true
+		}
+		if fallback := strings.TrimSpace(value[end+1:]); fallback != "" {
+			return c.parsePaint(fallback, parent)
+		}
+		return svgPaint{none: true}, true
+	}
+	rgba, ok := parseSVGColor(value)
+	return svgPaint{color: rgba}, ok
+}
+
+// gradientColor 返回渐变第一个色标的颜色，色标可以通过 href 从另一个渐变继承
+func (c *svgConverter) gradientColor(id string, depth int) (color.RGBA, bool) {
+	n, ok := c.ids[id]
+	if !ok || depth > 8 || (n.name != "linearGradient" && n.name != "radialGradient") {
+		return color.RGBA{}, false
+	}
+	for _, stop := range n.children {
+		if stop.name != "stop" {
+			continue
+		}
+		decls := parseSVGDeclarations(stop.attrs["style"])
+		for _, name := range []string{"stop-color", "stop-opacity"} {
+			if _, ok := decls[name]; !ok {
+				if value, ok := stop.attrs[name]; ok {
+					decls[name] = value
+				}
+			}
+		}
+		rgba, ok := parseSVGColor(decls["stop-color"])
+		if !ok {
+			rgba = color.RGBA{0, 0, 0, 255}
+		}
+		if value, ok := decls["stop-opacity"]; ok {
+			rgba.A = uint8(float64(rgba.A)*parseSVGOpacity(value, 1) + 0.5)
+		}
+		return rgba, true
+	}
+	if href := n.attrs["href"]; strings.HasPrefix(href, "#") {
+		return c.gradientColor(href[1:], depth+1)
+	}
+	return color.RGBA{}, false
+}
+
+// parseSVGColor 解析颜色字面量
+func parseSVGColor(value string) (color.RGBA, bool) {
+	value = strings.TrimSpace(value)
+	lower := strings.ToLower(value)
+	if strings.HasPrefix(lower, "#") {
+		hex := lower[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			expanded := make([]byte, 0, 2*len(hex))
+			for i := 0; i < len(hex); i++ {
+				expanded = append(expanded, hex[i], hex[i])
+			}
+			hex = string(expanded)
+		}
+		if len(hex) != 6 && len(hex) != 8 {
+			return color.RGBA{}, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return color.RGBA{}, false
+		}
+		if len(hex) == 6 {
+			v = v<<8 | 0xff
+		}
+		return color.RGBA{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+	}
+	if strings.HasPrefix(lower, "rgb") {
+		open, end := strings.IndexByte(lower, '('), strings.IndexByte(lower, ')')
+		if open < 0 || end < open {
+			return color.RGBA{}, false
+		}
+		fields := strings.FieldsFunc(lower[open+1:end], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/' || r == '\t'
+		})
+		if len(fields) < 3 {
+			return color.RGBA{}, false
+		}
+		var channels [4]float64
+		channels[3] = 1
+		for i, field := range fields[:min(len(fields), 4)] {
+			percent := strings.HasSuffix(field, "%")
+			v, err := strconv.ParseFloat(strings.TrimSuffix(field, "%"), 64)
+			if err != nil {
+				return color.RGBA{}, false
+			}
+			if percent {
+				if i == 3 {
+					v /= 100
+				} else {
+					v = v * 255 / 100
+				}
+			}
+			channels[i] = v
+		}
+		clamp := func(v float64) uint8 { return uint8(math.Max(0, math.Min(255, v)) + 0.5) }
+		return color.RGBA{clamp(channels[0]), clamp(channels[1]), clamp(channels[2]), clamp(channels[3] * 255)}, true
+	}
+	if named, ok := colornames.Map[lower]; ok {
+		return named, true
+	}
+	return color.RGBA{}, false
+}
+
+// parseSVGOpacity 解析 0 到 1 的透明度，也接受百分比
+func parseSVGOpacity(value string, fallback float64) float64 {
+	value = strings.TrimSpace(value)
+	scale := 1.0
+	if strings.HasSuffix(value, "%") {
+		value = strings.TrimSuffix(value, "%")
+		scale = 0.01
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+	return math.Max(0, math.Min(1, v*scale))
+}
+
+// parseSVGLength 解析长度，支持 px、pt、pc、mm、cm、in 单位，百分比相对于 reference
+func parseSVGLength(value string, reference float64) (float64, bool) {
+	value = strings.TrimSpace(value)
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"%", reference / 100}, {"px", 1}, {"pt", 96.0 / 72}, {"pc", 16},
+		{"mm", 96 / 25.4}, {"cm", 96 / 2.54}, {"in", 96}, {"em", 16},
+	}
+	factor := 1.0
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSuffix(value, unit.suffix)
+			factor = unit.factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * factor, true
+}
+
+// length 读取长度属性，缺省或无效时为 0
+func (n *svgNode) length(name string, reference float64) float64 {
+	v, _ := parseSVGLength(n.attrs[name], reference)
+	return v
+}
+
+// viewport 返回 svg 元素的视口变换：x、y 偏移，以及 viewBox 到 width、height 的等比映射
+func (c *svgConverter) viewport(n *svgNode) svgMatrix {
+	m := svgMatrix{1, 0, 0, 1, n.length("x", 0), n.length("y", 0)}
+	box, err := svgNumbers(n.attrs["viewBox"])
+	if err != nil || len(box) != 4 || box[2] <= 0 || box[3] <= 0 {
+		return m
+	}
+	width, okW := parseSVGLength(n.attrs["width"], box[2])
+	height, okH := parseSVGLength(n.attrs["height"], box[3])
+	if !okW || width <= 0 {
+		width = box[2]
+	}
+	if !okH || height <= 0 {
+		height = box[3]
+	}
+	// preserveAspectRatio 默认 xMidYMid meet
+	scale := math.Min(width/box[2], height/box[3])
+	dx := (width - box[2]*scale) / 2
+	dy := (height - box[3]*scale) / 2
+	return m.mul(svgMatrix{scale, 0, 0, scale, dx - box[0]*scale, dy - box[1]*scale})
+}
+
+// children 依次转换子元素
+func (c *svgConverter) children(n *svgNode, m svgMatrix, style svgStyle) error {
+	for _, child := range n.children {
+		if err := c.element(child, m, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// element 转换一个元素及其子元素
+func (c *svgConverter) element(n *svgNode, m svgMatrix, parent svgStyle) error {
+	switch n.name {
+	case "defs", "symbol", "clipPath", "mask", "pattern", "marker", "style", "title",
+		"desc", "metadata", "linearGradient", "radialGradient", "filter", "text":
+		return nil
+	}
+
+	decls := c.declarations(n)
+	if decls["display"] == "none" {
+		return nil
+	}
+	style := c.computeStyle(parent, decls)
+
+	if value, ok := n.attrs["transform"]; ok {
+		t, err := parseSVGTransform(value)
+		if err != nil {
+			return err
+		}
+		m = m.mul(t)
+	}
+
+	switch n.name {
+	case "g", "a", "switch":
+		return c.children(n, m, style)
+	case "svg":
+		return c.children(n, m.mul(c.viewport(n)), style)
+	case "use":
+		href := n.attrs["href"]
+		target, ok := c.ids[strings.TrimPrefix(href, "#")]
+		if !ok || !strings.HasPrefix(href, "#") || c.depth >= 16 {
+			return nil
+		}
+		c.depth++
+		defer func() { c.depth-- }()
+		m = m.mul(svgMatrix{1, 0, 0, 1, n.length("x", 0), n.length("y", 0)})
+		if target.name == "symbol" {
+			return c.children(target, m.mul(c.viewport(target)), style)
+		}
+		return c.element(target, m, style)
+	}
+
+	segments, err := svgShape(n)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 || decls["visibility"] == "hidden" {
+		return nil
+	}
+	if n.name == "line" {
+		style.fill = svgPaint{none: true}
+	}
+	if part := c.styledPath(segments, m, style); part != nil {
+		c.parts = append(c.parts, part)
+	}
+	return nil
+}
+
+// styledPath 变换路径的点并应用样式，既不填充也不描边时返回 nil
+func (c *svgConverter) styledPath(segments []PathSegment, m svgMatrix, style svgStyle) *Path {
+	resolve := func(p svgPaint) color.RGBA {
+		if p.current {
+			return style.current
+		}
+		return p.color
+	}
+	fill := !style.fill.none && style.fillOpacity > 0
+	stroke := !style.stroke.none && style.strokeOpacity > 0 && style.strokeWidth > 0
+	if !fill && !stroke {
+		return nil
+	}
+
+	transformed := make([]PathSegment, len(segments))
+	for i, seg := range segments {
+		points := make([]gmMath.Vector2, len(seg.Points))
+		for j, pt := range seg.Points {
+			points[j] = m.apply(pt)
+		}
+		transformed[i] = PathSegment{Command: seg.Command, Points: points}
+	}
+
+	path := NewPath()
+	path.AppendSegments(transformed)
+	path.SetEvenOdd(style.evenOdd)
+	path.SetFillOpacity(0)
+	path.SetStrokeWidth(0)
+	if fill {
+		path.SetColor(resolve(style.fill))
+		path.SetFillOpacity(style.fillOpacity * style.opacity)
+	} else {
+		path.SetColor(resolve(style.stroke))
+	}
+	if stroke {
+		c := resolve(style.stroke)
+		c.A = uint8(float64(c.A)*style.strokeOpacity*style.opacity + 0.5)
+		path.SetStrokeColor(c)
+		path.SetStrokeWidth(style.strokeWidth * m.scale())
+	}
+	return path
+}
+
+// svgShape 把形状元素转换为元素自身坐标系中的路径命令
+func svgShape(n *svgNode) ([]PathSegment, error) {
+	pt := func(x, y float64) gmMath.Vector2 { return gmMath.Vector2{X: x, Y: y} }
+	move := func(p gmMath.Vector2) PathSegment { return PathSegment{Command: PathMove, Points: []gmMath.Vector2{p}} }
+	line := func(p gmMath.Vector2) PathSegment { return PathSegment{Command: PathLine, Points: []gmMath.Vector2{p}} }
+	closePath := PathSegment{Command: PathClose}
+
+	switch n.name {
+	case "path":
+		segments, err := parseSVGPathData(n.attrs["d"])
+		if err != nil {
+			return nil, i18n.Errorf("svg.invalid_path", err)
+		}
+		return segments, nil
+
+	case "rect":
+		x, y := n.length("x", 0), n.length("y", 0)
+		w, h := n.length("width", 0), n.length("height", 0)
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		rx, okX := parseSVGLength(n.attrs["rx"], w)
+		ry, okY := parseSVGLength(n.attrs["ry"], h)
+		if !okX {
+			rx = ry
+		}
+		if !okY {
+			ry = rx
+		}
+		rx, ry = math.Min(math.Max(rx, 0), w/2), math.Min(math.Max(ry, 0), h/2)
+		if rx == 0 || ry == 0 {
+			return []PathSegment{move(pt(x, y)), line(pt(x+w, y)), line(pt(x+w, y+h)), line(pt(x, y+h)), closePath}, nil
+		}
+		corner := func(from, to gmMath.Vector2) []PathSegment {
+			return ArcSegments(from, rx, ry, 0, false, true, to)
+		}
+		segments := []PathSegment{move(pt(x+rx, y)), line(pt(x+w-rx, y))}
+		segments = append(segments, corner(pt(x+w-rx, y), pt(x+w, y+ry))...)
+		segments = append(segments, line(pt(x+w, y+h-ry)))
+		segments = append(segments, corner(pt(x+w, y+h-ry), pt(x+w-rx, y+h))...)
+		segments = append(segments, line(pt(x+rx, y+h)))
+		segments = append(segments, corner(pt(x+rx, y+h), pt(x, y+h-ry))...)
+		segments = append(segments, line(pt(x, y+ry)))
+		segments = append(segments, corner(pt(x, y+ry), pt(x+rx, y))...)
+		return append(segments, closePath), nil
+
+	case "circle", "ellipse":
+		cx, cy := n.length("cx", 0), n.length("cy", 0)
+		var rx, ry float64
+		if n.name == "circle" {
+			rx = n.length("r", 0)
+			ry = rx
+		} else {
+			rx, ry = n.length("rx", 0), n.length("ry", 0)
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		right, left := pt(cx+rx, cy), pt(cx-rx, cy)
+		segments := []PathSegment{move(right)}
+		segments = append(segments, ArcSegments(right, rx, ry, 0, false, true, left)...)
+		segments = append(segments, ArcSegments(left, rx, ry, 0, false, true, right)...)
+		return append(segments, closePath), nil
+
+	case "line":
+		return []PathSegment{
+			move(pt(n.length("x1", 0), n.length("y1", 0))),
+			line(pt(n.length("x2", 0), n.length("y2", 0))),
+		}, nil
+
+	case "polyline", "polygon":
+		numbers, err := svgNumbers(n.attrs["points"])
+		if err != nil {
+			return nil, i18n.Errorf("svg.invalid_points", n.name, err)
+		}
+		if len(numbers) < 4 {
+			return nil, nil
+		}
+		segments := []PathSegment{move(pt(numbers[0], numbers[1]))}
+		for i := 2; i+1 < len(numbers); i += 2 {
+			segments = append(segments, line(pt(numbers[i], numbers[i+1])))
+		}
+		if n.name == "polygon" {
+			segments = append(segments, closePath)
+		}
+		return segments, nil
+	}
+	return nil, nil
+}
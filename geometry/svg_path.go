@@ -0,0 +1,344 @@
+package geometry
+
+import (
+	"math"
+	"render2go/internal/i18n"
+	gmMath "render2go/math"
+	"strconv"
+	"strings"
+)
+
+// ParseSVGPath 解析 SVG 路径数据（path 元素的 d 属性），支持 M、L、H、V、C、S、Q、T、A、Z
+// 及其相对坐标形式。坐标按原样使用，不翻转 y 轴；圆弧转换为三次贝塞尔曲线。
+func ParseSVGPath(d string) (*Path, error) {
+	path := NewPath()
+	segments, err := parseSVGPathData(d)
+	if err != nil {
+		return nil, err
+	}
+	path.AppendSegments(segments)
+	return path, nil
+}
+
+// svgPathParser SVG 路径数据的词法和状态
+type svgPathParser struct {
+	data     string
+	pos      int
+	current  gmMath.Vector2 // 当前点
+	start    gmMath.Vector2 // 当前子路径起点，Z 之后回到这里
+	control  gmMath.Vector2 // 上一条曲线的最后一个控制点，用于 S 和 T 的对称控制点
+	lastCmd  byte
+	segments []PathSegment
+}
+
+func parseSVGPathData(d string) ([]PathSegment, error) {
+	p := &svgPathParser{data: d}
+	for {
+		p.skipSeparators()
+		if p.pos >= len(p.data) {
+			break
+		}
+
+		cmd := p.data[p.pos]
+		if isSVGCommand(cmd) {
+			p.pos++
+		} else if p.lastCmd != 0 && p.lastCmd != 'Z' && p.lastCmd != 'z' && p.startsNumber() {
+			// 省略命令字母时重复上一条命令，M 之后的坐标视为 L
+			cmd = p.lastCmd
+			if cmd == 'M' {
+				cmd = 'L'
+			} else if cmd == 'm' {
+				cmd = 'l'
+			}
+		} else {
+			return nil, i18n.Errorf("path.unexpected_char", string(p.data[p.pos]), p.pos+1)
+		}
+		if len(p.segments) == 0 && cmd != 'M' && cmd != 'm' {
+			return nil, i18n.Errorf("path.must_start_with_move")
+		}
+		if err := p.command(cmd); err != nil {
+			return nil, err
+		}
+		p.lastCmd = cmd
+	}
+	return p.segments, nil
+}
+
+// command 执行一条命令
+func (p *svgPathParser) command(cmd byte) error {
+	relative := cmd >= 'a' && cmd <= 'z'
+	upper := cmd &^ 0x20
+	offset := gmMath.Vector2{}
+	if relative {
+		offset = p.current
+	}
+
+	switch upper {
+	case 'Z':
+		p.add(PathClose)
+		p.current = p.start
+		p.control = p.current
+		return nil
+	case 'H', 'V':
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		pt := p.current
+		if upper == 'H' {
+			pt.X = v
+			if relative {
+				pt.X += p.current.X
+			}
+		} else {
+			pt.Y = v
+			if relative {
+				pt.Y += p.current.Y
+			}
+		}
+		p.add(PathLine, pt)
+		p.control = pt
+		return nil
+	case 'A':
+		return p.arc(offset)
+	}
+
+	counts := map[byte]int{'M': 1, 'L': 1, 'C': 3, 'S': 2, 'Q': 2, 'T': 1}
+	pts := make([]gmMath.Vector2, counts[upper])
+	for i := range pts {
+		pt, err := p.point()
+		if err != nil {
+			return err
+		}
+		pts[i] = pt.Add(offset)
+	}
+
+	switch upper {
+	case 'M':
+		p.add(PathMove, pts[0])
+		p.start = pts[0]
+	case 'L':
+		p.add(PathLine, pts[0])
+	case 'C':
+		p.add(PathCubic, pts...)
+	case 'S':
+		p.add(PathCubic, p.reflectedControl("CcSs"), pts[0], pts[1])
+	case 'Q':
+		p.add(PathQuad, pts...)
+	case 'T':
+		p.add(PathQuad, p.reflectedControl("QqTt"), pts[0])
+	}
+	return nil
+}
+
+// reflectedControl 返回上一条同类曲线最后一个控制点关于当前点的对称点，没有时为当前点
+func (p *svgPathParser) reflectedControl(previous string) gmMath.Vector2 {
+	if p.lastCmd != 0 && strings.IndexByte(previous, p.lastCmd) >= 0 {
+		return p.current.Scale(2).Sub(p.control)
+	}
+	return p.current
+}
+
+// add 记录一条路径命令并更新当前点和控制点
+func (p *svgPathParser) add(command PathCommand, pts ...gmMath.Vector2) {
+	p.segments = append(p.segments, PathSegment{Command: command, Points: pts})
+	if n := len(pts); n > 0 {
+		p.current = pts[n-1]
+		p.control = p.current
+		if n > 1 {
+			p.control = pts[n-2]
+		}
+	}
+}
+
+// arc 解析圆弧参数 rx ry x轴旋转 大弧标志 扫描标志 x y
+func (p *svgPathParser) arc(offset gmMath.Vector2) error {
+	var params [3]float64
+	for i := range params {
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		params[i] = v
+	}
+	largeArc, err := p.flag()
+	if err != nil {
+		return err
+	}
+	sweep, err := p.flag()
+	if err != nil {
+		return err
+	}
+	end, err := p.point()
+	if err != nil {
+		return err
+	}
+	end = end.Add(offset)
+
+	for _, seg := range ArcSegments(p.current, params[0], params[1], params[2], largeArc, sweep, end) {
+		p.add(seg.Command, seg.Points...)
+	}
+	p.current = end
+	p.control = end
+	return nil
+}
+
+// point 读取一对坐标
+func (p *svgPathParser) point() (gmMath.Vector2, error) {
+	x, err := p.number()
+	if err != nil {
+		return gmMath.Vector2{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return gmMath.Vector2{}, err
+	}
+	return gmMath.Vector2{X: x, Y: y}, nil
+}
+
+// number 读取一个数字，允许 "1.5.5"、"1-2"、"1e-3" 这样的紧凑写法
+func (p *svgPathParser) number() (float64, error) {
+	p.skipSeparators()
+	start := p.pos
+	if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+		p.pos++
+	}
+	digits, dot := 0, false
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c >= '0' && c <= '9' {
+			digits++
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		p.pos++
+	}
+	if digits == 0 {
+		p.pos = start
+		return 0, p.expectedNumber()
+	}
+	if p.pos < len(p.data) && (p.data[p.pos] == 'e' || p.data[p.pos] == 'E') {
+		exp := p.pos + 1
+		if exp < len(p.data) && (p.data[exp] == '+' || p.data[exp] == '-') {
+			exp++
+		}
+		if exp < len(p.data) && p.data[exp] >= '0' && p.data[exp] <= '9' {
+			p.pos = exp
+			for p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '9' {
+				p.pos++
+			}
+		}
+	}
+	return strconv.ParseFloat(p.data[start:p.pos], 64)
+}
+
+// flag 读取圆弧标志 0 或 1，标志之间可以没有分隔符
+func (p *svgPathParser) flag() (bool, error) {
+	p.skipSeparators()
+	if p.pos < len(p.data) && (p.data[p.pos] == '0' || p.data[p.pos] == '1') {
+		p.pos++
+		return p.data[p.pos-1] == '1', nil
+	}
+	return false, i18n.Errorf("path.invalid_flag", p.pos+1)
+}
+
+func (p *svgPathParser) expectedNumber() error {
+	if p.pos >= len(p.data) {
+		return i18n.Errorf("path.unexpected_end")
+	}
+	return i18n.Errorf("path.expected_number", string(p.data[p.pos]), p.pos+1)
+}
+
+func (p *svgPathParser) skipSeparators() {
+	for p.pos < len(p.data) && strings.IndexByte(" \t\r\n,", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *svgPathParser) startsNumber() bool {
+	c := p.data[p.pos]
+	return c >= '0' && c <= '9' || c == '-' || c == '+' || c == '.'
+}
+
+func isSVGCommand(c byte) bool {
+	return strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0
+}
+
+// ArcSegments 把 SVG 端点形式的椭圆弧转换为三次贝塞尔曲线，每段不超过 90 度。
+// 半径为 0 时退化为直线，半径过小时按 SVG 规范等比放大。
+func ArcSegments(from gmMath.Vector2, rx, ry, rotation float64, largeArc, sweep bool, to gmMath.Vector2) []PathSegment {
+	if from == to {
+		return nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return []PathSegment{{Command: PathLine, Points: []gmMath.Vector2{to}}}
+	}
+
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+
+	// 转换到椭圆坐标系（SVG 规范 F.6.5）
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(num, 0) / den)
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+
+	cx := cosPhi*cx1 - sinPhi*cy1 + (from.X+to.X)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (from.Y+to.Y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	// 椭圆上参数角 t 处的点和切向量
+	at := func(t float64) (gmMath.Vector2, gmMath.Vector2) {
+		cosT, sinT := math.Cos(t), math.Sin(t)
+		return gmMath.Vector2{
+			X: cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			Y: cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		}, gmMath.Vector2{
+			X: -rx*sinT*cosPhi - ry*cosT*sinPhi,
+			Y: -rx*sinT*sinPhi + ry*cosT*cosPhi,
+		}
+	}
+
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	segments := make([]PathSegment, 0, n)
+	for i := 0; i < n; i++ {
+		t1, t2 := theta+float64(i)*step, theta+float64(i+1)*step
+		p1, d1 := at(t1)
+		p2, d2 := at(t2)
+		if i == n-1 {
+			p2 = to // 避免累积误差
+		}
+		segments = append(segments, PathSegment{Command: PathCubic, Points: []gmMath.Vector2{
+			p1.Add(d1.Scale(k)), p2.Sub(d2.Scale(k)), p2,
+		}})
+	}
+	return segments
+}
@@ -0,0 +1,150 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+// formatSegments 把路径命令写成紧凑的文本，便于在表格中比较
+func formatSegments(segments []PathSegment) string {
+	names := map[PathCommand]string{PathMove: "M", PathLine: "L", PathQuad: "Q", PathCubic: "C", PathClose: "Z"}
+	var parts []string
+	for _, seg := range segments {
+		part := names[seg.Command]
+		for _, p := range seg.Points {
+			part += fmt.Sprintf(" %g,%g", p.X, p.Y)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestParseSVGPathData(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		want string
+	}{
+		{"absolute lines", "M0 0 L10 0 L10 10 Z", "M 0,0 L 10,0 L 10,10 Z"},
+		{"relative lines", "m5 5 l10 0 l0 10 z", "M 5,5 L 15,5 L 15,15 Z"},
+		{"horizontal and vertical", "M1 2 H5 V7 h-2 v-3", "M 1,2 L 5,2 L 5,7 L 3,7 L 3,4"},
+		{"implicit lineto after moveto", "M0 0 10 0 10 10", "M 0,0 L 10,0 L 10,10"},
+		{"implicit relative lineto", "m1 1 2 0 0 2", "M 1,1 L 3,1 L 3,3"},
+		{"repeated command", "M0 0 L1 1 2 2", "M 0,0 L 1,1 L 2,2"},
+		{"compact numbers", "M0-1L.5.5-2e1,1E-1", "M 0,-1 L 0.5,0.5 L -20,0.1"},
+		{"commas and newlines", "M 0,0\n\tL 3 , 4", "M 0,0 L 3,4"},
+		{"quadratic", "M0 0 Q5 10 10 0", "M 0,0 Q 5,10 10,0"},
+		{"smooth quadratic", "M0 0 Q5 10 10 0 T20 0", "M 0,0 Q 5,10 10,0 Q 15,-10 20,0"},
+		{"smooth quadratic without previous curve", "M0 0 T10 0", "M 0,0 Q 0,0 10,0"},
+		{"cubic", "M0 0 C0 10 10 10 10 0", "M 0,0 C 0,10 10,10 10,0"},
+		{"relative cubic", "M1 1 c0 10 10 10 10 0", "M 1,1 C 1,11 11,11 11,1"},
+		{"smooth cubic", "M0 0 C0 10 10 10 10 0 S20 -10 20 0", "M 0,0 C 0,10 10,10 10,0 C 10,-10 20,-10 20,0"},
+		{"close returns to subpath start", "M0 0 L10 0 Z l0 5", "M 0,0 L 10,0 Z L 0,5"},
+		{"zero radius arc is a line", "M0 0 A0 0 0 0 1 10 0", "M 0,0 L 10,0"},
+		{"arc to current point is dropped", "M3 4 A5 5 0 0 1 3 4", "M 3,4"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := parseSVGPathData(tt.d)
+			if err != nil {
+				t.Fatalf("parseSVGPathData(%q) error: %v", tt.d, err)
+			}
+			if got := formatSegments(segments); got != tt.want {
+				t.Errorf("parseSVGPathData(%q) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+// 错误信息随语言变化，wantErr 只取其中的命令字母和字符位置
+func TestParseSVGPathDataErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       string
+		wantErr string
+	}{
+		{"does not start with moveto", "L0 0", "M"},
+		{"unknown command", "M0 0 X1 1", "X"},
+		{"missing coordinate", "M0 0 L1", ""},
+		{"invalid number", "M0 0 L1 #", "9"},
+		{"invalid arc flag", "M0 0 A5 5 0 2 1 10 0", "13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSVGPathData(tt.d)
+			if err == nil {
+				t.Fatalf("parseSVGPathData(%q) succeeded, want error", tt.d)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSVGPathData(%q) error = %q, want it to contain %q", tt.d, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// cubicAt 三次贝塞尔曲线在参数 t 处的点
+func cubicAt(p0, p1, p2, p3 gmMath.Vector2, t float64) gmMath.Vector2 {
+	u := 1 - t
+	return p0.Scale(u * u * u).Add(p1.Scale(3 * u * u * t)).Add(p2.Scale(3 * u * t * t)).Add(p3.Scale(t * t * t))
+}
+
+func TestArcSegments(t *testing.T) {
+	tests := []struct {
+		name             string
+		from, to         gmMath.Vector2
+		rx, ry, rotation float64
+		largeArc, sweep  bool
+		center           gmMath.Vector2
+		segments         int
+	}{
+		{"quarter circle", gmMath.Vector2{X: 10, Y: 0}, gmMath.Vector2{X: 0, Y: 10}, 10, 10, 0, false, true, gmMath.Vector2{}, 1},
+		{"small arc other side", gmMath.Vector2{X: 10, Y: 0}, gmMath.Vector2{X: 0, Y: 10}, 10, 10, 0, false, false, gmMath.Vector2{X: 10, Y: 10}, 1},
+		{"large arc", gmMath.Vector2{X: 10, Y: 0}, gmMath.Vector2{X: 0, Y: 10}, 10, 10, 0, true, false, gmMath.Vector2{}, 3},
+		{"half circle", gmMath.Vector2{X: -5, Y: 0}, gmMath.Vector2{X: 5, Y: 0}, 5, 5, 0, false, true, gmMath.Vector2{}, 2},
+		{"radius scaled up", gmMath.Vector2{X: -5, Y: 0}, gmMath.Vector2{X: 5, Y: 0}, 1, 1, 0, false, true, gmMath.Vector2{}, 2},
+		{"rotated ellipse", gmMath.Vector2{X: 0, Y: 10}, gmMath.Vector2{X: -5, Y: 0}, 10, 5, 90, false, true, gmMath.Vector2{}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := ArcSegments(tt.from, tt.rx, tt.ry, tt.rotation, tt.largeArc, tt.sweep, tt.to)
+			if len(segments) != tt.segments {
+				t.Fatalf("got %d segments, want %d: %s", len(segments), tt.segments, formatSegments(segments))
+			}
+
+			// 半径过小时按 SVG 规范等比放大到恰好连接两端点
+			rx, ry := tt.rx, tt.ry
+			if d := tt.from.Distance(tt.to) / 2; tt.rx == tt.ry && rx < d {
+				rx, ry = d, d
+			}
+			phi := tt.rotation * math.Pi / 180
+			onEllipse := func(p gmMath.Vector2) bool {
+				v := p.Sub(tt.center).Rotate(-phi)
+				return math.Abs(v.X*v.X/(rx*rx)+v.Y*v.Y/(ry*ry)-1) < 1e-3
+			}
+
+			start := tt.from
+			for i, seg := range segments {
+				if seg.Command != PathCubic || len(seg.Points) != 3 {
+					t.Fatalf("segment %d = %s, want a cubic curve", i, formatSegments([]PathSegment{seg}))
+				}
+				for _, s := range []float64{0, 0.25, 0.5, 0.75, 1} {
+					if p := cubicAt(start, seg.Points[0], seg.Points[1], seg.Points[2], s); !onEllipse(p) {
+						t.Errorf("segment %d at t=%g: point (%g, %g) is off the ellipse", i, s, p.X, p.Y)
+					}
+				}
+				start = seg.Points[2]
+			}
+			if start.Distance(tt.to) > 1e-9 {
+				t.Errorf("arc ends at (%g, %g), want (%g, %g)", start.X, start.Y, tt.to.X, tt.to.Y)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package geometry
+
+import (
+	"render2go/fonts"
+	"render2go/internal/i18n"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+)
+
+// TextAlign 多行文本的水平对齐方式
+type TextAlign int
+
+// 对齐方式
+const (
+	AlignCenter TextAlign = iota
+	AlignLeft
+	AlignRight
+)
+
+// ParseTextAlign 解析对齐方式名称：left、center 或 right
+func ParseTextAlign(name string) (TextAlign, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "left":
+		return AlignLeft, nil
+	case "center", "centre":
+		return AlignCenter, nil
+	case "right":
+		return AlignRight, nil
+	}
+	return AlignCenter, i18n.Errorf("text.invalid_align", name)
+}
+
+// String 返回对齐方式名称
+func (a TextAlign) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	default:
+		return "center"
+	}
+}
+
+// TextAnchor 文本边界框上与文本位置重合的点，X 从左（0）到右（1），Y 从上（0）到下（1）
+type TextAnchor struct {
+	X, Y float64
+}
+
+// 常用锚点
+var (
+	AnchorCenter      = TextAnchor{0.5, 0.5}
+	AnchorTopLeft     = TextAnchor{0, 0}
+	AnchorTop         = TextAnchor{0.5, 0}
+	AnchorTopRight    = TextAnchor{1, 0}
+	AnchorLeft        = TextAnchor{0, 0.5}
+	AnchorRight       = TextAnchor{1, 0.5}
+	AnchorBottomLeft  = TextAnchor{0, 1}
+	AnchorBottom      = TextAnchor{0.5, 1}
+	AnchorBottomRight = TextAnchor{1, 1}
+)
+
+var textAnchors = map[string]TextAnchor{
+	"center":       AnchorCenter,
+	"top_left":     AnchorTopLeft,
+	"top":          AnchorTop,
+	"top_right":    AnchorTopRight,
+	"left":         AnchorLeft,
+	"right":        AnchorRight,
+	"bottom_left":  AnchorBottomLeft,
+	"bottom":       AnchorBottom,
+	"bottom_right": AnchorBottomRight,
+}
+
+// ParseTextAnchor 解析锚点名称，如 center、top_left、bottom（也接受 top-left）
+func ParseTextAnchor(name string) (TextAnchor, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	if anchor, ok := textAnchors[key]; ok {
+		return anchor, nil
+	}
+	return AnchorCenter, i18n.Errorf("text.invalid_anchor", name)
+}
+
+// TextLine 排版后的一行文本
+type TextLine struct {
+	Text     string
+	X        float64 // 行首相对边界框左边缘的偏移
+	Baseline float64 // 基线相对边界框上边缘的偏移（向下为正）
+	Width    float64
+	Runs     []TextRun // 富文本按片段切分的结果，普通文本为空
+}
+
+// TextLayout 文本排版结果，尺寸单位为像素
+type TextLayout struct {
+	Face   font.Face // 绘制所有行使用的字形，空文本时为 nil
+	Lines  []TextLine
+	Width  float64
+	Height float64
+}
+
+// layout 按换行符、最大宽度、对齐方式和行距排版文本
+func (t *Text) layout() TextLayout {
+	if t.text == "" {
+		return TextLayout{}
+	}
+
+	// 整段文本共用一个字体，保证各行字形一致
+	face, err := fonts.Default().Face(t.FontSpec(), t.RenderSize(), t.text)
+	if err != nil {
+		return TextLayout{}
+	}
+	measure := func(s string) float64 {
+		return float64(font.MeasureString(face, s)) / 64
+	}
+
+	var texts []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(t.text, "\r\n", "\n"), "\n") {
+		texts = append(texts, wrapText(paragraph, t.maxWidth, measure)...)
+	}
+
+	metrics := face.Metrics()
+	ascent := float64(metrics.Ascent) / 64
+	lineBox := ascent + float64(metrics.Descent)/64
+	lineHeight := lineBox * t.lineSpacing
+
+	layout := TextLayout{Face: face, Lines: make([]TextLine, len(texts))}
+	var runs [][]TextRun
+	var widths []float64
+	if len(t.spans) > 0 {
+		runs, widths = t.splitRuns(texts, face)
+	}
+	for i, s := range texts {
+		line := TextLine{Text: s, Baseline: ascent + float64(i)*lineHeight, Width: measure(s)}
+		if runs != nil {
+			line.Runs, line.Width = runs[i], widths[i]
+		}
+		layout.Lines[i] = line
+		w := line.Width
+		if w > layout.Width {
+			layout.Width = w
+		}
+	}
+	layout.Height = lineBox + float64(len(texts)-1)*lineHeight
+
+	for i := range layout.Lines {
+		line := &layout.Lines[i]
+		switch t.align {
+		case AlignLeft:
+			line.X = 0
+		case AlignRight:
+			line.X = layout.Width - line.Width
+		default:
+			line.X = (layout.Width - line.Width) / 2
+		}
+	}
+	return layout
+}
+
+// wrapText 将一段文本按 maxWidth 折行，maxWidth <= 0 时不折行。
+// 西文在空格处断行，中日韩文字可在任意两个字之间断行，
+// 行首不出现闭合标点；单个过长的单词按字符强制断开。
+func wrapText(paragraph string, maxWidth float64, measure func(string) float64) []string {
+	if maxWidth <= 0 || measure(paragraph) <= maxWidth {
+		return []string{paragraph}
+	}
+
+	var lines []string
+	line := ""
+	for _, seg := range breakSegments(paragraph) {
+		candidate := line + seg
+		if line == "" || measure(strings.TrimRight(candidate, " ")) <= maxWidth {
+			line = candidate
+		} else {
+			lines = append(lines, strings.TrimRight(line, " "))
+			line = strings.TrimLeft(seg, " ")
+		}
+
+		// 单个片段本身超宽时按字符拆开
+		for measure(strings.TrimRight(line, " ")) > maxWidth && len([]rune(line)) > 1 {
+			head, rest := splitToWidth(line, maxWidth, measure)
+			lines = append(lines, head)
+			line = rest
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return lines
+}
+
+// splitToWidth 取出不超过 maxWidth 的最长前缀（至少一个字符）
+func splitToWidth(s string, maxWidth float64, measure func(string) float64) (string, string) {
+	runes := []rune(s)
+	n := 1
+	for n < len(runes) && measure(string(runes[:n+1])) <= maxWidth {
+		n++
+	}
+	return string(runes[:n]), string(runes[n:])
+}
+
+// breakSegments 把文本切成不可再分的片段，片段之间是允许断行的位置。
+// 西文单词连同其后的空格为一个片段，中日韩字符各自成为片段，闭合标点附着在前一片段上。
+func breakSegments(s string) []string {
+	var segments []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, string(current))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case isClosingPunct(r):
+			// 附着在前一片段上
+			if len(current) == 0 && len(segments) > 0 {
+				segments[len(segments)-1] += string(r)
+				continue
+			}
+			current = append(current, r)
+		case isWideRune(r):
+			flush()
+			current = append(current, r)
+			flush()
+		case r == ' ':
+			current = append(current, r)
+			if i+1 < len(runes) && runes[i+1] != ' ' {
+				flush()
+			}
+		default:
+			if len(current) > 0 && current[len(current)-1] == ' ' {
+				flush()
+			}
+			current = append(current, r)
+		}
+	}
+	flush()
+	return segments
+}
+
+// isWideRune 判断是否为可在字间断行的中日韩字符
+func isWideRune(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		r >= 0x3000 && r <= 0x303F || // 中日韩标点
+		r >= 0xFF00 && r <= 0xFFEF // 全角字符
+}
+
+// isClosingPunct 判断是否为不能出现在行首的标点
+func isClosingPunct(r rune) bool {
+	return strings.ContainsRune("，。、；：！？）》」』】〕〉”’,.;:!?)]}…", r)
+}
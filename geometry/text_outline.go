@@ -0,0 +1,89 @@
+package geometry
+
+import (
+	"render2go/fonts"
+	gmMath "render2go/math"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Outline 把文本转换为由字形轮廓组成的路径。
+// 路径与文本的排版完全重合（包括旋转），使用文本的颜色填充、不描边，
+// 之后可以像其他图形一样描边、缩放、旋转和做动画，渲染时不再依赖字体。
+func (t *Text) Outline() (*Path, error) {
+	path := NewPath()
+	path.SetColor(t.GetColor())
+	path.SetFillOpacity(1.0)
+	path.SetStrokeWidth(0)
+
+	layout, topLeft := t.Layout()
+	size := t.RenderSize()
+	for _, line := range layout.Lines {
+		// 基线起点的逻辑坐标（y 轴向上）
+		origin := gmMath.Vector2{X: topLeft.X + line.X, Y: topLeft.Y - line.Baseline}
+		if len(line.Runs) == 0 {
+			if err := appendOutlines(path, t.FontSpec(), size, line.Text, origin); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for _, run := range line.Runs {
+			runOrigin := gmMath.Vector2{X: origin.X + run.X, Y: origin.Y}
+			if err := appendOutlines(path, t.spanFontSpec(run.Span), size, run.Text, runOrigin); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 与文本一样绕锚点旋转
+	if t.rotation != 0 {
+		points := path.GetPoints()
+		for i, p := range points {
+			points[i] = p.Sub(t.position).Rotate(t.rotation).Add(t.position)
+		}
+	}
+	return path, nil
+}
+
+// appendOutlines 把一段文字的字形轮廓追加到路径中，origin 为基线起点
+func appendOutlines(path *Path, spec fonts.Spec, size float64, text string, origin gmMath.Vector2) error {
+	outlines, err := fonts.Default().Outlines(spec, size, text)
+	if err != nil {
+		return err
+	}
+
+	var segments []PathSegment
+	for _, glyph := range outlines {
+		// 字形坐标 y 轴向下，转换为逻辑坐标
+		point := func(p fixed.Point26_6) gmMath.Vector2 {
+			return gmMath.Vector2{
+				X: origin.X + glyph.X + float64(p.X)/64,
+				Y: origin.Y - float64(p.Y)/64,
+			}
+		}
+		started := false
+		for _, seg := range glyph.Segments {
+			args := seg.Args
+			switch seg.Op {
+			case sfnt.SegmentOpMoveTo:
+				if started {
+					segments = append(segments, PathSegment{Command: PathClose})
+				}
+				segments = append(segments, PathSegment{Command: PathMove, Points: []gmMath.Vector2{point(args[0])}})
+				started = true
+			case sfnt.SegmentOpLineTo:
+				segments = append(segments, PathSegment{Command: PathLine, Points: []gmMath.Vector2{point(args[0])}})
+			case sfnt.SegmentOpQuadTo:
+				segments = append(segments, PathSegment{Command: PathQuad, Points: []gmMath.Vector2{point(args[0]), point(args[1])}})
+			case sfnt.SegmentOpCubeTo:
+				segments = append(segments, PathSegment{Command: PathCubic, Points: []gmMath.Vector2{point(args[0]), point(args[1]), point(args[2])}})
+			}
+		}
+		if started {
+			segments = append(segments, PathSegment{Command: PathClose})
+		}
+	}
+	path.AppendSegments(segments)
+	return nil
+}
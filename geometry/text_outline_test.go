@@ -0,0 +1,50 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	gmMath "render2go/math"
+)
+
+func TestTextOutlineRotated(t *testing.T) {
+	const angle = math.Pi / 2
+	anchor := gmMath.Vector2{X: 10, Y: 20}
+
+	text := NewText("Hi", 24)
+	text.SetAnchor(AnchorTopLeft).SetPosition(anchor.X, anchor.Y)
+	straight, err := text.Outline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text.SetRotation(angle)
+	rotated, err := text.Outline()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, got := straight.GetPoints(), rotated.GetPoints()
+	if len(want) == 0 || len(got) != len(want) {
+		t.Fatalf("rotated outline has %d points, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if p := want[i].Sub(anchor).Rotate(angle).Add(anchor); got[i].Distance(p) > 1e-9 {
+			t.Fatalf("point %d = %v, want %v rotated about the anchor", i, got[i], p)
+		}
+	}
+
+	// 旋转 90° 后文字从锚点向上排列，落在文本旋转后的边界框内
+	min, max := rotated.Bounds()
+	corners := text.GetPoints()
+	lo, hi := corners[0], corners[0]
+	for _, c := range corners[1:] {
+		lo.X, lo.Y = math.Min(lo.X, c.X), math.Min(lo.Y, c.Y)
+		hi.X, hi.Y = math.Max(hi.X, c.X), math.Max(hi.Y, c.Y)
+	}
+	if min.X < lo.X-1e-6 || min.Y < lo.Y-1e-6 || max.X > hi.X+1e-6 || max.Y > hi.Y+1e-6 {
+		t.Errorf("outline bounds %v, %v outside the rotated text bounds %v, %v", min, max, lo, hi)
+	}
+	if min.Y < anchor.Y-1e-6 {
+		t.Errorf("outline extends below the anchor: min = %v", min)
+	}
+}
@@ -0,0 +1,163 @@
+package geometry
+
+import (
+	"image/color"
+	"render2go/fonts"
+	"strings"
+
+	"golang.org/x/image/font"
+)
+
+// TextSpan 富文本中的一个片段，未设置的样式沿用所属文本的样式
+type TextSpan struct {
+	Text      string
+	Color     color.Color  // 文字颜色，nil 表示使用文本颜色
+	Weight    fonts.Weight // 字重，0 表示使用文本字重
+	Underline bool         // 是否绘制下划线
+	Highlight color.Color  // 背景高亮色，nil 表示不高亮
+}
+
+// TextRun 排版后一行中属于同一片段的连续文字
+type TextRun struct {
+	Text  string
+	Span  int       // 所属片段的序号
+	Face  font.Face // 该片段使用的字形
+	X     float64   // 相对行首的偏移
+	Width float64
+}
+
+// NewRichText 由多个片段创建文本对象，文本内容为各片段依次连接
+func NewRichText(spans []string, size float64) *Text {
+	t := NewText(strings.Join(spans, ""), size)
+	t.spans = make([]TextSpan, len(spans))
+	for i, s := range spans {
+		t.spans[i] = TextSpan{Text: s}
+	}
+	t.generateBounds()
+	return t
+}
+
+// SpanCount 返回富文本片段数量，普通文本为 0
+func (t *Text) SpanCount() int {
+	return len(t.spans)
+}
+
+// Spans 返回富文本片段的副本
+func (t *Text) Spans() []TextSpan {
+	return append([]TextSpan(nil), t.spans...)
+}
+
+// Span 返回第 i 个片段（从 0 开始）
+func (t *Text) Span(i int) (TextSpan, bool) {
+	if i < 0 || i >= len(t.spans) {
+		return TextSpan{}, false
+	}
+	return t.spans[i], true
+}
+
+// SetSpanColor 设置片段的文字颜色，nil 表示恢复为文本颜色
+func (t *Text) SetSpanColor(i int, c color.Color) *Text {
+	if i >= 0 && i < len(t.spans) {
+		t.spans[i].Color = c
+	}
+	return t
+}
+
+// SetSpanWeight 设置片段的字重，0 表示恢复为文本字重
+func (t *Text) SetSpanWeight(i int, weight fonts.Weight) *Text {
+	if i >= 0 && i < len(t.spans) {
+		t.spans[i].Weight = weight
+		t.generateBounds() // 字重影响字宽
+	}
+	return t
+}
+
+// SetSpanUnderline 设置片段是否带下划线
+func (t *Text) SetSpanUnderline(i int, underline bool) *Text {
+	if i >= 0 && i < len(t.spans) {
+		t.spans[i].Underline = underline
+	}
+	return t
+}
+
+// SetSpanHighlight 设置片段的背景高亮色，nil 表示取消高亮
+func (t *Text) SetSpanHighlight(i int, c color.Color) *Text {
+	if i >= 0 && i < len(t.spans) {
+		t.spans[i].Highlight = c
+	}
+	return t
+}
+
+// spanFontSpec 返回片段使用的字体描述
+func (t *Text) spanFontSpec(i int) fonts.Spec {
+	spec := t.FontSpec()
+	if w := t.spans[i].Weight; w != 0 {
+		spec.Weight = w
+	}
+	return spec
+}
+
+// spanIndexes 返回文本中每个字符所属的片段序号
+func (t *Text) spanIndexes() []int {
+	var indexes []int
+	for i, span := range t.spans {
+		for range span.Text {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// splitRuns 把排版后的各行按片段切分并测量，返回每行的片段和实际宽度。
+// 折行时会去掉行首行尾的空格，这里按原文逐字对齐找回每个字符所属的片段。
+func (t *Text) splitRuns(lines []string, fallback font.Face) ([][]TextRun, []float64) {
+	source := []rune(t.text)
+	indexes := t.spanIndexes()
+	faces := make(map[int]font.Face)
+	face := func(span int) font.Face {
+		if f, ok := faces[span]; ok {
+			return f
+		}
+		f, err := fonts.Default().Face(t.spanFontSpec(span), t.RenderSize(), t.spans[span].Text)
+		if err != nil {
+			f = fallback
+		}
+		faces[span] = f
+		return f
+	}
+
+	runs := make([][]TextRun, len(lines))
+	widths := make([]float64, len(lines))
+	cursor := 0
+	for li, line := range lines {
+		var lineRuns []TextRun
+		for _, r := range line {
+			// 跳过折行时去掉的空格和换行符
+			for cursor < len(source) && source[cursor] != r {
+				cursor++
+			}
+			if cursor >= len(source) {
+				break
+			}
+			span := indexes[cursor]
+			cursor++
+
+			if n := len(lineRuns); n > 0 && lineRuns[n-1].Span == span {
+				lineRuns[n-1].Text += string(r)
+			} else {
+				lineRuns = append(lineRuns, TextRun{Text: string(r), Span: span})
+			}
+		}
+
+		x := 0.0
+		for i := range lineRuns {
+			run := &lineRuns[i]
+			run.Face = face(run.Span)
+			run.X = x
+			run.Width = float64(font.MeasureString(run.Face, run.Text)) / 64
+			x += run.Width
+		}
+		runs[li], widths[li] = lineRuns, x
+	}
+	return runs, widths
+}
@@ -0,0 +1,358 @@
+package geometry
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"render2go/colors"
+	"render2go/core"
+	gmMath "render2go/math"
+	"strings"
+)
+
+// 跟踪对象的绘制参数（场景长度）
+const (
+	trackerDotRadius   = 4.0  // 圆点半径
+	trackerLabelOffset = 8.0  // 标签左下角相对圆点的偏移
+	trackerLabelSize   = 14.0 // 标签字号
+)
+
+// TrackerKind 跟踪对象的种类
+type TrackerKind int
+
+const (
+	TrackerPoint   TrackerKind = iota // 只有圆点（和标签）
+	TrackerTangent                    // 切线
+	TrackerSecant                     // 割线，连接 x 和 x + dx 两处
+)
+
+// GraphTracker 绑定在函数图像 y = f(x) 上参数 x 处的跟踪对象：圆点、可选的切线或割线，以及跟随圆点的标签。
+// 切线斜率用中心差分数值计算；x、dx 等参数改变时整体重新生成，因此可以用数值动画连续移动。
+// 标签文本中的 {x}、{y}、{slope} 会被替换为当前的值
+type GraphTracker struct {
+	*PathGroup
+	axes        *CoordinateSystem
+	function    func(x float64) float64
+	kind        TrackerKind
+	x           float64
+	dx          float64 // 割线第二个点相对 x 的偏移
+	length      float64 // 直线的长度（场景长度），0 表示延伸到坐标系边界
+	labelFormat string
+	label       *Text
+	color       color.Color
+	strokeWidth float64
+}
+
+// NewGraphTracker 在坐标系 axes 中创建绑定在 function 上 x 处的跟踪对象，dx 只对割线有效
+func NewGraphTracker(axes *CoordinateSystem, function func(x float64) float64, kind TrackerKind, x, dx float64) *GraphTracker {
+	tracker := &GraphTracker{
+		PathGroup:   NewPathGroup(nil),
+		axes:        axes,
+		function:    function,
+		kind:        kind,
+		x:           x,
+		dx:          dx,
+		color:       colors.MidBlue,
+		strokeWidth: 2.0,
+	}
+	tracker.Generate()
+	return tracker
+}
+
+// Axes 获取所在的坐标系
+func (t *GraphTracker) Axes() *CoordinateSystem {
+	return t.axes
+}
+
+// Kind 获取跟踪对象的种类
+func (t *GraphTracker) Kind() TrackerKind {
+	return t.kind
+}
+
+// X 获取跟踪的参数 x
+func (t *GraphTracker) X() float64 {
+	return t.x
+}
+
+// SetX 设置参数 x 并重新生成
+func (t *GraphTracker) SetX(x float64) *GraphTracker {
+	t.x = x
+	t.Generate()
+	return t
+}
+
+// DX 获取割线两点的 x 间距
+func (t *GraphTracker) DX() float64 {
+	return t.dx
+}
+
+// SetDX 设置割线两点的 x 间距并重新生成，间距为 0 时割线即为切线
+func (t *GraphTracker) SetDX(dx float64) *GraphTracker {
+	t.dx = dx
+	t.Generate()
+	return t
+}
+
+// Length 获取直线的长度，0 表示延伸到坐标系边界
+func (t *GraphTracker) Length() float64 {
+	return t.length
+}
+
+// SetLength 设置直线的长度（场景长度）并重新生成，直线以切点（割线为两点的中点）为中心
+func (t *GraphTracker) SetLength(length float64) *GraphTracker {
+	t.length = math.Max(0, length)
+	t.Generate()
+	return t
+}
+
+// LabelFormat 获取标签文本格式，为空表示没有标签
+func (t *GraphTracker) LabelFormat() string {
+	return t.labelFormat
+}
+
+// SetLabel 设置标签文本格式，{x}、{y}、{slope} 会被替换为当前的值，为空时不显示标签
+func (t *GraphTracker) SetLabel(format string) *GraphTracker {
+	t.labelFormat = format
+	t.Generate()
+	return t
+}
+
+// Label 返回当前的标签，没有标签或圆点不可见时为 nil
+func (t *GraphTracker) Label() *Text {
+	return t.label
+}
+
+// Y 返回 x 处的函数值
+func (t *GraphTracker) Y() float64 {
+	return t.function(t.x)
+}
+
+// Slope 返回切线（割线）的斜率，无定义时为 NaN
+func (t *GraphTracker) Slope() float64 {
+	if t.kind == TrackerSecant && t.dx != 0 {
+		return (t.function(t.x+t.dx) - t.function(t.x)) / t.dx
+	}
+	return derivativeAt(t.function, t.x)
+}
+
+// derivativeAt 用中心差分计算 f 在 x 处的导数，一侧无定义时改用单侧差分
+func derivativeAt(f func(x float64) float64, x float64) float64 {
+	h := 1e-5 * math.Max(1, math.Abs(x))
+	y0, left, right := f(x), f(x-h), f(x+h)
+	switch {
+	case finite(left) && finite(right):
+		return (right - left) / (2 * h)
+	case finite(right):
+		return (right - y0) / h
+	default:
+		return (y0 - left) / h
+	}
+}
+
+// Generate 按坐标系当前的范围和单位以及当前参数重新生成直线、圆点和标签。
+// x 超出坐标系的 x 范围或函数无定义时什么都不画
+func (t *GraphTracker) Generate() *GraphTracker {
+	t.parts = t.parts[:0]
+	t.label = nil
+	y := t.function(t.x)
+	xRange := t.axes.XRange()
+	if !finite(y) || t.x < xRange[0] || t.x > xRange[1] {
+		return t
+	}
+	point := gmMath.Vector2{X: t.x, Y: y}
+	slope := t.Slope()
+
+	if t.kind != TrackerPoint && finite(slope) {
+		// 方向在场景中计算，对数轴上也与曲线在该点相切或经过两个交点
+		h := 1e-6 * math.Max(1, math.Abs(t.x))
+		step := gmMath.Vector2{X: h, Y: slope * h}
+		a, b := t.axes.CoordinateToPoint(point.Sub(step)), t.axes.CoordinateToPoint(point.Add(step))
+		center := t.axes.CoordinateToPoint(point)
+		if t.kind == TrackerSecant && t.dx != 0 {
+			a = center
+			b = t.axes.CoordinateToPoint(gmMath.Vector2{X: t.x + t.dx, Y: t.function(t.x + t.dx)})
+			center = a.Add(b).Scale(0.5)
+		}
+		if a, b, ok := t.lineSegment(center, b.Sub(a)); ok {
+			line := NewPath()
+			line.setPolylines([][]gmMath.Vector2{{a, b}})
+			line.SetColor(t.color)
+			line.SetStrokeWidth(t.strokeWidth)
+			t.parts = append(t.parts, line)
+		}
+	}
+
+	dots := []gmMath.Vector2{point}
+	if t.kind == TrackerSecant && t.dx != 0 {
+		dots = append(dots, gmMath.Vector2{X: t.x + t.dx, Y: t.function(t.x + t.dx)})
+	}
+	for i, dot := range dots {
+		if !finite(dot.Y) || !t.axes.IsInRange(dot.X, dot.Y) {
+			if i == 0 {
+				return t // 圆点不可见时标签也不显示
+			}
+			continue
+		}
+		t.parts = append(t.parts, t.newDot(t.axes.CoordinateToPoint(dot)))
+	}
+
+	if t.labelFormat != "" {
+		replacer := strings.NewReplacer("{x}", formatValue(t.x), "{y}", formatValue(y), "{slope}", formatValue(slope))
+		p := t.axes.CoordinateToPoint(point)
+		t.label = t.axes.newLabel(replacer.Replace(t.labelFormat), trackerLabelSize)
+		t.label.SetAnchor(AnchorBottomLeft)
+		t.label.SetPosition(p.X+trackerLabelOffset, p.Y+trackerLabelOffset)
+		t.label.SetColor(t.color)
+		t.label.SetFillOpacity(t.opacity)
+	}
+	return t
+}
+
+// lineSegment 返回经过场景点 center、方向为 direction 的直线在场景中的线段，截取在坐标系范围内
+func (t *GraphTracker) lineSegment(center, direction gmMath.Vector2) (gmMath.Vector2, gmMath.Vector2, bool) {
+	xRange, yRange := t.axes.XRange(), t.axes.YRange()
+	lo := t.axes.CoordinateToPoint(gmMath.Vector2{X: xRange[0], Y: yRange[0]})
+	hi := t.axes.CoordinateToPoint(gmMath.Vector2{X: xRange[1], Y: yRange[1]})
+	d := direction.Normalize()
+	half := t.length / 2
+	if half == 0 {
+		half = hi.Sub(lo).Length()
+	}
+	return clipSegment(center.Sub(d.Scale(half)), center.Add(d.Scale(half)), lo, hi)
+}
+
+// clipSegment 用 Liang-Barsky 算法把线段 ab 截取在矩形 [lo, hi] 内
+func clipSegment(a, b, lo, hi gmMath.Vector2) (gmMath.Vector2, gmMath.Vector2, bool) {
+	t0, t1 := 0.0, 1.0
+	d := b.Sub(a)
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return false
+			}
+			t1 = math.Min(t1, r)
+		}
+		return true
+	}
+	if !clip(-d.X, a.X-lo.X) || !clip(d.X, hi.X-a.X) || !clip(-d.Y, a.Y-lo.Y) || !clip(d.Y, hi.Y-a.Y) || t0 >= t1 {
+		return a, b, false
+	}
+	return a.Add(d.Scale(t0)), a.Add(d.Scale(t1)), true
+}
+
+// newDot 创建以 center 为圆心的实心圆点
+func (t *GraphTracker) newDot(center gmMath.Vector2) *Path {
+	r := gmMath.Vector2{X: trackerDotRadius}
+	dot := NewPath()
+	dot.NewSubpath(center.Add(r)).
+		ArcTo(trackerDotRadius, trackerDotRadius, 0, false, true, center.Sub(r)).
+		ArcTo(trackerDotRadius, trackerDotRadius, 0, false, true, center.Add(r)).
+		Close()
+	dot.SetColor(t.color)
+	dot.SetStrokeWidth(0)
+	dot.SetFillOpacity(1.0)
+	return dot
+}
+
+// formatValue 格式化标签中的数值，保留两位小数
+func formatValue(v float64) string {
+	if !finite(v) {
+		return "?"
+	}
+	s := fmt.Sprintf("%.2f", v)
+	if s == "-0.00" {
+		s = "0.00"
+	}
+	return s
+}
+
+// GetColor 获取颜色
+func (t *GraphTracker) GetColor() color.Color {
+	return t.color
+}
+
+// SetColor 设置直线、圆点和标签的颜色
+func (t *GraphTracker) SetColor(c color.Color) {
+	t.color = c
+	t.PathGroup.SetColor(c)
+	if t.label != nil {
+		t.label.SetColor(c)
+	}
+}
+
+// GetStrokeWidth 获取直线的线宽
+func (t *GraphTracker) GetStrokeWidth() float64 {
+	return t.strokeWidth
+}
+
+// SetStrokeWidth 设置直线的线宽，圆点不受影响
+func (t *GraphTracker) SetStrokeWidth(width float64) {
+	t.strokeWidth = width
+	t.Generate()
+}
+
+// SetFillOpacity 设置整体透明度，标签随之改变
+func (t *GraphTracker) SetFillOpacity(opacity float64) {
+	t.PathGroup.SetFillOpacity(opacity)
+	if t.label != nil {
+		t.label.SetFillOpacity(opacity)
+	}
+}
+
+// Shift 平移直线、圆点和标签
+func (t *GraphTracker) Shift(offset gmMath.Vector2) core.Mobject {
+	t.PathGroup.Shift(offset)
+	if t.label != nil {
+		t.label.MoveTo(t.label.GetCenter().Add(offset))
+	}
+	return t
+}
+
+// MoveTo 平移跟踪对象，使直线和圆点的边界框中心位于指定位置
+func (t *GraphTracker) MoveTo(pos gmMath.Vector2) core.Mobject {
+	return t.Shift(pos.Sub(t.GetCenter()))
+}
+
+// Scale 以边界框中心为基准缩放，标签位置随之缩放，字号不变
+func (t *GraphTracker) Scale(factor float64) core.Mobject {
+	center := t.GetCenter()
+	t.PathGroup.Scale(factor)
+	if t.label != nil {
+		t.label.MoveTo(center.Add(t.label.GetCenter().Sub(center).Scale(factor)))
+	}
+	return t
+}
+
+// Rotate 绕边界框中心旋转，标签只移动位置
+func (t *GraphTracker) Rotate(angle float64) core.Mobject {
+	center := t.GetCenter()
+	t.PathGroup.Rotate(angle)
+	if t.label != nil {
+		t.label.MoveTo(rotateAbout(center, angle)(t.label.GetCenter()))
+	}
+	return t
+}
+
+// Copy 复制跟踪对象，与原对象共享坐标系和函数
+func (t *GraphTracker) Copy() core.Mobject {
+	c := *t
+	c.PathGroup = t.copyGroup()
+	if t.label != nil {
+		c.label = NewText(t.label.GetText(), t.label.GetSize())
+		c.label.sizeRange = t.label.sizeRange
+		c.label.SetAnchor(t.label.GetAnchor())
+		c.label.MoveTo(t.label.GetCenter())
+		c.label.SetColor(t.label.GetColor())
+		c.label.SetFillOpacity(t.label.GetFillOpacity())
+	}
+	return &c
+}
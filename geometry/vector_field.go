@@ -0,0 +1,585 @@
+package geometry
+
+import (
+	"image/color"
+	"math"
+	"render2go/colors"
+	"render2go/core"
+	gmMath "render2go/math"
+)
+
+// 向量场和流线的绘制参数
+const (
+	vectorFieldArrowScale = 0.8  // 最长箭头占网格间距的比例
+	vectorFieldHeadRatio  = 0.35 // 箭头头部占箭头长度的最大比例
+	streamlineMaxTime     = 30.0 // 流线单向积分的最长时间（秒）
+	streamlineSelfGap     = 12   // 流线回到自己 streamlineSelfGap 步之前经过的单元时停止（闭合轨道）
+	streamlineFlowDim     = 0.3  // 流动时流线本身的透明度倍数，以突出粒子
+)
+
+// VectorField 坐标系中的向量场：在网格点上画出箭头，箭头以网格点为中心，
+// 长度与向量的模成正比（最长的箭头约占网格间距的 80%），颜色按模的大小取自色图。
+// 流动时从各网格点释放沿场运动的粒子
+type VectorField struct {
+	axes        *CoordinateSystem
+	function    func(x, y float64) gmMath.Vector2
+	spacing     float64 // 网格间距（坐标单位）
+	arrows      []*Arrow
+	colormap    *colors.Colormap
+	color       color.Color // 不使用色图时的颜色
+	strokeWidth float64
+	opacity     float64
+	flowSpeed   float64
+	flowTime    float64
+	particles   *flowParticles
+}
+
+// NewVectorField 在坐标系 axes 的范围内按间距 spacing 画出向量场 function，spacing 不大于 0 时使用坐标系的网格间距
+func NewVectorField(axes *CoordinateSystem, function func(x, y float64) gmMath.Vector2, spacing float64) *VectorField {
+	if spacing <= 0 {
+		spacing = axes.GridSpacing()
+	}
+	field := &VectorField{
+		axes:        axes,
+		function:    function,
+		spacing:     spacing,
+		colormap:    &colors.Rainbow,
+		color:       color.RGBA{255, 255, 255, 255},
+		strokeWidth: 2.0,
+		opacity:     1.0,
+		flowSpeed:   1.0,
+	}
+	field.Generate()
+	return field
+}
+
+// fieldGrid 返回覆盖坐标系范围的网格点（坐标），网格在范围内居中
+func fieldGrid(axes *CoordinateSystem, spacing float64) []gmMath.Vector2 {
+	axis := func(r [2]float64) []float64 {
+		n := int(math.Floor((r[1]-r[0])/spacing + 1e-9))
+		if n < 1 {
+			n = 1
+		}
+		center := (r[0] + r[1]) / 2
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = center + (float64(i)-float64(n-1)/2)*spacing
+		}
+		return values
+	}
+	var points []gmMath.Vector2
+	for _, y := range axis(axes.YRange()) {
+		for _, x := range axis(axes.XRange()) {
+			points = append(points, gmMath.Vector2{X: x, Y: y})
+		}
+	}
+	return points
+}
+
+// Axes 获取所在的坐标系
+func (v *VectorField) Axes() *CoordinateSystem {
+	return v.axes
+}
+
+// Spacing 获取网格间距
+func (v *VectorField) Spacing() float64 {
+	return v.spacing
+}
+
+// Arrows 返回组成向量场的箭头，模为 0 或无定义的网格点没有箭头
+func (v *VectorField) Arrows() []*Arrow {
+	return v.arrows
+}
+
+// Trails 返回当前流动时间下各粒子的拖尾，未流动时为空
+func (v *VectorField) Trails() []*Path {
+	if v.flowTime == 0 {
+		return nil
+	}
+	return v.particles.trails
+}
+
+// Generate 按坐标系当前的范围和单位重新生成箭头和粒子轨迹
+func (v *VectorField) Generate() *VectorField {
+	field := flowField{axes: v.axes, function: v.function}
+	xUnit, yUnit := v.axes.Units()
+	maxLength := vectorFieldArrowScale * v.spacing * math.Min(xUnit, yUnit)
+
+	type gridVector struct {
+		point gmMath.Vector2
+		value gmMath.Vector2
+	}
+	var vectors []gridVector
+	maxNorm := 0.0
+	for _, p := range fieldGrid(v.axes, v.spacing) {
+		value, ok := field.at(p)
+		if !ok {
+			continue
+		}
+		vectors = append(vectors, gridVector{p, value})
+		maxNorm = math.Max(maxNorm, value.Length())
+	}
+
+	v.arrows = v.arrows[:0]
+	v.particles = &flowParticles{}
+	for _, vec := range vectors {
+		norm := vec.value.Length()
+		if norm == 0 || maxNorm == 0 {
+			continue
+		}
+		// 方向按坐标系的单位换算到场景中，长度按模的比例缩放
+		direction := gmMath.Vector2{X: vec.value.X * xUnit, Y: vec.value.Y * yUnit}.Normalize()
+		length := maxLength * norm / maxNorm
+		center := v.axes.CoordinateToPoint(vec.point)
+		arrow := NewArrow(center.Sub(direction.Scale(length/2)), center.Add(direction.Scale(length/2)))
+		arrow.SetHeadSize(math.Min(vectorFieldHeadRatio*length, 0.25*maxLength))
+		if v.colormap != nil {
+			arrow.SetColor(v.colormap.At(norm / maxNorm))
+		} else {
+			arrow.SetColor(v.color)
+		}
+		arrow.SetStrokeWidth(v.strokeWidth)
+		arrow.SetFillOpacity(v.opacity)
+		v.arrows = append(v.arrows, arrow)
+
+		v.particles.add(field.integrate(vec.point, 1, v.spacing/flowStepsPerSpacing, flowParticleLife, nil))
+	}
+	v.updateTrails()
+	return v
+}
+
+func (v *VectorField) updateTrails() {
+	v.particles.update(v.flowTime*v.flowSpeed, v.colormap, v.color, v.strokeWidth)
+}
+
+// Colormap 获取色图，未设置时为 nil
+func (v *VectorField) Colormap() *colors.Colormap {
+	return v.colormap
+}
+
+// SetColormap 按向量的模给箭头着色，传入 nil 时所有箭头使用同一颜色
+func (v *VectorField) SetColormap(colormap *colors.Colormap) *VectorField {
+	v.colormap = colormap
+	v.Generate()
+	return v
+}
+
+// FlowSpeed 获取流动速度
+func (v *VectorField) FlowSpeed() float64 {
+	return v.flowSpeed
+}
+
+// SetFlowSpeed 设置流动速度：粒子按场的速度乘以该倍数运动
+func (v *VectorField) SetFlowSpeed(speed float64) *VectorField {
+	v.flowSpeed = speed
+	v.updateTrails()
+	return v
+}
+
+// FlowTime 获取流动时间（秒）
+func (v *VectorField) FlowTime() float64 {
+	return v.flowTime
+}
+
+// SetFlowTime 设置流动时间，粒子从各网格点出发沿场运动，运动一段时间后回到出发点重新出发
+func (v *VectorField) SetFlowTime(t float64) {
+	v.flowTime = t
+	v.updateTrails()
+}
+
+// GetPoints 返回所有箭头的点，依次拼接
+func (v *VectorField) GetPoints() []gmMath.Vector2 {
+	var points []gmMath.Vector2
+	for _, arrow := range v.arrows {
+		points = append(points, arrow.GetPoints()...)
+	}
+	return points
+}
+
+// SetPoints 按各箭头的点数把点分配回各箭头
+func (v *VectorField) SetPoints(points []gmMath.Vector2) {
+	for _, arrow := range v.arrows {
+		n := len(arrow.GetPoints())
+		if n > len(points) {
+			n = len(points)
+		}
+		arrow.SetPoints(points[:n])
+		points = points[n:]
+	}
+}
+
+// GetColor 获取不使用色图时的颜色
+func (v *VectorField) GetColor() color.Color {
+	return v.color
+}
+
+// SetColor 所有箭头使用同一颜色，并取消色图
+func (v *VectorField) SetColor(c color.Color) {
+	v.color = c
+	v.colormap = nil
+	for _, arrow := range v.arrows {
+		arrow.SetColor(c)
+	}
+	v.updateTrails()
+}
+
+// GetStrokeWidth 获取箭头线宽
+func (v *VectorField) GetStrokeWidth() float64 {
+	return v.strokeWidth
+}
+
+// SetStrokeWidth 设置箭头线宽，粒子拖尾的线宽随之改变
+func (v *VectorField) SetStrokeWidth(width float64) {
+	v.strokeWidth = width
+	for _, arrow := range v.arrows {
+		arrow.SetStrokeWidth(width)
+	}
+	v.updateTrails()
+}
+
+// GetFillOpacity 获取整体透明度
+func (v *VectorField) GetFillOpacity() float64 {
+	return v.opacity
+}
+
+// SetFillOpacity 设置整体透明度，淡入淡出动画通过它改变向量场的不透明度
+func (v *VectorField) SetFillOpacity(opacity float64) {
+	v.opacity = opacity
+	for _, arrow := range v.arrows {
+		arrow.SetFillOpacity(opacity)
+	}
+}
+
+// GetCenter 返回所有箭头的边界框中心
+func (v *VectorField) GetCenter() gmMath.Vector2 {
+	return boundsCenter(v.GetPoints())
+}
+
+// transform 对箭头和粒子轨迹做同一变换
+func (v *VectorField) transform(fn func(gmMath.Vector2) gmMath.Vector2) {
+	for _, arrow := range v.arrows {
+		points := arrow.GetPoints()
+		for i := range points {
+			points[i] = fn(points[i])
+		}
+	}
+	v.particles.transform(fn)
+}
+
+// Shift 平移向量场
+func (v *VectorField) Shift(offset gmMath.Vector2) core.Mobject {
+	v.transform(func(p gmMath.Vector2) gmMath.Vector2 { return p.Add(offset) })
+	return v
+}
+
+// MoveTo 平移向量场，使边界框中心位于指定位置
+func (v *VectorField) MoveTo(pos gmMath.Vector2) core.Mobject {
+	return v.Shift(pos.Sub(v.GetCenter()))
+}
+
+// Scale 以边界框中心为基准缩放
+func (v *VectorField) Scale(factor float64) core.Mobject {
+	center := v.GetCenter()
+	v.transform(func(p gmMath.Vector2) gmMath.Vector2 { return center.Add(p.Sub(center).Scale(factor)) })
+	return v
+}
+
+// Rotate 绕边界框中心旋转
+func (v *VectorField) Rotate(angle float64) core.Mobject {
+	center := v.GetCenter()
+	v.transform(rotateAbout(center, angle))
+	return v
+}
+
+// Copy 复制向量场，与原对象共享坐标系和函数
+func (v *VectorField) Copy() core.Mobject {
+	c := *v
+	c.arrows = make([]*Arrow, len(v.arrows))
+	for i, arrow := range v.arrows {
+		line := &Line{BaseMobject: arrow.BaseMobject.Copy().(*core.BaseMobject), start: arrow.start, end: arrow.end}
+		c.arrows[i] = &Arrow{Line: line, headSize: arrow.headSize}
+	}
+	c.particles = v.particles.copyParticles()
+	return &c
+}
+
+// StreamLines 坐标系中向量场的流线：从均匀分布的网格点出发，用 RK4 沿场正反两个方向积分，
+// 进入其他流线附近或回到自身经过的位置时停止，使流线分布比较均匀。
+// 每条流线按平均速度取色图中的颜色；流动时粒子沿流线运动，快慢与场的速度一致
+type StreamLines struct {
+	*PathGroup
+	axes      *CoordinateSystem
+	function  func(x, y float64) gmMath.Vector2
+	spacing   float64 // 起点之间的间距（坐标单位），也决定流线之间的最小距离
+	colormap  *colors.Colormap
+	speeds    []float64 // 各流线的平均速度
+	flowSpeed float64
+	flowTime  float64
+	particles *flowParticles
+}
+
+// NewStreamLines 在坐标系 axes 的范围内追踪向量场 function 的流线，spacing 不大于 0 时使用坐标系的网格间距
+func NewStreamLines(axes *CoordinateSystem, function func(x, y float64) gmMath.Vector2, spacing float64) *StreamLines {
+	if spacing <= 0 {
+		spacing = axes.GridSpacing()
+	}
+	lines := &StreamLines{
+		PathGroup: NewPathGroup(nil),
+		axes:      axes,
+		function:  function,
+		spacing:   spacing,
+		colormap:  &colors.Rainbow,
+		flowSpeed: 1.0,
+	}
+	lines.Generate()
+	return lines
+}
+
+// Axes 获取所在的坐标系
+func (s *StreamLines) Axes() *CoordinateSystem {
+	return s.axes
+}
+
+// Spacing 获取起点间距
+func (s *StreamLines) Spacing() float64 {
+	return s.spacing
+}
+
+// Trails 返回当前流动时间下各粒子的拖尾，未流动时为空
+func (s *StreamLines) Trails() []*Path {
+	if s.flowTime == 0 {
+		return nil
+	}
+	return s.particles.trails
+}
+
+// LineOpacity 流线本身的透明度：流动时变暗以突出粒子
+func (s *StreamLines) LineOpacity() float64 {
+	if s.flowTime == 0 {
+		return s.GetFillOpacity()
+	}
+	return s.GetFillOpacity() * streamlineFlowDim
+}
+
+// Generate 按坐标系当前的范围和单位重新追踪所有流线
+func (s *StreamLines) Generate() *StreamLines {
+	field := flowField{axes: s.axes, function: s.function}
+	xUnit, yUnit := s.axes.Units()
+	step := s.spacing / flowStepsPerSpacing
+	cellSize := s.spacing * math.Min(xUnit, yUnit) / 2
+
+	// 占用网格：记录每个单元被哪条流线的第几步经过
+	type occupant struct{ line, index int }
+	occupied := make(map[[2]int]occupant)
+	cellOf := func(p gmMath.Vector2) [2]int {
+		return [2]int{int(math.Floor(p.X / cellSize)), int(math.Floor(p.Y / cellSize))}
+	}
+
+	// 重新追踪时沿用当前的颜色和线宽
+	var lineColor color.Color = color.RGBA{255, 255, 255, 255}
+	strokeWidth := 2.0
+	if len(s.parts) > 0 {
+		lineColor, strokeWidth = s.GetColor(), s.GetStrokeWidth()
+	}
+
+	s.parts = s.parts[:0]
+	s.speeds = s.speeds[:0]
+	s.particles = &flowParticles{}
+	for _, seed := range fieldGrid(s.axes, s.spacing) {
+		if !s.axes.IsInRange(seed.X, seed.Y) {
+			continue
+		}
+		if _, taken := occupied[cellOf(s.axes.CoordinateToPoint(seed))]; taken {
+			continue
+		}
+		line := len(s.parts)
+		var marked [][2]int
+		trace := func(direction float64, sign int) []flowSample {
+			return field.integrate(seed, direction, step, streamlineMaxTime, func(p gmMath.Vector2, index int) bool {
+				cell := cellOf(p)
+				index *= sign
+				if o, ok := occupied[cell]; ok {
+					if o.line != line {
+						return true
+					}
+					if gap := o.index - index; gap > streamlineSelfGap || gap < -streamlineSelfGap {
+						return true
+					}
+					return false
+				}
+				occupied[cell] = occupant{line, index}
+				marked = append(marked, cell)
+				return false
+			})
+		}
+		forward := trace(1, 1)
+		backward := trace(-1, -1)
+
+		// 反向部分倒序接在正向部分之前，时间从 0 开始递增
+		var samples []flowSample
+		if len(backward) > 0 {
+			offset := backward[len(backward)-1].t
+			for i := len(backward) - 1; i >= 1; i-- {
+				sample := backward[i]
+				sample.t = offset - sample.t
+				samples = append(samples, sample)
+			}
+			for _, sample := range forward {
+				sample.t += offset
+				samples = append(samples, sample)
+			}
+		} else {
+			samples = forward
+		}
+		if len(samples) < 3 {
+			for _, cell := range marked {
+				delete(occupied, cell)
+			}
+			continue
+		}
+
+		points := make([]gmMath.Vector2, len(samples))
+		total := 0.0
+		for i, sample := range samples {
+			points[i] = sample.p
+			total += sample.speed
+		}
+		path := NewPath()
+		path.setPolylines([][]gmMath.Vector2{points})
+		path.SetColor(lineColor)
+		path.SetStrokeWidth(strokeWidth)
+		s.parts = append(s.parts, path)
+		s.speeds = append(s.speeds, total/float64(len(samples)))
+		s.particles.add(samples)
+	}
+	s.applyColormap()
+	return s
+}
+
+func (s *StreamLines) applyColormap() {
+	if s.colormap != nil {
+		maxSpeed := 0.0
+		for _, speed := range s.speeds {
+			maxSpeed = math.Max(maxSpeed, speed)
+		}
+		for i, part := range s.parts {
+			t := 0.5
+			if maxSpeed > 0 {
+				t = s.speeds[i] / maxSpeed
+			}
+			part.SetColor(s.colormap.At(t))
+		}
+	}
+	s.updateTrails()
+}
+
+func (s *StreamLines) updateTrails() {
+	s.particles.update(s.flowTime*s.flowSpeed, s.colormap, s.GetColor(), s.GetStrokeWidth())
+}
+
+// Colormap 获取色图，未设置时为 nil
+func (s *StreamLines) Colormap() *colors.Colormap {
+	return s.colormap
+}
+
+// SetColormap 按平均速度给各流线着色，传入 nil 时保持当前颜色
+func (s *StreamLines) SetColormap(colormap *colors.Colormap) *StreamLines {
+	s.colormap = colormap
+	s.applyColormap()
+	return s
+}
+
+// SetColor 所有流线使用同一颜色，并取消色图
+func (s *StreamLines) SetColor(c color.Color) {
+	s.colormap = nil
+	s.PathGroup.SetColor(c)
+	s.updateTrails()
+}
+
+// SetStrokeWidth 设置流线线宽，粒子拖尾的线宽随之改变
+func (s *StreamLines) SetStrokeWidth(width float64) {
+	s.PathGroup.SetStrokeWidth(width)
+	s.updateTrails()
+}
+
+// FlowSpeed 获取流动速度
+func (s *StreamLines) FlowSpeed() float64 {
+	return s.flowSpeed
+}
+
+// SetFlowSpeed 设置流动速度：粒子按场的速度乘以该倍数运动
+func (s *StreamLines) SetFlowSpeed(speed float64) *StreamLines {
+	s.flowSpeed = speed
+	s.updateTrails()
+	return s
+}
+
+// FlowTime 获取流动时间（秒）
+func (s *StreamLines) FlowTime() float64 {
+	return s.flowTime
+}
+
+// SetFlowTime 设置流动时间，粒子沿流线运动，到达终点后回到起点重新出发
+func (s *StreamLines) SetFlowTime(t float64) {
+	s.flowTime = t
+	s.updateTrails()
+}
+
+// Shift 平移流线和粒子轨迹
+func (s *StreamLines) Shift(offset gmMath.Vector2) core.Mobject {
+	s.PathGroup.Shift(offset)
+	s.particles.transform(func(p gmMath.Vector2) gmMath.Vector2 { return p.Add(offset) })
+	return s
+}
+
+// MoveTo 平移流线，使边界框中心位于指定位置
+func (s *StreamLines) MoveTo(pos gmMath.Vector2) core.Mobject {
+	return s.Shift(pos.Sub(s.GetCenter()))
+}
+
+// Scale 以边界框中心为基准缩放
+func (s *StreamLines) Scale(factor float64) core.Mobject {
+	center := s.GetCenter()
+	s.PathGroup.Scale(factor)
+	s.particles.transform(func(p gmMath.Vector2) gmMath.Vector2 { return center.Add(p.Sub(center).Scale(factor)) })
+	return s
+}
+
+// Rotate 绕边界框中心旋转
+func (s *StreamLines) Rotate(angle float64) core.Mobject {
+	center := s.GetCenter()
+	s.PathGroup.Rotate(angle)
+	s.particles.transform(rotateAbout(center, angle))
+	return s
+}
+
+// Copy 复制流线，与原对象共享坐标系和函数
+func (s *StreamLines) Copy() core.Mobject {
+	c := *s
+	c.PathGroup = s.copyGroup()
+	c.speeds = append([]float64(nil), s.speeds...)
+	c.particles = s.particles.copyParticles()
+	return &c
+}
+
+// rotateAbout 返回绕 center 旋转 angle 弧度的变换
+func rotateAbout(center gmMath.Vector2, angle float64) func(gmMath.Vector2) gmMath.Vector2 {
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	return func(p gmMath.Vector2) gmMath.Vector2 {
+		d := p.Sub(center)
+		return center.Add(gmMath.Vector2{X: d.X*cos - d.Y*sin, Y: d.X*sin + d.Y*cos})
+	}
+}
+
+// boundsCenter 返回点集边界框的中心，空点集返回原点
+func boundsCenter(points []gmMath.Vector2) gmMath.Vector2 {
+	if len(points) == 0 {
+		return gmMath.Vector2{}
+	}
+	min, max := points[0], points[0]
+	for _, p := range points[1:] {
+		min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+		max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+	}
+	return min.Add(max).Scale(0.5)
+}
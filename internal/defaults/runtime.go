@@ -0,0 +1,128 @@
+package defaults
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Runtime 可由配置文件覆盖的运行时默认值
+type Runtime struct {
+	Width      int        // 默认分辨率宽度
+	Height     int        // 默认分辨率高度
+	FPS        int        // 默认帧率
+	Background color.RGBA // 新场景的背景色
+	OutputRoot string     // 输出根目录
+	FontPaths  []string   // 额外字体文件或目录，优先于系统字体
+	FFmpegPath string     // FFmpeg 可执行文件
+	Theme      string     // 配色主题名称，为空表示不使用主题
+
+	// 文本渲染字号的范围，小于 0 表示不限制
+	TextMinSize float64
+	TextMaxSize float64
+}
+
+var (
+	runtimeMu      sync.RWMutex
+	currentRuntime = BuiltinRuntime()
+)
+
+// BuiltinRuntime 返回内置的运行时默认值
+func BuiltinRuntime() Runtime {
+	return Runtime{
+		Width:      1920,
+		Height:     1080,
+		FPS:        60,
+		Background: Colors.White,
+		OutputRoot: "output",
+		FFmpegPath: "ffmpeg",
+
+		TextMinSize: 8,
+		TextMaxSize: 36,
+	}
+}
+
+// CurrentRuntime 获取当前的运行时默认值
+func CurrentRuntime() Runtime {
+	runtimeMu.RLock()
+	defer runtimeMu.RUnlock()
+	r := currentRuntime
+	r.FontPaths = append([]string(nil), currentRuntime.FontPaths...)
+	return r
+}
+
+// SetRuntime 设置运行时默认值，零值字段使用内置默认值
+func SetRuntime(r Runtime) {
+	builtin := BuiltinRuntime()
+	if r.Width <= 0 || r.Height <= 0 {
+		r.Width, r.Height = builtin.Width, builtin.Height
+	}
+	if r.FPS <= 0 {
+		r.FPS = builtin.FPS
+	}
+	if r.Background == (color.RGBA{}) {
+		r.Background = builtin.Background
+	}
+	if r.OutputRoot == "" {
+		r.OutputRoot = builtin.OutputRoot
+	}
+	if r.FFmpegPath == "" {
+		r.FFmpegPath = builtin.FFmpegPath
+	}
+	if r.TextMinSize == 0 {
+		r.TextMinSize = builtin.TextMinSize
+	}
+	if r.TextMaxSize == 0 {
+		r.TextMaxSize = builtin.TextMaxSize
+	}
+	r.FontPaths = append([]string(nil), r.FontPaths...)
+
+	runtimeMu.Lock()
+	currentRuntime = r
+	runtimeMu.Unlock()
+}
+
+// OutputPath 将路径片段拼接到输出根目录下
+func OutputPath(elem ...string) string {
+	return CurrentRuntime().OutputPath(elem...)
+}
+
+// OutputPath 将路径片段拼接到 r 的输出根目录下
+func (r Runtime) OutputPath(elem ...string) string {
+	return filepath.Join(append([]string{r.OutputRoot}, elem...)...)
+}
+
+// ClampSize 将字号限制在 [min, max] 内，小于等于 0 的一端不限制
+func ClampSize(size, min, max float64) float64 {
+	if min > 0 && size < min {
+		size = min
+	}
+	if max > 0 && size > max {
+		size = max
+	}
+	return size
+}
+
+// SystemFontPaths 返回当前系统常见的字体文件路径
+func SystemFontPaths() []string {
+	if strings.Contains(os.Getenv("OS"), "Windows") {
+		return []string{
+			"C:/Windows/Fonts/msyh.ttc",    // 微软雅黑
+			"C:/Windows/Fonts/msyhbd.ttc",  // 微软雅黑 Bold
+			"C:/Windows/Fonts/simhei.ttf",  // 黑体
+			"C:/Windows/Fonts/simsun.ttc",  // 宋体
+			"C:/Windows/Fonts/arial.ttf",   // Arial (英文后备)
+			"C:/Windows/Fonts/calibri.ttf", // Calibri (英文后备)
+		}
+	}
+
+	// Linux/Unix/macOS
+	return []string{
+		"/usr/share/fonts/truetype/wqy/wqy-microhei.ttc",
+		"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+		"/System/Library/Fonts/PingFang.ttc", // macOS
+		"/System/Library/Fonts/Arial.ttf",    // macOS英文后备
+	}
+}
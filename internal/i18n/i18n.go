@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Locale 界面语言
+type Locale string
+
+// 支持的语言
+const (
+	ZhCN Locale = "zh-CN"
+	En   Locale = "en"
+)
+
+// DefaultLocale 未指定语言时使用的默认语言
+const DefaultLocale = ZhCN
+
+var current atomic.Value // Locale，当前生效的语言
+
+// catalogs 各语言的消息表，键相同
+var catalogs = map[Locale]map[string]string{
+	ZhCN: zhCNMessages,
+	En:   enMessages,
+}
+
+// SetLocale 设置进程默认语言。需要单独语言的调用方（如一次渲染）
+// 应当自己保存语言并使用 Tl、Errorfl，而不是修改进程默认语言
+func SetLocale(l Locale) {
+	current.Store(l)
+}
+
+// CurrentLocale 获取当前生效的语言
+func CurrentLocale() Locale {
+	if l, ok := current.Load().(Locale); ok {
+		return l
+	}
+	return DefaultLocale
+}
+
+// ParseLocale 解析语言标识，支持 "zh-CN"、"zh_CN.UTF-8"、"en"、"en_US" 等写法
+func ParseLocale(s string) (Locale, error) {
+	s = strings.TrimSpace(s)
+	// 去掉编码和修饰部分，如 en_US.UTF-8@euro
+	if i := strings.IndexAny(s, ".@"); i >= 0 {
+		s = s[:i]
+	}
+	lang := strings.ToLower(strings.SplitN(strings.ReplaceAll(s, "_", "-"), "-", 2)[0])
+
+	switch lang {
+	case "zh":
+		return ZhCN, nil
+	case "en":
+		return En, nil
+	default:
+		return "", fmt.Errorf("unsupported language %q (supported: zh-CN, en)", s)
+	}
+}
+
+// DetectLocale 从环境变量检测语言，依次检查 LC_ALL、LC_MESSAGES、LANG。
+// 未设置或为 C/POSIX 时返回默认语言，其他不支持的语言回退到英文
+func DetectLocale() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			return DefaultLocale
+		}
+		if l, err := ParseLocale(value); err == nil {
+			return l
+		}
+		return En
+	}
+	return DefaultLocale
+}
+
+// T 按当前语言查找消息并格式化
+func T(key string, args ...interface{}) string {
+	return Tl(CurrentLocale(), key, args...)
+}
+
+// Tl 按指定语言查找消息并格式化，l 为空时使用当前语言；
+// 缺失时回退到默认语言，仍缺失则返回键本身
+func Tl(l Locale, key string, args ...interface{}) string {
+	format := lookup(l, key)
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Errorf 按当前语言创建错误，消息格式支持 %w
+func Errorf(key string, args ...interface{}) error {
+	return Errorfl(CurrentLocale(), key, args...)
+}
+
+// Errorfl 按指定语言创建错误，l 为空时使用当前语言，消息格式支持 %w
+func Errorfl(l Locale, key string, args ...interface{}) error {
+	return fmt.Errorf(lookup(l, key), args...)
+}
+
+func lookup(l Locale, key string) string {
+	if l == "" {
+		l = CurrentLocale()
+	}
+	if msg, ok := catalogs[l][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
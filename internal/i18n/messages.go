@@ -0,0 +1,673 @@
+package i18n
+
+// zhCNMessages 简体中文消息表
+var zhCNMessages = map[string]string{
+	// 错误位置与错误类型
+	"error.position":       "行: %d, 列: %d",
+	"error.position_file":  "文件: %s, 行: %d, 列: %d",
+	"error.parse":          "语法错误 (%s): %s",
+	"error.parse_list":     "解析错误:\n%s",
+	"error.runtime":        "执行错误 (%s): %s",
+	"error.unknown_object": "执行错误 (%s): 对象 '%s' 不存在",
+	"error.execution":      "执行失败: %w",
+	"error.executions":     "执行失败:\n%s",
+	"error.open_file":      "打开文件 %s 失败: %w",
+	"error.read_source":    "读取 %s 失败: %w",
+
+	// 语法分析
+	"parse.expected_token":       "需要 %s，但得到了 '%s'",
+	"parse.expected_object_type": "需要对象类型（%s），但得到了 '%s'",
+	"parse.expected_property":    "需要属性名（%s），但得到了 '%s'",
+	"parse.expected_animation":   "需要动画类型（%s），但得到了 '%s'",
+	"parse.invalid_number":       "无法将 %q 解析为数字",
+	"parse.illegal_character":    "非法字符 '%s'，请检查输入",
+	"parse.unexpected_eof":       "脚本意外结束",
+	"parse.unknown_statement":    "未知语句类型: %s",
+	"parse.token.number":         "数字",
+	"parse.token.string":         "字符串",
+	"parse.token.identifier":     "标识符",
+
+	// 执行：通用
+	"eval.unknown_statement":   "未知语句类型: %T",
+	"eval.unknown_expression":  "未知表达式类型: %T",
+	"eval.no_scene":            "未定义场景，请先使用 'scene' 命令创建场景",
+	"eval.unknown_object_type": "未知对象类型: %s",
+	"eval.create_failed":       "创建对象 '%s' 失败: %v",
+	"eval.object_not_found":    "对象 '%s' 不存在",
+	"eval.set_value_failed":    "设置属性 '%s.%s' 时解析值失败: %v",
+	"eval.unknown_property":    "未知属性: %s",
+	"eval.parse_x":             "解析X坐标失败: %v",
+	"eval.parse_y":             "解析Y坐标失败: %v",
+
+	// 执行：创建对象
+	"circle.radius_required":       "创建圆形需要指定半径参数",
+	"circle.radius_after_position": "指定位置后还需要指定半径",
+	"circle.radius_parse":          "解析圆形半径参数失败: %w",
+	"circle.radius_type":           "圆形半径必须是数字，得到的是: %T",
+	"circle.radius_positive":       "圆形半径必须大于0，当前值: %v",
+	"triangle.params_required":     "三角形至少需要一个参数",
+	"triangle.unknown_type":        "未知三角形类型: %s，支持: equilateral, right, isosceles",
+	"triangle.equilateral_size":    "等边三角形需要指定边长",
+	"triangle.right_size":          "直角三角形需要指定宽度和高度",
+	"triangle.isosceles_size":      "等腰三角形需要指定尺寸",
+	"vertex.invalid_x":             "顶点 %d 的X坐标无效: %v",
+	"vertex.invalid_y":             "顶点 %d 的Y坐标无效: %v",
+	"vertex.not_coordinate":        "顶点 %d 必须是坐标 (x,y)",
+	"axes.unknown_type":            "未知坐标系类型: %s，支持: standard, small, large, viewport, auto, polar",
+	"axes.invalid_param":           "坐标系参数 %s 无效: %w",
+	"axes.param_count":             "坐标系需要 0 个（标准）、1 个（类型）或至少 5 个（自定义）参数，得到 %d 个",
+	"axes.polar_usage":             "用法: create axes <名称> \"polar\" [最大半径] [间距]",
+	"axes.unknown_option":          "坐标系不支持选项 %s，支持: x_scale, y_scale, angles, divisions",
+	"axes.scale_unknown":           "%s 必须是 linear 或 log，得到 %v",
+	"axes.log_range":               "对数刻度的 %s 轴范围必须为正数，得到 [%g, %g]",
+	"axes.polar_log":               "极坐标网格不支持对数刻度",
+	"axes.angles_unknown":          "angles 必须是 degrees 或 radians，得到 %v",
+	"axes.divisions_type":          "divisions 必须是正整数，得到 %v",
+	"axes.only":                    "属性 %s 只适用于坐标系",
+	"axes.positive_type":           "%s 必须是正数，得到 %v",
+	"axes.tick_step_type":          "%s 必须是非负数（0 表示自动），得到 %v",
+	"axes.rotation_type":           "%s 必须是角度（度），得到 %v",
+	"axes.tick_format":             "tick_format 必须是包含一个数字占位符的格式串（如 \"%%.2f\"），得到 %v",
+	"axes.switch_type":             "%s 必须是 true 或 false，得到 %v",
+	"axes.label_type":              "%s 必须是字符串，得到 %v",
+	"rect.size_required":           "矩形需要宽度和高度参数",
+	"line.points_required":         "直线需要起点和终点坐标参数",
+	"line.coordinates_required":    "直线参数必须是坐标表达式",
+	"arrow.points_required":        "箭头需要起点和终点坐标参数",
+	"arrow.coordinates_required":   "箭头参数必须是坐标表达式",
+	"polygon.points_required":      "多边形需要顶点数组参数",
+	"polygon.array_required":       "多边形参数必须是坐标数组",
+	"polygon.coordinates_required": "多边形数组只能包含坐标表达式",
+	"text.params_required":         "文本对象需要至少2个参数：文本内容和字体大小",
+	"text.content_parse":           "解析文本内容失败: %w",
+	"text.content_type":            "文本内容必须是字符串",
+	"text.size_parse":              "解析字体大小失败: %w",
+	"text.unknown_size_name":       "未知字体大小名称: %s",
+	"text.size_type":               "字体大小必须是数字或字体大小名称",
+	"text.size_positive":           "字体大小必须大于0",
+	"text.max_width_type":          "max_width 必须是非负数字",
+	"text.line_spacing_type":       "line_spacing 必须是大于0的数字",
+	"text.invalid_align":           "无效的对齐方式 %q（可用 left、center、right）",
+	"text.invalid_anchor":          "无效的锚点 %q（可用 center、top_left、top、top_right、left、right、bottom_left、bottom、bottom_right）",
+	"text.spans_empty":             "富文本至少需要一个片段",
+	"text.span_type":               "第 %d 个片段必须是字符串",
+	"text.no_spans":                "%s 不是由片段数组创建的富文本，不能设置 span 属性",
+	"text.span_index":              "片段序号 %s 无效（可用 0 到 %d 的整数）",
+	"text.underline_type":          "underline 必须是 true/false、on/off 或 1/0，得到 %s",
+	"text.span_property":           "片段不支持 %s 属性（可用 color、weight、underline、highlight）",
+	"outline.text_required":        "%s 不是文本对象，outline 需要文本对象名称或文本内容和字号",
+	"stroke_width.type":            "stroke_width 必须是非负数字",
+	"path.data_required":           "路径需要 SVG 路径数据字符串，如 \"M0 0 L 100 0\"",
+	"path.must_start_with_move":    "路径数据必须以 M 或 m 命令开始",
+	"path.unexpected_char":         "路径数据第 %[2]d 个字符处出现意外的 %[1]q",
+	"path.expected_number":         "路径数据第 %[2]d 个字符处需要数字，得到 %[1]q",
+	"path.unexpected_end":          "路径数据意外结束，命令缺少参数",
+	"path.invalid_flag":            "路径数据第 %d 个字符处的圆弧标志必须是 0 或 1",
+	"svg.file_required":            "svg 需要 SVG 文件路径字符串，如 \"assets/logo.svg\"",
+	"svg.height_type":              "svg 的高度必须是正数，得到 %v",
+	"svg.read_failed":              "读取 SVG 文件 %s 失败: %w",
+	"svg.invalid_xml":              "SVG 文件不是有效的 XML: %w",
+	"svg.not_svg":                  "文件的根元素不是 <svg>",
+	"svg.empty":                    "SVG 文件中没有可以绘制的形状",
+	"svg.invalid_transform":        "无法解析 transform 属性 %q",
+	"svg.invalid_path":             "path 元素的 d 属性无效: %w",
+	"svg.invalid_points":           "%s 元素的 points 属性无效: %w",
+	"image.file_required":          "image 需要图像文件路径字符串，如 \"assets/photo.png\"",
+	"image.size_type":              "图像的宽度和高度必须是正数，最多两个，得到 %v",
+	"image.read_failed":            "读取图像文件 %s 失败: %w",
+	"image.decode_failed":          "无法解码图像文件 %s（支持 PNG 和 JPEG）: %w",
+	"image.invalid_filter":         "无效的采样方式 %v，可选 nearest 或 bilinear",
+	"image.crop_type":              "crop 必须是 [x, y, 宽, 高] 形式的像素区域或 none",
+	"image.crop_outside":           "裁剪区域在图像范围之外",
+	"image.image_only":             "只有图像对象支持 %s 属性",
+	"graph.usage":                  "graph 需要坐标系名称和函数表达式，如 create graph g axes \"sin(x) * x\" -5 5",
+	"graph.not_axes":               "%s 不是坐标系对象",
+	"graph.range_type":             "函数图像的 x 范围必须是两个数字 xMin xMax，且 xMin < xMax",
+	"graph.invalid_function":       "函数表达式 %q 无效: %w",
+	"curve.parametric_usage":       "parametric 需要 x(t) 和 y(t) 两个表达式，如 create parametric c \"cos(3*t)\" \"sin(2*t)\" 0 6.28",
+	"curve.polar_usage":            "polar 需要 r(theta) 表达式，如 create polar r \"1 + cos(theta)\" 0 6.28",
+	"curve.range_type":             "参数范围必须是两个不同的数字（起点 终点），之后可以跟采样数",
+	"curve.resolution_type":        "采样数必须是不小于 2 的整数，得到 %v",
+	"curve.curve_only":             "只有参数曲线、极坐标曲线和等高线支持 %s 属性",
+	"implicit.usage":               "implicit 需要坐标系名称和 f(x, y) 表达式，可以跟等值或等值数组，如 create implicit c axes \"x^2 + y^2 = 4\"",
+	"implicit.levels_type":         "等值必须是数字或数字数组，如 [-1, 0, 1]",
+	"colormap.unknown":             "未知色图 %v，可选: %s",
+	"colormap.unsupported":         "对象不支持 %s 属性",
+	"field.usage":                  "vector_field 和 streamlines 需要坐标系名称和向量表达式，可以跟间距，如 create vector_field vf axes \"(-y, x)\" 1",
+	"field.components":             "向量表达式 %q 必须写成 (P, Q) 两个分量",
+	"field.spacing_type":           "间距必须是正数",
+	"field.flow_speed_type":        "flow_speed 必须是非负数",
+	"field.flow_only":              "只有向量场和流线支持 %s 属性",
+	"area.usage":                   "area 需要坐标系名称和一到两条曲线，可以跟 x 范围，如 create area a axes \"x^2\" 0 2",
+	"area.curve_type":              "曲线必须是以 x 为自变量的表达式字符串或函数图像的名称",
+	"area.not_graph":               "%s 不是函数图像",
+	"riemann.usage":                "riemann 需要坐标系名称和曲线，可以跟 x 范围和 n=、method= 选项，如 create riemann r axes \"x^2\" 0 2 n=8 method=mid",
+	"riemann.n_type":               "区间数 n 必须是 1 到 10000 之间的整数，得到 %v",
+	"riemann.method_unknown":       "未知取样方式 %v，可选: %s",
+	"riemann.unknown_option":       "riemann 不支持选项 %s，可用的选项: n, method",
+	"riemann.only":                 "只有黎曼和支持 %s 属性",
+	"tracker.usage":                "%s 需要坐标系名称、曲线和 x，如 create tangent t axes \"x^2\" 1",
+	"secant.usage":                 "%s 需要坐标系名称、曲线、x 和可选的 dx，如 create secant s axes \"x^2\" 1 0.5",
+	"tracker.number_type":          "%s 必须是数字，得到 %v",
+	"tracker.length_type":          "length 必须是非负数字，得到 %v",
+	"tracker.label_type":           "label 必须是字符串，得到 %v",
+	"tracker.unknown_option":       "%s 不支持选项 %s，可用的选项: %s",
+	"tracker.only":                 "只有切线、割线和函数图像上的点支持 %s 属性",
+	"expr.empty":                   "表达式为空",
+	"expr.unexpected_char":         "表达式第 %[2]d 个字符处出现意外的 %[1]q",
+	"expr.unexpected_token":        "表达式第 %[2]d 个字符处出现意外的 %[1]q",
+	"expr.unexpected_end":          "表达式意外结束",
+	"expr.invalid_number":          "表达式第 %[2]d 个字符处的数字 %[1]q 无效",
+	"expr.missing_paren":           "表达式第 %d 个字符处的左括号没有对应的右括号",
+	"expr.call_paren":              "函数 %s 需要括号参数，如 %[1]s(x)（第 %[2]d 个字符）",
+	"expr.unknown_function":        "表达式第 %[2]d 个字符处的未知函数 %[1]s",
+	"expr.arg_count":               "函数 %s 需要 %d 个参数，得到 %d 个",
+	"expr.unknown_variable":        "未知变量 %s，可用的变量: %s",
+	"expr.not_differentiable":      "函数 %s 不能符号求导",
+	"call.derivative_usage":        "derivative 需要表达式字符串和可选的变量名，如 derivative(\"x^3 - 2*x\") 或 derivative(\"t^2\", \"t\")",
+	"call.simplify_usage":          "simplify 需要一个表达式字符串，如 simplify(\"x + x\")",
+	"call.unknown_function":        "未知函数 %s，可用的函数: %s",
+	"call.axes_usage":              "用法: %s(x, y)，x 和 y 为数字",
+
+	// 执行：设置属性
+	"color.unknown_name":      "未知颜色名: %s",
+	"color.type":              "颜色必须是字符串（如 '#FF0000' 或颜色名），得到的是 %T",
+	"color.unsupported":       "对象不支持颜色属性",
+	"position.type":           "位置必须是坐标形式 (x, y)，得到的是 %T",
+	"position.unsupported":    "对象不支持位置属性",
+	"position.self_axes":      "坐标系不能定位在自己的坐标上",
+	"opacity.type":            "透明度必须是数字",
+	"opacity.unsupported":     "对象不支持透明度属性",
+	"size.type":               "尺寸必须是数字",
+	"size.unsupported":        "对象不支持尺寸属性",
+	"width.not_implemented":   "width 属性尚未实现",
+	"height.not_implemented":  "height 属性尚未实现",
+	"vertex.triangle_only":    "顶点属性只支持三角形对象",
+	"vertex.unknown_property": "未知顶点属性: %s，请使用 vertex1、vertex2 或 vertex3",
+	"vertex.type":             "顶点必须是坐标 (x, y)，得到的是 %T",
+	"vertex.value_invalid_x":  "顶点X坐标无效: %v",
+	"vertex.value_invalid_y":  "顶点Y坐标无效: %v",
+	"vertices.triangle_only":  "vertices 属性只支持三角形对象",
+	"vertices.type":           "vertices 必须是坐标数组 [(x1,y1), (x2,y2), (x3,y3)]，得到的是 %T",
+	"vertices.count":          "三角形顶点数组必须恰好包含3个坐标，得到 %d 个",
+	"vertices.element_type":   "顶点 %d 必须是坐标 (x,y)，得到的是 %T",
+
+	// 执行：动画
+	"anim.not_animatable":        "对象 '%s' 不支持动画",
+	"anim.move_target":           "移动动画需要目标位置",
+	"anim.move_type":             "移动动画参数必须是坐标",
+	"anim.scale_factor":          "缩放动画需要缩放倍数",
+	"anim.rotate_angle":          "旋转动画需要旋转角度",
+	"anim.color_target":          "颜色动画需要目标颜色",
+	"anim.color_type":            "颜色动画参数必须是字符串",
+	"anim.path_points":           "路径动画需要路径点数组",
+	"anim.path_type":             "路径动画参数必须是坐标数组",
+	"anim.path_point_type":       "路径点必须是坐标",
+	"anim.path_target":           "%s 不是路径或曲线对象，不能用作运动路径",
+	"anim.flow_target":           "%s 不是向量场或流线，不能做流动动画",
+	"anim.value_params":          "数值动画需要属性名字符串和目标数值，如 animate value r \"n\" 64 2",
+	"anim.value_property":        "%s 的属性 %s 不能做数值动画",
+	"anim.elastic_params":        "弹性动画需要属性名和目标值",
+	"anim.elastic_property_type": "弹性动画的属性名必须是字符串",
+	"anim.unsupported":           "不支持的动画类型: %s",
+
+	// 执行：渲染与文件
+	"render.frame_failed":         "渲染第 %d 帧失败: %w",
+	"render.no_active_scene":      "没有活动的场景",
+	"render.unsupported_renderer": "不支持的渲染器类型",
+	"render.no_font":              "未找到可用字体",
+	"tex.unexpected_end":          "公式意外结束",
+	"tex.unexpected_token":        "公式第 %[2]d 个字符处出现意外的 %[1]q",
+	"tex.unclosed_group":          "公式第 %d 个字符处的括号没有闭合",
+	"tex.unclosed_math":           "第 %d 个字符处的 $ 没有对应的结束 $",
+	"tex.unknown_command":         "公式第 %[2]d 个字符处有不支持的命令 \\%[1]s",
+	"tex.expected_brace":          "公式第 %d 个字符处应为 {",
+	"tex.double_script":           "公式第 %[2]d 个字符处重复的 %[1]s",
+	"tex.invalid_delimiter":       "公式第 %[2]d 个字符处的 %[1]q 不是有效的定界符",
+	"tex.missing_right":           "公式第 %d 个字符处的 \\left 缺少对应的 \\right",
+	"tex.unknown_environment":     "公式第 %[2]d 个字符处有不支持的环境 %[1]s（支持 matrix、pmatrix、bmatrix、Bmatrix、vmatrix、Vmatrix、cases）",
+	"tex.missing_end":             "公式第 %[2]d 个字符处的环境 %[1]s 缺少 \\end",
+	"tex.mismatched_end":          "公式第 %[3]d 个字符处的 \\end{%[2]s} 与 \\begin{%[1]s} 不匹配",
+	"font.invalid_weight":         "无效的字重 %q（可用 thin、light、normal、medium、bold、black 或 100-900）",
+	"font.invalid_style":          "无效的字形样式 %q（可用 normal、italic）",
+	"font.invalid_size":           "无效的字号 %v",
+	"font.parse_failed":           "解析字体 %s 失败: %w",
+	"font.unknown_family":         "未知字体 %q（已注册: %s）",
+	"font.family_type":            "字体名称必须是字符串",
+	"font.text_only":              "只有文本对象支持 %s 属性",
+	"io.mkdir_failed":             "创建目录失败 '%s': %w",
+	"io.create_file_failed":       "创建输出文件失败 '%s': %w",
+	"io.png_encode_failed":        "PNG编码失败: %w",
+	"video.ffmpeg_failed":         "FFmpeg 生成视频 %s 失败: %w",
+	"clean.dir_parse":             "解析目录名失败: %v",
+	"clean.dir_type":              "目录名必须是字符串，得到的是: %T",
+	"clean.invalid_dir":           "非法目录路径: %s",
+	"clean.failed":                "清空目录 '%s' 失败: %v",
+
+	// 配置文件
+	"config.file_error":          "配置文件 %s: %w",
+	"config.unsupported_format":  "配置文件 %s 的格式不受支持（请使用 .json 或 .toml）",
+	"config.negative_resolution": "分辨率不能为负数，当前为 %dx%d",
+	"config.resolution_pair":     "width 和 height 必须同时设置",
+	"config.negative_fps":        "fps 不能为负数，当前为 %d",
+	"config.unknown_theme":       "未知的配色主题 %q",
+	"config.text_size_range":     "text_min_size (%v) 不能大于 text_max_size (%v)",
+	"config.invalid_color":       "背景色 %q 无效（应为 #RRGGBB）",
+	"config.unknown_color":       "未知的背景色 %q",
+	"toml.unknown_key":           "第 %d 行: 未知的键 %q",
+	"toml.not_integer":           "第 %d 行: %s 必须是整数",
+	"toml.not_number":            "第 %d 行: %s 必须是数字",
+	"toml.not_string":            "第 %d 行: %s 必须是字符串",
+	"toml.not_string_array":      "第 %d 行: %s 必须是字符串数组",
+	"toml.table":                 "第 %d 行: 不支持表，请把所有键写在顶层（只支持 TOML 的一个子集，其余写法请改用 JSON 配置）",
+	"toml.expected_key":          "第 %d 行: 缺少键名",
+	"toml.expected_assign":       "第 %d 行: %q 后面缺少 '='",
+	"toml.duplicate_key":         "第 %d 行: 重复的键 %q",
+	"toml.after_value":           "第 %d 行: 值后面出现了多余的 %q",
+	"toml.missing_value":         "第 %d 行: 缺少值",
+	"toml.unsupported_value":     "第 %d 行: 不支持以 %q 开头的值（只支持字符串、数字和字符串数组，其余写法请改用 JSON 配置）",
+	"toml.invalid_number":        "第 %d 行: 无效的数字 %q",
+	"toml.invalid_integer":       "第 %d 行: 无效的整数 %q",
+	"toml.unterminated_array":    "第 %d 行: 数组没有结束",
+	"toml.array_item":            "第 %d 行: 数组只能包含字符串",
+	"toml.unterminated_string":   "第 %d 行: 字符串没有结束",
+	"toml.unsupported_escape":    "第 %d 行: 不支持的转义 \\%c（只支持 \\n、\\t、\\\" 和 \\\\）",
+
+	// 状态日志
+	"log.runtime_error":          "执行错误",
+	"log.parse_script":           "解析脚本",
+	"log.execute_start":          "开始执行",
+	"log.execute_done":           "执行完成",
+	"log.fix_png_failed":         "修复PNG扩展名失败",
+	"log.rename_failed":          "重命名失败",
+	"log.png_fixed_by_copy":      "已通过复制修复PNG扩展名",
+	"log.png_fixed":              "已修复PNG扩展名",
+	"log.render_progress":        "渲染进度",
+	"log.sequence_start":         "开始渲染序列帧",
+	"log.sequence_done":          "序列帧渲染完成",
+	"log.video_try":              "正在尝试自动生成视频",
+	"log.ffmpeg_not_found":       "FFmpeg 未找到，跳过自动视频生成，请手动安装 FFmpeg 或使用在线工具转换",
+	"log.mp4_start":              "生成 MP4",
+	"log.mp4_failed":             "MP4 生成失败",
+	"log.mp4_done":               "MP4 生成成功",
+	"log.palette_failed":         "调色板生成失败",
+	"log.gif_start":              "生成 GIF",
+	"log.gif_failed":             "GIF 生成失败",
+	"log.gif_done":               "GIF 生成成功",
+	"log.video_done":             "视频生成完成",
+	"log.instructions_saved":     "说明文档已保存",
+	"log.dir_cleaned":            "已清空目录",
+	"log.video_from_frames":      "使用已存在的帧文件生成视频",
+	"log.animation_video_done":   "动画视频已生成",
+	"log.ffmpeg_commands":        "使用FFmpeg生成视频",
+	"log.script_executing":       "执行脚本",
+	"log.script_failed":          "脚本执行失败",
+	"log.script_done":            "脚本执行成功",
+	"log.file_not_found":         "文件不存在",
+	"log.default_script":         "找到默认脚本",
+	"log.output_missing":         "输出目录不存在，无需清理",
+	"log.output_read_failed":     "读取输出目录失败",
+	"log.output_empty":           "输出目录已经是空的",
+	"log.output_cleaning":        "正在清理输出目录",
+	"log.remove_failed":          "删除失败",
+	"log.removed":                "已删除",
+	"log.output_cleaned":         "输出目录清理完成",
+	"log.output_partial_cleaned": "输出目录部分清理完成",
+	"log.config_loaded":          "已加载配置文件",
+	"log.config_invalid":         "配置文件无效",
+	"log.font_load_failed":       "加载字体失败",
+	"log.font_missing_glyphs":    "没有能显示这些字符的字体，将绘制为占位方框",
+
+	// 命令行
+	"cli.version":        "Render2Go 脚本解释器 v1.0.0\n强大的动画脚本语言",
+	"cli.error":          "错误: %v",
+	"cli.no_script":      "未指定脚本文件，进入交互模式...\n使用 'render2go --help' 查看用法\n",
+	"cli.usage":          cliUsageZhCN,
+	"repl.banner":        "🎬 Render2Go 脚本解释器\n输入命令，输入 'exit' 退出\n可用命令: scene, create, set, animate, render, save, wait, loop\n",
+	"repl.goodbye":       "👋 再见！",
+	"repl.debug_on":      "🔍 调试模式已开启",
+	"repl.debug_off":     "🔍 调试模式已关闭",
+	"repl.cleared":       "🧹 解释器状态已清空",
+	"repl.error":         "❌ 错误: %v",
+	"repl.no_objects":    "📦 尚未创建任何对象",
+	"repl.objects":       "📦 已创建的对象:",
+	"repl.help":          replHelpZhCN,
+	"video.instructions": videoInstructionsZhCN,
+	"repl.line_source":   "第 %d 行",
+}
+
+// enMessages 英文消息表
+var enMessages = map[string]string{
+	// 错误位置与错误类型
+	"error.position":       "line %d, column %d",
+	"error.position_file":  "file %s, line %d, column %d",
+	"error.parse":          "syntax error (%s): %s",
+	"error.parse_list":     "parsing errors:\n%s",
+	"error.runtime":        "runtime error (%s): %s",
+	"error.unknown_object": "runtime error (%s): object '%s' does not exist",
+	"error.execution":      "execution error: %w",
+	"error.executions":     "execution errors:\n%s",
+	"error.open_file":      "failed to open file %s: %w",
+	"error.read_source":    "failed to read from %s: %w",
+
+	// 语法分析
+	"parse.expected_token":       "expected %s, got '%s'",
+	"parse.expected_object_type": "expected an object type (%s), got '%s'",
+	"parse.expected_property":    "expected a property name (%s), got '%s'",
+	"parse.expected_animation":   "expected an animation type (%s), got '%s'",
+	"parse.invalid_number":       "could not parse %q as a number",
+	"parse.illegal_character":    "illegal character '%s', please check the input",
+	"parse.unexpected_eof":       "unexpected end of script",
+	"parse.unknown_statement":    "unknown statement type: %s",
+	"parse.token.number":         "a number",
+	"parse.token.string":         "a string",
+	"parse.token.identifier":     "an identifier",
+
+	// 执行：通用
+	"eval.unknown_statement":   "unknown statement type: %T",
+	"eval.unknown_expression":  "unknown expression type: %T",
+	"eval.no_scene":            "no scene defined; create one with the 'scene' command first",
+	"eval.unknown_object_type": "unknown object type: %s",
+	"eval.create_failed":       "failed to create object '%s': %v",
+	"eval.object_not_found":    "object '%s' does not exist",
+	"eval.set_value_failed":    "failed to evaluate value for '%s.%s': %v",
+	"eval.unknown_property":    "unknown property: %s",
+	"eval.parse_x":             "failed to parse X coordinate: %v",
+	"eval.parse_y":             "failed to parse Y coordinate: %v",
+
+	// 执行：创建对象
+	"circle.radius_required":       "circle requires a radius",
+	"circle.radius_after_position": "a radius is required after the position",
+	"circle.radius_parse":          "failed to parse circle radius: %w",
+	"circle.radius_type":           "circle radius must be a number, got: %T",
+	"circle.radius_positive":       "circle radius must be greater than 0, got: %v",
+	"triangle.params_required":     "triangle requires at least one parameter",
+	"triangle.unknown_type":        "unknown triangle type: %s. Supported types: equilateral, right, isosceles",
+	"triangle.equilateral_size":    "equilateral triangle requires side length",
+	"triangle.right_size":          "right triangle requires width and height",
+	"triangle.isosceles_size":      "isosceles triangle requires size",
+	"vertex.invalid_x":             "invalid vertex %d X coordinate: %v",
+	"vertex.invalid_y":             "invalid vertex %d Y coordinate: %v",
+	"vertex.not_coordinate":        "vertex %d must be a coordinate (x,y)",
+	"axes.unknown_type":            "unknown coordinate system type: %s. Supported: standard, small, large, viewport, auto, polar",
+	"axes.invalid_param":           "invalid coordinate system parameter %s: %w",
+	"axes.param_count":             "coordinate system requires 0 (standard), 1 (type), or 5+ (custom) parameters, got %d",
+	"axes.polar_usage":             "usage: create axes <name> \"polar\" [rMax] [spacing]",
+	"axes.unknown_option":          "unknown coordinate system option %s. Supported: x_scale, y_scale, angles, divisions",
+	"axes.scale_unknown":           "%s must be linear or log, got %v",
+	"axes.log_range":               "log scale %s axis range must be positive, got [%g, %g]",
+	"axes.polar_log":               "polar grids do not support log scales",
+	"axes.angles_unknown":          "angles must be degrees or radians, got %v",
+	"axes.divisions_type":          "divisions must be a positive integer, got %v",
+	"axes.only":                    "property %s applies only to coordinate systems",
+	"axes.positive_type":           "%s must be a positive number, got %v",
+	"axes.tick_step_type":          "%s must be a non-negative number (0 means automatic), got %v",
+	"axes.rotation_type":           "%s must be an angle in degrees, got %v",
+	"axes.tick_format":             "tick_format must be a format string with one number verb (such as \"%%.2f\"), got %v",
+	"axes.switch_type":             "%s must be true or false, got %v",
+	"axes.label_type":              "%s must be a string, got %v",
+	"rect.size_required":           "rectangle requires width and height parameters",
+	"line.points_required":         "line requires start and end coordinate parameters",
+	"line.coordinates_required":    "line requires coordinate expressions",
+	"arrow.points_required":        "arrow requires start and end coordinate parameters",
+	"arrow.coordinates_required":   "arrow requires coordinate expressions",
+	"polygon.points_required":      "polygon requires points array parameter",
+	"polygon.array_required":       "polygon requires array of coordinates",
+	"polygon.coordinates_required": "polygon array must contain coordinate expressions",
+	"text.params_required":         "text requires at least 2 parameters: content and font size",
+	"text.content_parse":           "failed to evaluate text content: %w",
+	"text.content_type":            "text content must be a string",
+	"text.size_parse":              "failed to evaluate font size: %w",
+	"text.unknown_size_name":       "unknown font size name: %s",
+	"text.size_type":               "font size must be a number or a font size name",
+	"text.size_positive":           "font size must be greater than 0",
+	"text.max_width_type":          "max_width must be a non-negative number",
+	"text.line_spacing_type":       "line_spacing must be a number greater than 0",
+	"text.invalid_align":           "invalid alignment %q (use left, center or right)",
+	"text.invalid_anchor":          "invalid anchor %q (use center, top_left, top, top_right, left, right, bottom_left, bottom or bottom_right)",
+	"text.spans_empty":             "rich text needs at least one span",
+	"text.span_type":               "span %d must be a string",
+	"text.no_spans":                "%s was not created from an array of spans, so span properties cannot be set",
+	"text.span_index":              "invalid span index %s (use an integer from 0 to %d)",
+	"text.underline_type":          "underline must be true/false, on/off or 1/0, got %s",
+	"text.span_property":           "spans do not support the %s property (use color, weight, underline or highlight)",
+	"outline.text_required":        "%s is not a text object; outline needs a text object name or text content and size",
+	"stroke_width.type":            "stroke_width must be a non-negative number",
+	"path.data_required":           "path requires an SVG path data string such as \"M0 0 L 100 0\"",
+	"path.must_start_with_move":    "path data must start with an M or m command",
+	"path.unexpected_char":         "unexpected %[1]q at character %[2]d of path data",
+	"path.expected_number":         "expected a number at character %[2]d of path data, got %[1]q",
+	"path.unexpected_end":          "path data ended early; a command is missing arguments",
+	"path.invalid_flag":            "arc flag at character %d of path data must be 0 or 1",
+	"svg.file_required":            "svg requires an SVG file path string such as \"assets/logo.svg\"",
+	"svg.height_type":              "svg height must be a positive number, got %v",
+	"svg.read_failed":              "failed to read SVG file %s: %w",
+	"svg.invalid_xml":              "SVG file is not valid XML: %w",
+	"svg.not_svg":                  "the root element of the file is not <svg>",
+	"svg.empty":                    "the SVG file contains no drawable shapes",
+	"svg.invalid_transform":        "cannot parse transform attribute %q",
+	"svg.invalid_path":             "invalid d attribute on path element: %w",
+	"svg.invalid_points":           "invalid points attribute on %s element: %w",
+	"image.file_required":          "image requires an image file path string such as \"assets/photo.png\"",
+	"image.size_type":              "image width and height must be positive numbers, at most two, got %v",
+	"image.read_failed":            "failed to read image file %s: %w",
+	"image.decode_failed":          "cannot decode image file %s (PNG and JPEG are supported): %w",
+	"image.invalid_filter":         "invalid filter %v, expected nearest or bilinear",
+	"image.crop_type":              "crop must be a pixel region [x, y, width, height] or none",
+	"image.crop_outside":           "crop region lies outside the image",
+	"image.image_only":             "only image objects support the %s property",
+	"graph.usage":                  "graph requires a coordinate system name and a function expression, e.g. create graph g axes \"sin(x) * x\" -5 5",
+	"graph.not_axes":               "%s is not a coordinate system",
+	"graph.range_type":             "the graph x range must be two numbers xMin xMax with xMin < xMax",
+	"graph.invalid_function":       "invalid function expression %q: %w",
+	"curve.parametric_usage":       "parametric requires two expressions x(t) and y(t), e.g. create parametric c \"cos(3*t)\" \"sin(2*t)\" 0 6.28",
+	"curve.polar_usage":            "polar requires an expression r(theta), e.g. create polar r \"1 + cos(theta)\" 0 6.28",
+	"curve.range_type":             "the parameter range must be two different numbers (start end), optionally followed by a resolution",
+	"curve.resolution_type":        "resolution must be an integer of at least 2, got %v",
+	"curve.curve_only":             "only parametric curves, polar curves and contours support the %s property",
+	"implicit.usage":               "implicit requires a coordinate system name and an f(x, y) expression, optionally followed by a level or an array of levels, e.g. create implicit c axes \"x^2 + y^2 = 4\"",
+	"implicit.levels_type":         "levels must be a number or an array of numbers such as [-1, 0, 1]",
+	"colormap.unknown":             "unknown colormap %v, expected one of: %s",
+	"colormap.unsupported":         "this object does not support the %s property",
+	"field.usage":                  "vector_field and streamlines require a coordinate system name and a vector expression, optionally followed by a spacing, e.g. create vector_field vf axes \"(-y, x)\" 1",
+	"field.components":             "vector expression %q must have two components written as (P, Q)",
+	"field.spacing_type":           "spacing must be a positive number",
+	"field.flow_speed_type":        "flow_speed must be a non-negative number",
+	"field.flow_only":              "only vector fields and streamlines support the %s property",
+	"area.usage":                   "area requires a coordinate system name and one or two curves, optionally followed by an x range, e.g. create area a axes \"x^2\" 0 2",
+	"area.curve_type":              "a curve must be an expression string in x or the name of a graph",
+	"area.not_graph":               "%s is not a graph",
+	"riemann.usage":                "riemann requires a coordinate system name and a curve, optionally followed by an x range and n=, method= options, e.g. create riemann r axes \"x^2\" 0 2 n=8 method=mid",
+	"riemann.n_type":               "the rectangle count n must be an integer between 1 and 10000, got %v",
+	"riemann.method_unknown":       "unknown method %v, expected one of: %s",
+	"riemann.unknown_option":       "riemann does not support the option %s, available options: n, method",
+	"riemann.only":                 "only Riemann sums support the %s property",
+	"tracker.usage":                "%s requires a coordinate system name, a curve and x, e.g. create tangent t axes \"x^2\" 1",
+	"secant.usage":                 "%s requires a coordinate system name, a curve, x and an optional dx, e.g. create secant s axes \"x^2\" 1 0.5",
+	"tracker.number_type":          "%s must be a number, got %v",
+	"tracker.length_type":          "length must be a non-negative number, got %v",
+	"tracker.label_type":           "label must be a string, got %v",
+	"tracker.unknown_option":       "%s does not support the option %s, available options: %s",
+	"tracker.only":                 "only tangents, secants and graph points support the %s property",
+	"expr.empty":                   "empty expression",
+	"expr.unexpected_char":         "unexpected %[1]q at character %[2]d of expression",
+	"expr.unexpected_token":        "unexpected %[1]q at character %[2]d of expression",
+	"expr.unexpected_end":          "unexpected end of expression",
+	"expr.invalid_number":          "invalid number %[1]q at character %[2]d of expression",
+	"expr.missing_paren":           "the opening parenthesis at character %d of expression is never closed",
+	"expr.call_paren":              "function %s needs parenthesized arguments such as %[1]s(x) (character %[2]d)",
+	"expr.unknown_function":        "unknown function %[1]s at character %[2]d of expression",
+	"expr.arg_count":               "function %s takes %d argument(s), got %d",
+	"expr.unknown_variable":        "unknown variable %s, available variables: %s",
+	"expr.not_differentiable":      "cannot differentiate function %s symbolically",
+	"call.derivative_usage":        "derivative requires an expression string and an optional variable name, e.g. derivative(\"x^3 - 2*x\") or derivative(\"t^2\", \"t\")",
+	"call.simplify_usage":          "simplify requires one expression string, e.g. simplify(\"x + x\")",
+	"call.unknown_function":        "unknown function %s, available functions: %s",
+	"call.axes_usage":              "usage: %s(x, y) with numeric x and y",
+
+	// 执行：设置属性
+	"color.unknown_name":      "unknown color name: %s",
+	"color.type":              "color must be a string (such as '#FF0000' or a color name), got %T",
+	"color.unsupported":       "object does not support color property",
+	"position.type":           "position must be a coordinate (x, y), got %T",
+	"position.unsupported":    "object does not support position property",
+	"position.self_axes":      "a coordinate system cannot be positioned in its own coordinates",
+	"opacity.type":            "opacity must be a number",
+	"opacity.unsupported":     "object does not support opacity property",
+	"size.type":               "size must be a number",
+	"size.unsupported":        "object does not support size property",
+	"width.not_implemented":   "width property not yet implemented",
+	"height.not_implemented":  "height property not yet implemented",
+	"vertex.triangle_only":    "vertex properties are only supported for triangle objects",
+	"vertex.unknown_property": "unknown vertex property: %s. Use vertex1, vertex2, or vertex3",
+	"vertex.type":             "vertex must be a coordinate (x, y), got %T",
+	"vertex.value_invalid_x":  "invalid vertex X coordinate: %v",
+	"vertex.value_invalid_y":  "invalid vertex Y coordinate: %v",
+	"vertices.triangle_only":  "vertices property is only supported for triangle objects",
+	"vertices.type":           "vertices must be an array of coordinates [(x1,y1), (x2,y2), (x3,y3)], got %T",
+	"vertices.count":          "triangle vertices array must contain exactly 3 coordinates, got %d",
+	"vertices.element_type":   "vertex %d must be a coordinate (x,y), got %T",
+
+	// 执行：动画
+	"anim.not_animatable":        "object '%s' is not animatable",
+	"anim.move_target":           "move animation requires target position",
+	"anim.move_type":             "move parameter must be coordinate",
+	"anim.scale_factor":          "scale animation requires scale factor",
+	"anim.rotate_angle":          "rotate animation requires angle",
+	"anim.color_target":          "color animation requires target color",
+	"anim.color_type":            "color parameter must be a string",
+	"anim.path_points":           "path animation requires path points array",
+	"anim.path_type":             "path parameter must be an array of coordinates",
+	"anim.path_point_type":       "path points must be coordinates",
+	"anim.path_target":           "%s is not a path or curve object and cannot be used as a motion path",
+	"anim.flow_target":           "%s is not a vector field or streamlines and cannot flow",
+	"anim.value_params":          "value animation requires a property name string and a target number, e.g. animate value r \"n\" 64 2",
+	"anim.value_property":        "property %[2]s of %[1]s cannot be animated as a value",
+	"anim.elastic_params":        "elastic animation requires property and target value",
+	"anim.elastic_property_type": "elastic property must be a string",
+	"anim.unsupported":           "unsupported animation type: %s",
+
+	// 执行：渲染与文件
+	"render.frame_failed":         "failed to render frame %d: %w",
+	"render.no_active_scene":      "no active scene",
+	"render.unsupported_renderer": "unsupported renderer type",
+	"render.no_font":              "no suitable font found",
+	"tex.unexpected_end":          "unexpected end of formula",
+	"tex.unexpected_token":        "unexpected %q at character %d of formula",
+	"tex.unclosed_group":          "unclosed group opened at character %d of formula",
+	"tex.unclosed_math":           "$ at character %d has no closing $",
+	"tex.unknown_command":         "unsupported command \\%s at character %d of formula",
+	"tex.expected_brace":          "expected { at character %d of formula",
+	"tex.double_script":           "duplicate %s at character %d of formula",
+	"tex.invalid_delimiter":       "%q at character %d of formula is not a valid delimiter",
+	"tex.missing_right":           "\\left at character %d of formula has no matching \\right",
+	"tex.unknown_environment":     "unsupported environment %s at character %d of formula (supported: matrix, pmatrix, bmatrix, Bmatrix, vmatrix, Vmatrix, cases)",
+	"tex.missing_end":             "environment %s at character %d of formula has no \\end",
+	"tex.mismatched_end":          "\\end{%[2]s} at character %[3]d does not match \\begin{%[1]s}",
+	"font.invalid_weight":         "invalid font weight %q (use thin, light, normal, medium, bold, black or 100-900)",
+	"font.invalid_style":          "invalid font style %q (use normal or italic)",
+	"font.invalid_size":           "invalid font size %v",
+	"font.parse_failed":           "failed to parse font %s: %w",
+	"font.unknown_family":         "unknown font %q (registered: %s)",
+	"font.family_type":            "font name must be a string",
+	"font.text_only":              "only text objects support the %s property",
+	"io.mkdir_failed":             "failed to create directory '%s': %w",
+	"io.create_file_failed":       "failed to create output file '%s': %w",
+	"io.png_encode_failed":        "failed to encode PNG: %w",
+	"video.ffmpeg_failed":         "FFmpeg failed to encode %s: %w",
+	"clean.dir_parse":             "failed to evaluate directory name: %v",
+	"clean.dir_type":              "directory name must be a string, got: %T",
+	"clean.invalid_dir":           "invalid directory path: %s",
+	"clean.failed":                "failed to clean directory '%s': %v",
+
+	// 配置文件
+	"config.file_error":          "config file %s: %w",
+	"config.unsupported_format":  "config file %s: unsupported format (use .json or .toml)",
+	"config.negative_resolution": "resolution must not be negative, got %dx%d",
+	"config.resolution_pair":     "width and height must be set together",
+	"config.negative_fps":        "fps must not be negative, got %d",
+	"config.unknown_theme":       "unknown theme %q",
+	"config.text_size_range":     "text_min_size (%v) must not exceed text_max_size (%v)",
+	"config.invalid_color":       "invalid background color %q (expected #RRGGBB)",
+	"config.unknown_color":       "unknown background color %q",
+	"toml.unknown_key":           "line %d: unknown key %q",
+	"toml.not_integer":           "line %d: %s must be an integer",
+	"toml.not_number":            "line %d: %s must be a number",
+	"toml.not_string":            "line %d: %s must be a string",
+	"toml.not_string_array":      "line %d: %s must be an array of strings",
+	"toml.table":                 "line %d: tables are not supported, put all keys at the top level (only a subset of TOML is supported; use a JSON config for anything else)",
+	"toml.expected_key":          "line %d: expected a key",
+	"toml.expected_assign":       "line %d: expected '=' after %q",
+	"toml.duplicate_key":         "line %d: duplicate key %q",
+	"toml.after_value":           "line %d: unexpected %q after value",
+	"toml.missing_value":         "line %d: missing value",
+	"toml.unsupported_value":     "line %d: unsupported value starting with %q (only strings, numbers and string arrays are supported; use a JSON config for anything else)",
+	"toml.invalid_number":        "line %d: invalid number %q",
+	"toml.invalid_integer":       "line %d: invalid integer %q",
+	"toml.unterminated_array":    "line %d: unterminated array",
+	"toml.array_item":            "line %d: arrays may only contain strings",
+	"toml.unterminated_string":   "line %d: unterminated string",
+	"toml.unsupported_escape":    "line %d: unsupported escape \\%c (only \\n, \\t, \\\" and \\\\ are supported)",
+
+	// 状态日志
+	"log.runtime_error":          "Runtime error",
+	"log.parse_script":           "Parsing script",
+	"log.execute_start":          "Executing",
+	"log.execute_done":           "Execution finished",
+	"log.fix_png_failed":         "Failed to fix PNG extensions",
+	"log.rename_failed":          "Rename failed",
+	"log.png_fixed_by_copy":      "Fixed PNG extension by copying",
+	"log.png_fixed":              "Fixed PNG extension",
+	"log.render_progress":        "Render progress",
+	"log.sequence_start":         "Rendering frame sequence",
+	"log.sequence_done":          "Frame sequence rendered",
+	"log.video_try":              "Trying to generate video automatically",
+	"log.ffmpeg_not_found":       "FFmpeg not found, skipping video generation; install FFmpeg or convert the frames with another tool",
+	"log.mp4_start":              "Generating MP4",
+	"log.mp4_failed":             "MP4 generation failed",
+	"log.mp4_done":               "MP4 generated",
+	"log.palette_failed":         "Palette generation failed",
+	"log.gif_start":              "Generating GIF",
+	"log.gif_failed":             "GIF generation failed",
+	"log.gif_done":               "GIF generated",
+	"log.video_done":             "Video generation finished",
+	"log.instructions_saved":     "Instructions saved",
+	"log.dir_cleaned":            "Directory cleaned",
+	"log.video_from_frames":      "Generating video from existing frames",
+	"log.animation_video_done":   "Animation video generated",
+	"log.ffmpeg_commands":        "Generate video with FFmpeg",
+	"log.script_executing":       "Executing script",
+	"log.script_failed":          "Script execution failed",
+	"log.script_done":            "Script execution completed successfully",
+	"log.file_not_found":         "File does not exist",
+	"log.default_script":         "Found default script",
+	"log.output_missing":         "Output directory does not exist, nothing to clean",
+	"log.output_read_failed":     "Failed to read output directory",
+	"log.output_empty":           "Output directory is already empty",
+	"log.output_cleaning":        "Cleaning output directory",
+	"log.remove_failed":          "Failed to remove",
+	"log.removed":                "Removed",
+	"log.output_cleaned":         "Successfully cleaned output directory",
+	"log.output_partial_cleaned": "Partially cleaned output directory",
+	"log.config_loaded":          "Loaded config file",
+	"log.config_invalid":         "Invalid config file",
+	"log.font_load_failed":       "Failed to load font",
+	"log.font_missing_glyphs":    "No font can display these characters; they will be drawn as placeholder boxes",
+
+	// 命令行
+	"cli.version":        "Render2Go Script Interpreter v1.0.0\nA powerful animation scripting language",
+	"cli.error":          "Error: %v",
+	"cli.no_script":      "No script file specified. Starting interactive mode...\nUse 'render2go --help' for usage information\n",
+	"cli.usage":          cliUsageEn,
+	"repl.banner":        "🎬 Render2Go Script Interpreter\nType your commands or 'exit' to quit\nCommands: scene, create, set, animate, render, save, wait, loop\n",
+	"repl.goodbye":       "👋 Goodbye!",
+	"repl.debug_on":      "🔍 Debug mode enabled",
+	"repl.debug_off":     "🔍 Debug mode disabled",
+	"repl.cleared":       "🧹 Interpreter state cleared",
+	"repl.error":         "❌ Error: %v",
+	"repl.no_objects":    "📦 No objects created yet",
+	"repl.objects":       "📦 Created Objects:",
+	"repl.help":          replHelpEn,
+	"video.instructions": videoInstructionsEn,
+	"repl.line_source":   "line %d",
+}
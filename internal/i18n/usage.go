@@ -0,0 +1,363 @@
+package i18n
+
+// 命令行和交互模式的长篇帮助文本，以及导出视频失败时写出的操作说明
+
+const cliUsageEn = `🎬 Render2Go Script Interpreter
+
+USAGE:
+    render2go [OPTIONS] [FILE]
+
+OPTIONS:
+    -file <file>        Execute the specified script file
+    -i                  Run in interactive mode
+    -debug              Enable debug mode (shows tokens and AST)
+    -clean              Clean output directory (remove all generated files)
+    -quiet              Only log warnings and errors
+    -verbose            Log debug details, including per-frame progress
+    -log-format <fmt>   Log format: text (default) or json
+    -lang <lang>        Message language: zh-CN or en (default: from LANG)
+    -config <file>      Config file (default: render2go.json or render2go.toml
+                        found by walking up from the script directory)
+    -help               Show this help message
+    -version            Show version information
+
+FILE FORMATS:
+    .r2g                Render2Go Animation script files
+
+EXAMPLES:
+    render2go script.r2g              # Execute script.r2g
+    render2go -file animation.r2g     # Execute animation.r2g
+    render2go -i                      # Start interactive mode
+    render2go -debug script.r2g       # Execute with debug output
+    render2go -clean                  # Clean output directory
+    render2go -log-format=json -quiet script.r2g   # CI-friendly logs on stderr
+    render2go -lang=en script.r2g     # English messages
+
+SCRIPT LANGUAGE:
+    The Render2Go scripting language supports:
+
+    Scene Management:
+        scene 800 600 "my_project"
+
+    Object Creation:
+        create circle c1 50 (400, 300)
+        create rectangle r1 100 80 (200, 200)
+        create line l1 (0, 0) (100, 100)
+        create text t1 "Hello World" 24 (400, 100)
+
+    Property Setting:
+        set c1.color = #576DA2
+        set c1.position = (500, 400)
+        set c1.opacity = 0.8
+
+    Rendering:
+        render
+        save "my_frame.png"
+
+    Control Flow:
+        loop 10 {
+            render
+            save "frame.png"
+        }
+
+DEFAULT BEHAVIOR:
+    If no file is specified, render2go will look for these files in order:
+    - main.r2g
+    - script.r2g
+    - animation.r2g
+
+    If none are found, interactive mode will start.
+
+For more information, visit: https://github.com/render2go/render2go`
+
+const cliUsageZhCN = `🎬 Render2Go 脚本解释器
+
+用法:
+    render2go [选项] [文件]
+
+选项:
+    -file <文件>        执行指定的脚本文件
+    -i                  进入交互模式
+    -debug              调试模式（显示词法标记和语法树）
+    -clean              清理输出目录（删除所有生成的文件）
+    -quiet              只输出警告和错误日志
+    -verbose            输出调试日志，包括逐帧进度
+    -log-format <格式>  日志格式: text（默认）或 json
+    -lang <语言>        界面语言: zh-CN 或 en（默认读取 LANG）
+    -config <文件>      配置文件（默认从脚本所在目录逐级向上查找
+                        render2go.json 或 render2go.toml）
+    -help               显示本帮助
+    -version            显示版本信息
+
+文件格式:
+    .r2g                Render2Go 动画脚本文件
+
+示例:
+    render2go script.r2g              # 执行 script.r2g
+    render2go -file animation.r2g     # 执行 animation.r2g
+    render2go -i                      # 进入交互模式
+    render2go -debug script.r2g       # 带调试输出执行
+    render2go -clean                  # 清理输出目录
+    render2go -log-format=json -quiet script.r2g   # 适合 CI 的 stderr 日志
+    render2go -lang=en script.r2g     # 使用英文消息
+
+脚本语言:
+    Render2Go 脚本语言支持:
+
+    场景管理:
+        scene 800 600 "my_project"
+
+    创建对象:
+        create circle c1 50 (400, 300)
+        create rectangle r1 100 80 (200, 200)
+        create line l1 (0, 0) (100, 100)
+        create text t1 "Hello World" 24 (400, 100)
+
+    设置属性:
+        set c1.color = #576DA2
+        set c1.position = (500, 400)
+        set c1.opacity = 0.8
+
+    渲染:
+        render
+        save "my_frame.png"
+
+    流程控制:
+        loop 10 {
+            render
+            save "frame.png"
+        }
+
+默认行为:
+    未指定文件时，render2go 按顺序查找以下文件:
+    - main.r2g
+    - script.r2g
+    - animation.r2g
+
+    都不存在时进入交互模式。
+
+更多信息请访问: https://github.com/render2go/render2go`
+
+const replHelpEn = `
+📚 Render2Go Script Language Help
+
+Scene Management:
+  scene <width> <height> "name"     - Create a new scene
+
+Object Creation:
+  create circle <name> <radius> [(<x>, <y>)]
+  create rectangle <name> <width> <height> [(<x>, <y>)]
+  create line <name> (<x1>, <y1>) (<x2>, <y2>)
+  create arrow <name> (<x1>, <y1>) (<x2>, <y2>)
+  create text <name> "text" <size> [(<x>, <y>)]
+  create mathtex <name> "\frac{a}{b}" <size> [(<x>, <y>)]
+  create markdown <name> "# Title\n- **item**" <size> [(<x>, <y>)]
+  create outline <name> <text>     - Convert a text object to glyph outline paths
+  create path <name> "<d>" (x, y)  - Create a Bezier path from SVG path data
+  create svg <name> "<file>" [h]   - Import shapes from an SVG file
+  create image <name> "<file>" [h] - Place a PNG or JPEG image
+  create axes <name> <xMin> <xMax> <yMin> <yMax> <spacing> [x_scale=log] [y_scale=log] - Coordinate system
+  create axes <name> "polar" [rMax] [spacing] [angles=degrees|radians] - Polar grid
+  create graph <name> <axes> "<f(x)>" [xMin xMax] - Plot a function on a coordinate system
+  create parametric <name> "<x(t)>" "<y(t)>" [tMin tMax] - Parametric curve
+  create polar <name> "<r(theta)>" [thetaMin thetaMax]  - Polar curve
+  create implicit <name> <axes> "<f(x, y)>" [levels]     - Implicit curve / contour lines
+  create vector_field <name> <axes> "(<P>, <Q>)" [spacing] - Vector field arrows
+  create streamlines <name> <axes> "(<P>, <Q>)" [spacing]  - Streamlines of a vector field
+  create area <name> <axes> <curve> [<curve2>] [xMin xMax]  - Area under a curve or between curves
+  create riemann <name> <axes> <curve> [xMin xMax] [n=8] [method=left|right|mid|trap] - Riemann sum
+  create tangent|secant|graph_point <name> <axes> <curve> <x> [dx] [length=] [label=] - Tracker bound to a curve at x
+  create polygon <name> [(<x1>, <y1>), (<x2>, <y2>), ...]
+
+Property Setting:
+  set <object>.color = #RRGGBB | deepblue | midblue | purpleblue | cyanblue | darkcolor | lightpurple
+  set <object>.position = (<x>, <y>)
+  set <object>.position = <axes>(<x>, <y>) - Point (x, y) of the axes, follows them when they move or scale
+  set <object>.opacity = <value>
+  set <object>.size = <value>
+  set <object>.stroke_width = <value>
+  set <text>.font = "Go Mono"      - Font family (font, weight, style)
+  set <text>.max_width = 300       - Wrap width (align, line_spacing, anchor)
+  set <text>.span[1].color = red   - Style one span of ["a", "b"] text (weight, underline, highlight)
+  set <axes>.x_tick_step = 2       - Axis ticks (spacing, tick_format, tick_pi, hide_zero, tick_rotation, tick_size; x_/y_ prefix for one axis)
+  set <axes>.x_label = "t"         - Axis titles (x_label, y_label)
+
+Expressions:
+  derivative("<f(x)>" [, "<var>"]) - Symbolic derivative, usable wherever a string is expected
+  simplify("<expression>")          - Simplified expression
+
+Rendering:
+  render                           - Render current frame
+  save "filename"                  - Save current frame
+
+Control Flow:
+  wait <seconds>                   - Wait for specified time
+  loop <count> { ... }            - Repeat commands
+
+Interactive Commands:
+  help                            - Show this help
+  debug on/off                    - Toggle debug mode
+  clear                           - Clear interpreter state
+  objects                         - List created objects
+  exit/quit                       - Exit interpreter
+
+Color Names:
+  deepblue, midblue, purpleblue, cyanblue, darkcolor, lightpurple
+
+Examples:
+  scene 800 600 "my_animation"
+  create circle c1 50 (400, 300)
+  set c1.color = #576DA2
+  set c1.opacity = 0.8
+  render
+  save "frame.png"`
+
+const replHelpZhCN = `
+📚 Render2Go 脚本语言帮助
+
+场景管理:
+  scene <宽> <高> "名称"            - 创建新场景
+
+创建对象:
+  create circle <名称> <半径> [(<x>, <y>)]
+  create rectangle <名称> <宽> <高> [(<x>, <y>)]
+  create line <名称> (<x1>, <y1>) (<x2>, <y2>)
+  create arrow <名称> (<x1>, <y1>) (<x2>, <y2>)
+  create text <名称> "文本" <字号> [(<x>, <y>)]
+  create mathtex <名称> "\frac{a}{b}" <字号> [(<x>, <y>)]
+  create markdown <名称> "# 标题\n- **要点**" <字号> [(<x>, <y>)]
+  create outline <名称> <文本>     - 把文本对象转换为字形轮廓路径
+  create path <名称> "<d>" (x, y)  - 由 SVG 路径数据创建贝塞尔路径
+  create svg <名称> "<文件>" [高度] - 导入 SVG 文件中的图形
+  create image <名称> "<文件>" [高度] - 放置 PNG 或 JPEG 图像
+  create axes <名称> <xMin> <xMax> <yMin> <yMax> <间距> [x_scale=log] [y_scale=log] - 坐标系
+  create axes <名称> "polar" [最大半径] [间距] [angles=degrees|radians] - 极坐标网格
+  create graph <名称> <坐标系> "<f(x)>" [xMin xMax] - 在坐标系中绘制函数图像
+  create parametric <名称> "<x(t)>" "<y(t)>" [tMin tMax] - 参数曲线
+  create polar <名称> "<r(theta)>" [thetaMin thetaMax]  - 极坐标曲线
+  create implicit <名称> <坐标系> "<f(x, y)>" [等值]      - 隐函数曲线与等高线
+  create vector_field <名称> <坐标系> "(<P>, <Q>)" [间距]  - 向量场箭头
+  create streamlines <名称> <坐标系> "(<P>, <Q>)" [间距]   - 向量场的流线
+  create area <名称> <坐标系> <曲线> [<曲线2>] [xMin xMax]  - 曲线下或两条曲线之间的面积
+  create riemann <名称> <坐标系> <曲线> [xMin xMax] [n=8] [method=left|right|mid|trap] - 黎曼和
+  create tangent|secant|graph_point <名称> <坐标系> <曲线> <x> [dx] [length=] [label=] - 绑定在曲线 x 处的切线、割线或点
+  create polygon <名称> [(<x1>, <y1>), (<x2>, <y2>), ...]
+
+设置属性:
+  set <对象>.color = #RRGGBB | deepblue | midblue | purpleblue | cyanblue | darkcolor | lightpurple
+  set <对象>.position = (<x>, <y>)
+  set <对象>.position = <坐标系>(<x>, <y>) - 坐标系中的点 (x, y)，坐标系移动或缩放时跟随
+  set <对象>.opacity = <值>
+  set <对象>.size = <值>
+  set <对象>.stroke_width = <值>
+  set <文本>.font = "Go Mono"      - 字体族（另有 weight、style）
+  set <文本>.max_width = 300       - 折行宽度（另有 align、line_spacing、anchor）
+  set <文本>.span[1].color = red   - 设置 ["a", "b"] 富文本的单个片段（另有 weight、underline、highlight）
+  set <坐标系>.x_tick_step = 2     - 刻度设置（另有 spacing、tick_format、tick_pi、hide_zero、tick_rotation、tick_size；x_/y_ 前缀只作用于一条轴）
+  set <坐标系>.x_label = "t"       - 坐标轴标题（x_label、y_label）
+
+表达式:
+  derivative("<f(x)>" [, "<变量>"]) - 符号求导，结果可用于任何需要字符串的地方
+  simplify("<表达式>")              - 化简表达式
+
+渲染:
+  render                           - 渲染当前帧
+  save "文件名"                    - 保存当前帧
+
+流程控制:
+  wait <秒>                        - 等待指定时间
+  loop <次数> { ... }              - 重复执行命令
+
+交互命令:
+  help                            - 显示本帮助
+  debug on/off                    - 开关调试模式
+  clear                           - 清空解释器状态
+  objects                         - 列出已创建的对象
+  exit/quit                       - 退出
+
+颜色名:
+  deepblue, midblue, purpleblue, cyanblue, darkcolor, lightpurple
+
+示例:
+  scene 800 600 "my_animation"
+  create circle c1 50 (400, 300)
+  set c1.color = #576DA2
+  set c1.opacity = 0.8
+  render
+  save "frame.png"`
+
+// videoInstructionsEn 参数依次为帧率和总时长（秒）
+const videoInstructionsEn = `# Video Generation Guide
+
+## Automatic generation (requires FFmpeg)
+
+### Install FFmpeg
+- Windows: download https://ffmpeg.org/download.html and add it to PATH
+- macOS: brew install ffmpeg
+- Linux: sudo apt install ffmpeg
+
+### Commands
+
+#### MP4 video:
+` + "```bash" + `
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -c:v libx264 -preset medium -crf 23 -pix_fmt yuv420p animation.mp4
+` + "```" + `
+
+#### GIF animation:
+` + "```bash" + `
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -vf "fps=30,scale=800:450:flags=lanczos,palettegen" palette.png
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -i palette.png -filter_complex "fps=30,scale=800:450:flags=lanczos[x];[x][1:v]paletteuse" animation.gif
+` + "```" + `
+
+## Online conversion
+
+If FFmpeg cannot be installed, use an online tool:
+1. Pack all PNG files into a ZIP archive
+2. Upload it to ezgif.com or a similar site
+3. Set the frame rate to %[1]d fps
+4. Download the generated video
+
+## Files
+
+- frame_000000.png ~ frame_NNNNNN.png: frame images
+- Suggested frame rate: %[1]d fps
+- Total duration: %.1[2]f seconds
+`
+
+// videoInstructionsZhCN 参数依次为帧率和总时长（秒）
+const videoInstructionsZhCN = `# 视频生成说明
+
+## 自动生成 (需要 FFmpeg)
+
+### 安装 FFmpeg
+- Windows: 下载 https://ffmpeg.org/download.html 并添加到 PATH
+- macOS: brew install ffmpeg
+- Linux: sudo apt install ffmpeg
+
+### 生成命令
+
+#### MP4 视频:
+` + "```bash" + `
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -c:v libx264 -preset medium -crf 23 -pix_fmt yuv420p animation.mp4
+` + "```" + `
+
+#### GIF 动画:
+` + "```bash" + `
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -vf "fps=30,scale=800:450:flags=lanczos,palettegen" palette.png
+ffmpeg -framerate %[1]d -i "frame_%%06d.png" -i palette.png -filter_complex "fps=30,scale=800:450:flags=lanczos[x];[x][1:v]paletteuse" animation.gif
+` + "```" + `
+
+## 在线转换
+
+如果无法安装 FFmpeg，可使用在线工具:
+1. 将所有 PNG 文件打包为 ZIP
+2. 上传到 ezgif.com 或类似网站
+3. 设置帧率为 %[1]d fps
+4. 下载生成的视频
+
+## 文件说明
+
+- frame_000000.png ~ frame_NNNNNN.png: 序列帧图像
+- 建议帧率: %[1]d fps
+- 总时长: %.1[2]f 秒
+`
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// 日志输出格式
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options 日志配置
+type Options struct {
+	Quiet   bool   // 只输出警告和错误
+	Verbose bool   // 输出调试信息（包括逐帧进度）
+	Format  string // text 或 json
+}
+
+// Level 根据配置计算日志级别，Quiet 优先于 Verbose
+func (o Options) Level() slog.Level {
+	switch {
+	case o.Quiet:
+		return slog.LevelWarn
+	case o.Verbose:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// New 创建写入 w 的日志器
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level()}
+
+	switch strings.ToLower(opts.Format) {
+	case "", FormatText:
+		// 文本模式面向终端阅读，省略时间戳
+		handlerOpts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		}
+		return slog.New(slog.NewTextHandler(w, handlerOpts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, handlerOpts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (supported: text, json)", opts.Format)
+	}
+}
+
+// Discard 创建丢弃所有输出的日志器
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
@@ -0,0 +1,128 @@
+package interpreter
+
+import (
+	"render2go/internal/i18n"
+	"strings"
+)
+
+// ErrorCode 错误代码，供调用方区分错误类别
+type ErrorCode string
+
+// 解析错误代码
+const (
+	CodeUnexpectedToken    ErrorCode = "R2G1001" // 出现了非预期的标记
+	CodeInvalidNumber      ErrorCode = "R2G1002" // 数字字面量无法解析
+	CodeUnknownStatement   ErrorCode = "R2G1003" // 未知语句类型
+	CodeIllegalCharacter   ErrorCode = "R2G1004" // 非法字符
+	CodeUnexpectedEOF      ErrorCode = "R2G1005" // 脚本意外结束
+	CodeExpectedObjectType ErrorCode = "R2G1006" // 需要对象类型
+	CodeExpectedProperty   ErrorCode = "R2G1007" // 需要属性名
+	CodeExpectedAnimation  ErrorCode = "R2G1008" // 需要动画类型
+)
+
+// 运行错误代码
+const (
+	CodeRuntime             ErrorCode = "R2G2001" // 通用运行错误
+	CodeNoScene             ErrorCode = "R2G2002" // 尚未创建场景
+	CodeInvalidArgument     ErrorCode = "R2G2003" // 参数类型或取值不合法
+	CodeUnsupportedProperty ErrorCode = "R2G2004" // 对象不支持该属性
+	CodeUnknownColor        ErrorCode = "R2G2005" // 未知颜色名
+	CodeUnknownObjectType   ErrorCode = "R2G2006" // 未知对象类型
+	CodeIO                  ErrorCode = "R2G2007" // 文件读写失败
+	CodeUnknownObject       ErrorCode = "R2G2101" // 引用了不存在的对象
+)
+
+// Position 源码位置，行列均从 1 开始，未知时为 0
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String 返回位置描述
+func (p Position) String() string {
+	return p.in("")
+}
+
+// in 返回指定语言的位置描述，l 为空时使用当前语言
+func (p Position) in(l i18n.Locale) string {
+	if p.File != "" {
+		return i18n.Tl(l, "error.position_file", p.File, p.Line, p.Column)
+	}
+	return i18n.Tl(l, "error.position", p.Line, p.Column)
+}
+
+// positionOf 根据标记生成位置
+func positionOf(tok Token) Position {
+	return Position{Line: tok.Line, Column: tok.Column}
+}
+
+// ParseError 语法分析错误
+type ParseError struct {
+	Position
+	Code    ErrorCode
+	Message string
+
+	locale i18n.Locale // 消息语言，为空时使用当前语言
+}
+
+func (e *ParseError) Error() string {
+	return i18n.Tl(e.locale, "error.parse", e.Position.in(e.locale), e.Message)
+}
+
+// ParseErrors 一次解析中收集到的全部语法错误，可通过 errors.As 取出第一个 *ParseError
+type ParseErrors []*ParseError
+
+func (errs ParseErrors) Error() string {
+	messages := make([]string, len(errs))
+	var locale i18n.Locale
+	for i, err := range errs {
+		messages[i] = err.Error()
+		locale = err.locale
+	}
+	return i18n.Tl(locale, "error.parse_list", strings.Join(messages, "\n"))
+}
+
+// Unwrap 返回全部错误，供 errors.Is / errors.As 遍历
+func (errs ParseErrors) Unwrap() []error {
+	result := make([]error, len(errs))
+	for i, err := range errs {
+		result[i] = err
+	}
+	return result
+}
+
+// RuntimeError 执行错误，Err 为底层原因（可能为 nil）
+type RuntimeError struct {
+	Position
+	Code    ErrorCode
+	Message string
+	Err     error
+
+	locale i18n.Locale // 消息语言，为空时使用当前语言
+}
+
+func (e *RuntimeError) Error() string {
+	msg := e.Message
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return i18n.Tl(e.locale, "error.runtime", e.Position.in(e.locale), msg)
+}
+
+func (e *RuntimeError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownObjectError 引用了不存在的对象
+type UnknownObjectError struct {
+	Position
+	Code ErrorCode
+	Name string
+
+	locale i18n.Locale // 消息语言，为空时使用当前语言
+}
+
+func (e *UnknownObjectError) Error() string {
+	return i18n.Tl(e.locale, "error.unknown_object", e.Position.in(e.locale), e.Name)
+}
@@ -0,0 +1,79 @@
+package interpreter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"render2go/internal/i18n"
+)
+
+func TestNestedErrors(t *testing.T) {
+	const prelude = "scene 64 48 \"errors\"\ncreate coordinate_system ax \"small\"\ncreate circle c 5 (0, 0)\n"
+	tests := []struct {
+		name    string
+		line    string
+		message string
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:    "missing axes",
+			line:    `create graph g missing "x^2"`,
+			message: "failed to create object 'g': object 'missing' does not exist",
+			check: func(t *testing.T, err error) {
+				var unknown *UnknownObjectError
+				if !errors.As(err, &unknown) || unknown.Name != "missing" || unknown.Code != CodeUnknownObject {
+					t.Errorf("errors.As(UnknownObjectError) = %+v, want object 'missing'", unknown)
+				}
+			},
+		},
+		{
+			name:    "object is not a coordinate system",
+			line:    `create graph g c "x^2"`,
+			message: "failed to create object 'g': c is not a coordinate system",
+			check: func(t *testing.T, err error) {
+				var outer *RuntimeError
+				if !errors.As(err, &outer) {
+					t.Fatalf("error %v is not a RuntimeError", err)
+				}
+				var inner *RuntimeError
+				if !errors.As(outer.Err, &inner) || inner.Code != CodeInvalidArgument {
+					t.Errorf("cause = %#v, want an invalid argument RuntimeError", outer.Err)
+				}
+			},
+		},
+		{
+			name:    "invalid function keeps the parse error",
+			line:    `create graph g ax "x^"`,
+			message: "failed to create object 'g': invalid function expression \"x^\"",
+			check: func(t *testing.T, err error) {
+				var outer *RuntimeError
+				if !errors.As(err, &outer) || outer.Err == nil {
+					t.Fatalf("error %v has no cause", err)
+				}
+				if errors.Unwrap(outer.Err) == nil {
+					t.Errorf("cause %v does not wrap the expression parse error", outer.Err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interp := NewInterpreter(false)
+			interp.SetLocale(i18n.En)
+			err := interp.RunString(prelude+tt.line+"\n", "errors.r2g")
+			if err == nil {
+				t.Fatal("RunString succeeded, want error")
+			}
+			msg := err.Error()
+			if !strings.HasPrefix(msg, "runtime error (file errors.r2g, line 4, column 1): "+tt.message) {
+				t.Errorf("error = %q, want the position once followed by %q", msg, tt.message)
+			}
+			if n := strings.Count(msg, "line 4"); n != 1 {
+				t.Errorf("error = %q repeats the position %d times", msg, n)
+			}
+			tt.check(t, err)
+		})
+	}
+}
@@ -1,17 +1,24 @@
 package interpreter
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"image"
 	"image/color"
 	"image/png"
+	"log/slog"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"render2go/animation"
 	"render2go/colors"
 	"render2go/core"
+	"render2go/fonts"
 	"render2go/geometry"
 	"render2go/internal/defaults"
+	"render2go/internal/i18n"
 	gmMath "render2go/math"
 	"render2go/renderer"
 	"render2go/scene"
@@ -21,27 +28,132 @@ import (
 
 // Evaluator 执行引擎
 type Evaluator struct {
-	scene       *scene.Scene
-	objects     map[string]interface{} // 存储创建的对象
-	animations  []animation.Animation  // 存储动画序列
-	errors      []string
-	projectName string // 项目名称
-	currentLine int    // 当前执行行号
-	fileName    string // 当前执行的文件名
+	scene         *scene.Scene
+	objects       map[string]interface{} // 存储创建的对象
+	animations    []animation.Animation  // 存储动画序列
+	errors        []string
+	projectName   string // 项目名称
+	currentLine   int    // 当前执行行号
+	currentColumn int    // 当前执行列号
+	fileName      string // 当前执行的文件名
+
+	// 嵌入调用时的运行选项
+	ctx            context.Context       // 取消上下文
+	logger         *slog.Logger          // 状态日志
+	progress       renderer.ProgressFunc // 序列帧进度回调
+	sink           renderer.FrameSink    // 序列帧输出目标，nil 表示写入脚本指定目录
+	width          int                   // 分辨率覆盖，0 表示使用脚本中的设置
+	height         int
+	frameRate      int               // 帧率覆盖，0 表示使用脚本中的设置
+	framesRendered int               // 已输出的序列帧总数
+	runtime        *defaults.Runtime // 运行时默认值（分辨率、帧率、输出目录、主题等），nil 表示使用全局配置
+	locale         i18n.Locale       // 错误和日志消息的语言，为空时使用进程默认语言
+
+	anchors       []axesAnchor        // 用坐标系坐标定位的对象，按设置的先后顺序
+	axesRevisions map[interface{}]int // 画在坐标系上的对象生成时坐标系的版本号
+}
+
+// axesPoint 坐标系中的一个点，由 <axes>(x, y) 求值得到
+type axesPoint struct {
+	axes  *geometry.CoordinateSystem
+	coord gmMath.Vector2
+}
+
+// axesAnchor 用坐标系坐标定位的对象，每帧渲染前按坐标系的当前状态重新放置
+type axesAnchor struct {
+	object core.Mobject
+	point  axesPoint
 }
 
 // NewEvaluator 创建新的执行引擎
 func NewEvaluator() *Evaluator {
 	return &Evaluator{
-		objects:    make(map[string]interface{}),
-		animations: make([]animation.Animation, 0),
-		errors:     []string{},
+		objects:       make(map[string]interface{}),
+		animations:    make([]animation.Animation, 0),
+		axesRevisions: make(map[interface{}]int),
+		errors:        []string{},
+		ctx:           context.Background(),
+		logger:        slog.Default(),
+	}
+}
+
+// SetRuntime 设置本次执行使用的运行时默认值，默认使用全局配置。
+// 嵌入调用通过它为每次渲染单独指定配置，不修改全局默认值
+func (e *Evaluator) SetRuntime(rt defaults.Runtime) {
+	e.runtime = &rt
+	for _, err := range fonts.Default().LoadPaths(rt.FontPaths) {
+		e.logger.Warn(i18n.Tl(e.locale, "log.font_load_failed"), "error", err)
+	}
+}
+
+// SetLocale 设置本次执行的错误和日志消息语言，为空时使用进程默认语言。
+// 语言随执行引擎传递，不修改进程默认语言，并发的渲染互不影响
+func (e *Evaluator) SetLocale(l i18n.Locale) {
+	e.locale = l
+}
+
+// SetContext 设置取消上下文，取消后在下一条语句或下一帧前停止执行
+func (e *Evaluator) SetContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e.ctx = ctx
+}
+
+// SetLogger 设置状态日志输出
+func (e *Evaluator) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	e.logger = logger
+}
+
+// SetProgressFunc 设置序列帧渲染进度回调
+func (e *Evaluator) SetProgressFunc(fn renderer.ProgressFunc) {
+	e.progress = fn
+}
+
+// SetFrameSink 设置序列帧输出目标，设置后不再自动调用 FFmpeg 生成视频
+func (e *Evaluator) SetFrameSink(sink renderer.FrameSink) {
+	e.sink = sink
+}
+
+// SetResolution 覆盖脚本中 scene 语句的分辨率，传入 0 表示不覆盖
+func (e *Evaluator) SetResolution(width, height int) {
+	e.width = width
+	e.height = height
+}
+
+// SetFrameRate 覆盖脚本中的帧率，传入 0 表示不覆盖
+func (e *Evaluator) SetFrameRate(fps int) {
+	e.frameRate = fps
+}
+
+// textSizeRange 运行时配置的文本字号范围
+func (e *Evaluator) textSizeRange() (min, max float64) {
+	rt := e.rt()
+	return rt.TextMinSize, rt.TextMaxSize
+}
+
+// rt 返回本次执行使用的运行时默认值
+func (e *Evaluator) rt() defaults.Runtime {
+	if e.runtime != nil {
+		return *e.runtime
 	}
+	return defaults.CurrentRuntime()
+}
+
+// FramesRendered 获取已输出的序列帧总数
+func (e *Evaluator) FramesRendered() int {
+	return e.framesRendered
 }
 
 // Evaluate 执行程序
 func (e *Evaluator) Evaluate(program *Program) error {
 	for _, stmt := range program.Statements {
+		if err := e.ctx.Err(); err != nil {
+			return err
+		}
 		err := e.evalStatement(stmt)
 		if err != nil {
 			e.errors = append(e.errors, err.Error())
@@ -56,8 +168,14 @@ func (e *Evaluator) evalStatement(stmt Statement) error {
 	// 更新当前执行的行号，用于错误定位
 	if token := getStatementToken(stmt); token != nil {
 		e.currentLine = token.Line
+		e.currentColumn = token.Column
 	}
 
+	return e.wrapError(e.dispatchStatement(stmt))
+}
+
+// dispatchStatement 按语句类型分派执行
+func (e *Evaluator) dispatchStatement(stmt Statement) error {
 	switch node := stmt.(type) {
 	case *SceneStatement:
 		return e.evalSceneStatement(node)
@@ -84,7 +202,7 @@ func (e *Evaluator) evalStatement(stmt Statement) error {
 	case *CleanStatement:
 		return e.evalCleanStatement(node)
 	default:
-		return e.newError("未知语句类型: %T", stmt)
+		return e.newError("eval.unknown_statement", stmt)
 	}
 }
 
@@ -120,20 +238,80 @@ func getStatementToken(stmt Statement) *Token {
 	}
 }
 
-// newError 创建更详细的错误信息
-func (e *Evaluator) newError(format string, args ...interface{}) error {
-	errorMsg := fmt.Sprintf(format, args...)
-	locationInfo := ""
+// SetFileName 设置当前执行的文件名，用于错误定位
+func (e *Evaluator) SetFileName(name string) {
+	e.fileName = name
+}
 
-	if e.fileName != "" {
-		locationInfo = fmt.Sprintf("文件: %s, 行: %d", e.fileName, e.currentLine)
-	} else {
-		locationInfo = fmt.Sprintf("行: %d", e.currentLine)
+// position 返回当前执行语句的源码位置
+func (e *Evaluator) position() Position {
+	return Position{File: e.fileName, Line: e.currentLine, Column: e.currentColumn}
+}
+
+// newError 创建带源码位置的通用执行错误，key 为消息表中的键
+func (e *Evaluator) newError(key string, args ...interface{}) error {
+	return e.newErrorCode(CodeRuntime, key, args...)
+}
+
+// newErrorCode 创建带源码位置和错误代码的执行错误，key 为消息表中的键。
+// 参数中的错误作为底层原因保存在 Err 中，可通过 errors.Is / errors.As 取出
+func (e *Evaluator) newErrorCode(code ErrorCode, key string, args ...interface{}) error {
+	var cause error
+	for i, arg := range args {
+		if err, ok := arg.(error); ok {
+			cause = err
+			args[i] = e.causeMessage(err)
+		}
+	}
+	err := &RuntimeError{
+		Position: e.position(),
+		Code:     code,
+		Message:  i18n.Tl(e.locale, key, args...),
+		Err:      cause,
+		locale:   e.locale,
+	}
+	e.logger.Debug(i18n.Tl(e.locale, "log.runtime_error"), "file", e.fileName, "line", e.currentLine, "code", code, "error", err.Message)
+	return err
+}
+
+// causeMessage 返回嵌入其他错误消息中的原因描述，结构化错误去掉已由外层错误给出的位置
+func (e *Evaluator) causeMessage(err error) string {
+	switch err := err.(type) {
+	case *RuntimeError:
+		if err.Message != "" {
+			return err.Message
+		}
+		if err.Err != nil {
+			return e.causeMessage(err.Err)
+		}
+	case *UnknownObjectError:
+		return i18n.Tl(e.locale, "eval.object_not_found", err.Name)
+	}
+	return err.Error()
+}
+
+// unknownObjectError 创建对象不存在错误
+func (e *Evaluator) unknownObjectError(name string) error {
+	return &UnknownObjectError{Position: e.position(), locale: e.locale, Code: CodeUnknownObject, Name: name}
+}
+
+// wrapError 为尚未携带位置的错误补充当前语句位置，已是结构化错误的原样返回
+func (e *Evaluator) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var runtimeErr *RuntimeError
+	var unknownErr *UnknownObjectError
+	if errors.As(err, &runtimeErr) || errors.As(err, &unknownErr) {
+		return err
 	}
 
-	fullError := fmt.Sprintf("执行错误 (%s): %s", locationInfo, errorMsg)
-	fmt.Fprintf(os.Stderr, "❌ %s\n", fullError)
-	return fmt.Errorf("%s", fullError)
+	code := CodeRuntime
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		code = CodeIO
+	}
+	return &RuntimeError{Position: e.position(), locale: e.locale, Code: code, Err: err}
 }
 
 // evalSceneStatement 执行场景语句
@@ -156,12 +334,18 @@ func (e *Evaluator) evalSceneStatement(stmt *SceneStatement) error {
 	w := int(width.(float64))
 	h := int(height.(float64))
 
-	// 如果指定为0或负数，使用默认的1920*1080分辨率
+	// 嵌入调用指定的分辨率优先
+	if e.width > 0 && e.height > 0 {
+		w, h = e.width, e.height
+	}
+
+	// 如果指定为0或负数，使用配置的默认分辨率
+	rt := e.rt()
 	if w <= 0 {
-		w = 1920
+		w = rt.Width
 	}
 	if h <= 0 {
-		h = 1080
+		h = rt.Height
 	}
 
 	// 设置项目名称
@@ -169,10 +353,13 @@ func (e *Evaluator) evalSceneStatement(stmt *SceneStatement) error {
 
 	// 创建场景
 	sc := scene.NewScene(w, h)
+	sc.SetBackground(float64(rt.Background.R)/255.0, float64(rt.Background.G)/255.0, float64(rt.Background.B)/255.0)
+	sc.SetFrameRate(rt.FPS)
 
 	// 设置渲染器
 	canvasRenderer := renderer.NewCanvasRenderer(w, h)
 	canvasRenderer.SetAutoSaveProjectName(e.projectName) // 设置自动保存项目名称
+	canvasRenderer.SetOutputRoot(rt.OutputRoot)
 	sc.SetRenderer(canvasRenderer)
 
 	e.scene = sc
@@ -182,7 +369,7 @@ func (e *Evaluator) evalSceneStatement(stmt *SceneStatement) error {
 // evalCreateStatement 执行创建语句
 func (e *Evaluator) evalCreateStatement(stmt *CreateStatement) error {
 	if e.scene == nil {
-		return e.newError("未定义场景，请先使用 'scene' 命令创建场景")
+		return e.newError("eval.no_scene")
 	}
 
 	var obj interface{}
@@ -203,25 +390,59 @@ func (e *Evaluator) evalCreateStatement(stmt *CreateStatement) error {
 		obj, err = e.createPolygon(stmt)
 	case TOKEN_TEXT:
 		obj, err = e.createText(stmt)
-	// 以下功能已被移除以简化项目
-	// case TOKEN_MARKDOWN:
-	// 	obj, err = e.createMarkdown(stmt)
-	// case TOKEN_TEX:
-	// 	obj, err = e.createTex(stmt)
-	// case TOKEN_MATHTEX:
-	// 	obj, err = e.createMathTex(stmt)
+	case TOKEN_TEX:
+		obj, err = e.createTex(stmt)
+	case TOKEN_MATHTEX:
+		obj, err = e.createMathTex(stmt)
+	case TOKEN_MARKDOWN:
+		obj, err = e.createMarkdown(stmt)
+	case TOKEN_OUTLINE:
+		obj, err = e.createOutline(stmt)
+	case TOKEN_PATH:
+		obj, err = e.createPath(stmt)
+	case TOKEN_SVG:
+		obj, err = e.createSVG(stmt)
+	case TOKEN_IMAGE:
+		obj, err = e.createImage(stmt)
 	case TOKEN_COORDINATE_SYSTEM:
 		obj, err = e.createCoordinateSystem(stmt)
+	case TOKEN_GRAPH:
+		obj, err = e.createGraph(stmt)
+	case TOKEN_PARAMETRIC:
+		obj, err = e.createCurve(stmt, false)
+	case TOKEN_POLAR:
+		obj, err = e.createCurve(stmt, true)
+	case TOKEN_IMPLICIT:
+		obj, err = e.createImplicit(stmt)
+	case TOKEN_VECTOR_FIELD:
+		obj, err = e.createVectorField(stmt, false)
+	case TOKEN_STREAMLINES:
+		obj, err = e.createVectorField(stmt, true)
+	case TOKEN_AREA:
+		obj, err = e.createArea(stmt)
+	case TOKEN_RIEMANN:
+		obj, err = e.createRiemann(stmt)
+	case TOKEN_TANGENT:
+		obj, err = e.createTracker(stmt, geometry.TrackerTangent)
+	case TOKEN_SECANT:
+		obj, err = e.createTracker(stmt, geometry.TrackerSecant)
+	case TOKEN_GRAPH_POINT:
+		obj, err = e.createTracker(stmt, geometry.TrackerPoint)
 	default:
-		return e.newError("未知对象类型: %s", stmt.ObjectType.Literal)
+		return e.newErrorCode(CodeUnknownObjectType, "eval.unknown_object_type", stmt.ObjectType.Literal)
 	}
 
 	if err != nil {
-		return e.newError("创建对象 '%s' 失败: %v", stmt.Name.Value, err)
+		return e.newError("eval.create_failed", stmt.Name.Value, err)
+	}
+
+	// 配置了配色主题时，新对象使用主题的前景色；文字轮廓沿用所转换文本的颜色
+	if stmt.ObjectType.Type != TOKEN_OUTLINE {
+		e.applyTheme(obj)
 	}
 
 	// 存储对象
-	e.objects[stmt.Name.Value] = obj
+	e.setVariable(stmt.Name.Value, obj)
 
 	// 添加到场景
 	if mobject, ok := obj.(core.Mobject); ok {
@@ -231,10 +452,46 @@ func (e *Evaluator) evalCreateStatement(stmt *CreateStatement) error {
 	return nil
 }
 
+// setVariable 把名称绑定到对象。名称原来绑定的对象不再跟随坐标系移动
+func (e *Evaluator) setVariable(name string, obj interface{}) {
+	if old, ok := e.objects[name]; ok && old != obj {
+		e.removeAnchor(old)
+		delete(e.axesRevisions, old)
+	}
+	e.objects[name] = obj
+	if axes := boundAxes(obj); axes != nil {
+		e.axesRevisions[obj] = axes.Revision()
+	}
+}
+
+// applyTheme 按配置的配色主题设置新对象的默认颜色，文本使用文字色，其他图形使用主要色
+func (e *Evaluator) applyTheme(obj interface{}) {
+	scheme, ok := colors.SchemeByName(e.rt().Theme)
+	if !ok {
+		return
+	}
+
+	switch o := obj.(type) {
+	case *geometry.CoordinateSystem, *geometry.SVG:
+		// 坐标系和 SVG 由多个部件组成，保留其自身配色
+	case *geometry.Image:
+		// 图像使用像素本身的颜色
+	case interface{ Colormap() *colors.Colormap }:
+		// 按色图着色的等高线、向量场和流线保留色图
+		if o.Colormap() == nil {
+			o.(core.Mobject).SetColor(scheme.GetPrimaryColor())
+		}
+	case *geometry.Text, *geometry.MathTex, *geometry.Markdown:
+		o.(core.Mobject).SetColor(scheme.GetLightColor())
+	case core.Mobject:
+		o.SetColor(scheme.GetPrimaryColor())
+	}
+}
+
 // createCircle 创建圆形
 func (e *Evaluator) createCircle(stmt *CreateStatement) (*geometry.Circle, error) {
 	if len(stmt.Parameters) < 1 {
-		return nil, fmt.Errorf("创建圆形需要指定半径参数")
+		return nil, i18n.Errorfl(e.locale, "circle.radius_required")
 	}
 
 	var radius float64
@@ -244,7 +501,7 @@ func (e *Evaluator) createCircle(stmt *CreateStatement) (*geometry.Circle, error
 	if coord, ok := stmt.Parameters[0].(*CoordinateExpression); ok {
 		// 第一个参数是坐标，第二个应该是半径
 		if len(stmt.Parameters) < 2 {
-			return nil, fmt.Errorf("指定位置后还需要指定半径")
+			return nil, i18n.Errorfl(e.locale, "circle.radius_after_position")
 		}
 
 		// 解析坐标
@@ -261,22 +518,22 @@ func (e *Evaluator) createCircle(stmt *CreateStatement) (*geometry.Circle, error
 		// 解析半径
 		radiusVal, err := e.evalExpression(stmt.Parameters[1])
 		if err != nil {
-			return nil, fmt.Errorf("解析圆形半径参数失败: %v", err)
+			return nil, i18n.Errorfl(e.locale, "circle.radius_parse", err)
 		}
 		radiusFloat, ok := radiusVal.(float64)
 		if !ok {
-			return nil, fmt.Errorf("圆形半径必须是数字，得到的是: %T", radiusVal)
+			return nil, i18n.Errorfl(e.locale, "circle.radius_type", radiusVal)
 		}
 		radius = radiusFloat
 	} else {
 		// 第一个参数是半径
 		radiusVal, err := e.evalExpression(stmt.Parameters[0])
 		if err != nil {
-			return nil, fmt.Errorf("解析圆形半径参数失败: %v", err)
+			return nil, i18n.Errorfl(e.locale, "circle.radius_parse", err)
 		}
 		radiusFloat, ok := radiusVal.(float64)
 		if !ok {
-			return nil, fmt.Errorf("圆形半径必须是数字，得到的是: %T", radiusVal)
+			return nil, i18n.Errorfl(e.locale, "circle.radius_type", radiusVal)
 		}
 		radius = radiusFloat
 
@@ -297,7 +554,7 @@ func (e *Evaluator) createCircle(stmt *CreateStatement) (*geometry.Circle, error
 	}
 
 	if radius <= 0 {
-		return nil, fmt.Errorf("圆形半径必须大于0，当前值: %v", radius)
+		return nil, i18n.Errorfl(e.locale, "circle.radius_positive", radius)
 	}
 
 	circle := geometry.NewCircle(radius)
@@ -316,7 +573,7 @@ func (e *Evaluator) createTriangle(stmt *CreateStatement) (*geometry.Triangle, e
 	numParams := len(stmt.Parameters)
 
 	if numParams == 0 {
-		return nil, fmt.Errorf("triangle requires at least one parameter")
+		return nil, i18n.Errorfl(e.locale, "triangle.params_required")
 	}
 
 	// 方式1: 通过三个顶点创建 - triangle name (x1,y1) (x2,y2) (x3,y3)
@@ -326,15 +583,15 @@ func (e *Evaluator) createTriangle(stmt *CreateStatement) (*geometry.Triangle, e
 			if coord, ok := param.(*CoordinateExpression); ok {
 				x, err := e.evalExpression(coord.X)
 				if err != nil {
-					return nil, fmt.Errorf("invalid vertex %d X coordinate: %v", i+1, err)
+					return nil, e.newErrorCode(CodeInvalidArgument, "vertex.invalid_x", i+1, err)
 				}
 				y, err := e.evalExpression(coord.Y)
 				if err != nil {
-					return nil, fmt.Errorf("invalid vertex %d Y coordinate: %v", i+1, err)
+					return nil, e.newErrorCode(CodeInvalidArgument, "vertex.invalid_y", i+1, err)
 				}
 				vertices[i] = gmMath.Vector2{X: x.(float64), Y: y.(float64)}
 			} else {
-				return nil, fmt.Errorf("vertex %d must be a coordinate (x,y)", i+1)
+				return nil, i18n.Errorfl(e.locale, "vertex.not_coordinate", i+1)
 			}
 		}
 		return geometry.NewTriangle(vertices[0], vertices[1], vertices[2]), nil
@@ -392,14 +649,14 @@ func (e *Evaluator) createTriangleByType(triangleType string, params []Expressio
 		// 等腰直角三角形: triangle name "isosceles" size (centerX, centerY)
 		return e.createIsoscelesTriangle(params)
 	default:
-		return nil, fmt.Errorf("unknown triangle type: %s. Supported types: equilateral, right, isosceles", triangleType)
+		return nil, i18n.Errorfl(e.locale, "triangle.unknown_type", triangleType)
 	}
 }
 
 // createEquilateralTriangle 创建等边三角形
 func (e *Evaluator) createEquilateralTriangle(params []Expression) (*geometry.Triangle, error) {
 	if len(params) < 1 {
-		return nil, fmt.Errorf("equilateral triangle requires side length")
+		return nil, i18n.Errorfl(e.locale, "triangle.equilateral_size")
 	}
 
 	sideLengthVal, err := e.evalExpression(params[0])
@@ -429,7 +686,7 @@ func (e *Evaluator) createEquilateralTriangle(params []Expression) (*geometry.Tr
 // createRightTriangle 创建直角三角形
 func (e *Evaluator) createRightTriangle(params []Expression) (*geometry.Triangle, error) {
 	if len(params) < 2 {
-		return nil, fmt.Errorf("right triangle requires width and height")
+		return nil, i18n.Errorfl(e.locale, "triangle.right_size")
 	}
 
 	widthVal, err := e.evalExpression(params[0])
@@ -465,7 +722,7 @@ func (e *Evaluator) createRightTriangle(params []Expression) (*geometry.Triangle
 // createIsoscelesTriangle 创建等腰直角三角形
 func (e *Evaluator) createIsoscelesTriangle(params []Expression) (*geometry.Triangle, error) {
 	if len(params) < 1 {
-		return nil, fmt.Errorf("isosceles triangle requires size")
+		return nil, i18n.Errorfl(e.locale, "triangle.isosceles_size")
 	}
 
 	sizeVal, err := e.evalExpression(params[0])
@@ -492,9 +749,124 @@ func (e *Evaluator) createIsoscelesTriangle(params []Expression) (*geometry.Tria
 	return geometry.NewTriangleByCenter(center, size), nil
 }
 
-// createCoordinateSystem 创建坐标系
+// createCoordinateSystem 创建坐标系：按参数确定范围，再应用 x_scale、y_scale、angles、
+// divisions、fit 选项
 func (e *Evaluator) createCoordinateSystem(stmt *CreateStatement) (*geometry.CoordinateSystem, error) {
-	numParams := len(stmt.Parameters)
+	var params, options []Expression
+	for _, param := range stmt.Parameters {
+		if _, ok := param.(*KeywordArgument); ok {
+			options = append(options, param)
+		} else {
+			params = append(params, param)
+		}
+	}
+	cs, err := e.coordinateSystemShape(params)
+	if err != nil {
+		return nil, err
+	}
+	cs.SetLabelSizeRange(e.textSizeRange())
+
+	xScale, yScale := cs.Scales()
+	fit := false
+	for _, param := range options {
+		option := param.(*KeywordArgument)
+		value, err := e.evalExpression(option.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch option.Name {
+		case "x_scale", "y_scale":
+			scale, err := e.axisScale(option.Name, value)
+			if err != nil {
+				return nil, err
+			}
+			if option.Name == "x_scale" {
+				xScale = scale
+			} else {
+				yScale = scale
+			}
+		case "angles":
+			name, _ := value.(string)
+			switch strings.ToLower(name) {
+			case "degrees":
+				cs.SetAngleUnit(geometry.AngleDegrees)
+			case "radians":
+				cs.SetAngleUnit(geometry.AngleRadians)
+			default:
+				return nil, i18n.Errorfl(e.locale, "axes.angles_unknown", value)
+			}
+		case "divisions":
+			n, ok := value.(float64)
+			if !ok || n < 1 || n != math.Trunc(n) {
+				return nil, i18n.Errorfl(e.locale, "axes.divisions_type", value)
+			}
+			cs.SetAngleDivisions(int(n))
+		case "fit":
+			on, ok := parseSwitch(value)
+			if !ok {
+				return nil, i18n.Errorfl(e.locale, "axes.switch_type", "fit", value)
+			}
+			fit = on
+		default:
+			return nil, i18n.Errorfl(e.locale, "axes.unknown_option", option.Name)
+		}
+	}
+
+	if xScale != geometry.ScaleLinear || yScale != geometry.ScaleLinear {
+		if cs.Polar() {
+			return nil, i18n.Errorfl(e.locale, "axes.polar_log")
+		}
+		xRange, yRange := cs.XRange(), cs.YRange()
+		if xScale == geometry.ScaleLog && !(xRange[0] > 0 && xRange[1] > xRange[0]) {
+			return nil, i18n.Errorfl(e.locale, "axes.log_range", "x", xRange[0], xRange[1])
+		}
+		if yScale == geometry.ScaleLog && !(yRange[0] > 0 && yRange[1] > yRange[0]) {
+			return nil, i18n.Errorfl(e.locale, "axes.log_range", "y", yRange[0], yRange[1])
+		}
+		cs.SetScales(xScale, yScale)
+	}
+	if fit {
+		e.fitToFrame(cs)
+	}
+	return cs, nil
+}
+
+// fitToFrame 把坐标系缩放到占满画面的 90%，范围中心放在场景原点；
+// 正方形范围保持两轴单位长度相同
+func (e *Evaluator) fitToFrame(cs *geometry.CoordinateSystem) {
+	if e.scene == nil {
+		return
+	}
+	width := float64(e.scene.GetWidth()) * 0.9
+	height := float64(e.scene.GetHeight()) * 0.9
+	xRange, yRange := cs.XRange(), cs.YRange()
+	xScale, yScale := cs.Scales()
+	if xScale == yScale && xRange[1]-xRange[0] == yRange[1]-yRange[0] {
+		width = math.Min(width, height)
+		height = width
+	}
+	cs.FitTo(width, height)
+}
+
+// axisScale 解析 x_scale、y_scale 选项
+func (e *Evaluator) axisScale(name string, value interface{}) (geometry.AxisScale, error) {
+	scale, _ := value.(string)
+	switch strings.ToLower(scale) {
+	case "linear":
+		return geometry.ScaleLinear, nil
+	case "log":
+		return geometry.ScaleLog, nil
+	}
+	return geometry.ScaleLinear, i18n.Errorfl(e.locale, "axes.scale_unknown", name, value)
+}
+
+// coordinateSystemShape 按位置参数创建坐标系：
+//
+//	create axes <name> ["standard"|"small"|"large"|"viewport"|"auto"]
+//	create axes <name> "polar" [rMax] [spacing]
+//	create axes <name> xMin xMax yMin yMax spacing
+func (e *Evaluator) coordinateSystemShape(params []Expression) (*geometry.CoordinateSystem, error) {
+	numParams := len(params)
 
 	// 默认创建适应视口的坐标系
 	if numParams == 0 {
@@ -507,9 +879,14 @@ func (e *Evaluator) createCoordinateSystem(stmt *CreateStatement) (*geometry.Coo
 		return geometry.NewStandardCoordinateSystem(), nil
 	}
 
-	// 如果第一个参数是字符串，可能是预定义类型
+	// 极坐标网格："polar" 后可以跟半径和间距
+	if firstVal, err := e.evalExpression(params[0]); err == nil {
+		if typeStr, ok := firstVal.(string); ok && strings.ToLower(typeStr) == "polar" {
+			return e.polarCoordinateSystem(params[1:])
+		}
+	}
 	if numParams == 1 {
-		if firstVal, err := e.evalExpression(stmt.Parameters[0]); err == nil {
+		if firstVal, err := e.evalExpression(params[0]); err == nil {
 			if typeStr, ok := firstVal.(string); ok {
 				switch strings.ToLower(typeStr) {
 				case "standard":
@@ -527,7 +904,7 @@ func (e *Evaluator) createCoordinateSystem(stmt *CreateStatement) (*geometry.Coo
 					}
 					return geometry.NewStandardCoordinateSystem(), nil
 				default:
-					return nil, fmt.Errorf("unknown coordinate system type: %s. Supported: standard, small, large, viewport, auto", typeStr)
+					return nil, i18n.Errorfl(e.locale, "axes.unknown_type", typeStr)
 				}
 			}
 		}
@@ -535,25 +912,25 @@ func (e *Evaluator) createCoordinateSystem(stmt *CreateStatement) (*geometry.Coo
 
 	// 自定义坐标系: coord_system name xMin xMax yMin yMax spacing
 	if numParams >= 5 {
-		xMinVal, err := e.evalExpression(stmt.Parameters[0])
+		xMinVal, err := e.evalExpression(params[0])
 		if err != nil {
-			return nil, fmt.Errorf("invalid xMin: %v", err)
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", "xMin", err)
 		}
-		xMaxVal, err := e.evalExpression(stmt.Parameters[1])
+		xMaxVal, err := e.evalExpression(params[1])
 		if err != nil {
-			return nil, fmt.Errorf("invalid xMax: %v", err)
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", "xMax", err)
 		}
-		yMinVal, err := e.evalExpression(stmt.Parameters[2])
+		yMinVal, err := e.evalExpression(params[2])
 		if err != nil {
-			return nil, fmt.Errorf("invalid yMin: %v", err)
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", "yMin", err)
 		}
-		yMaxVal, err := e.evalExpression(stmt.Parameters[3])
+		yMaxVal, err := e.evalExpression(params[3])
 		if err != nil {
-			return nil, fmt.Errorf("invalid yMax: %v", err)
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", "yMax", err)
 		}
-		spacingVal, err := e.evalExpression(stmt.Parameters[4])
+		spacingVal, err := e.evalExpression(params[4])
 		if err != nil {
-			return nil, fmt.Errorf("invalid spacing: %v", err)
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", "spacing", err)
 		}
 
 		xMin := xMinVal.(float64)
@@ -566,175 +943,1196 @@ func (e *Evaluator) createCoordinateSystem(stmt *CreateStatement) (*geometry.Coo
 	}
 
 	// 如果参数数量不匹配，返回错误
-	return nil, fmt.Errorf("coordinate system requires 0 (standard), 1 (type), or 5+ (custom) parameters, got %d", numParams)
+	return nil, i18n.Errorfl(e.locale, "axes.param_count", numParams)
 }
 
-// createRectangle 创建矩形
-func (e *Evaluator) createRectangle(stmt *CreateStatement) (*geometry.Rectangle, error) {
-	if len(stmt.Parameters) < 2 {
-		return nil, fmt.Errorf("rectangle requires width and height parameters")
+// polarCoordinateSystem 创建极坐标网格的坐标系，范围为 [-rMax, rMax]²，
+// 半径默认为 5，同心圆间距默认为 1
+func (e *Evaluator) polarCoordinateSystem(params []Expression) (*geometry.CoordinateSystem, error) {
+	if len(params) > 2 {
+		return nil, i18n.Errorfl(e.locale, "axes.polar_usage")
+	}
+	numbers := []float64{5, 1} // rMax 和间距
+	for i, param := range params {
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok || number <= 0 {
+			return nil, i18n.Errorfl(e.locale, "axes.invalid_param", []string{"rMax", "spacing"}[i], value)
+		}
+		numbers[i] = number
 	}
+	rMax := numbers[0]
+	cs := geometry.NewCoordinateSystem([2]float64{-rMax, rMax}, [2]float64{-rMax, rMax}, numbers[1])
+	return cs.SetPolar(true), nil
+}
 
-	widthVal, err := e.evalExpression(stmt.Parameters[0])
+// createGraph 在已有坐标系中绘制函数图像：create graph <name> <axes> "<expression>" [xMin xMax]，
+// 表达式以 x 为自变量，省略 x 范围时使用坐标系的 x 范围
+// axesObject 按名称取出坐标系对象，对象不存在时返回 UnknownObjectError
+func (e *Evaluator) axesObject(name string) (*geometry.CoordinateSystem, error) {
+	obj, exists := e.objects[name]
+	if !exists {
+		return nil, e.unknownObjectError(name)
+	}
+	axes, ok := obj.(*geometry.CoordinateSystem)
+	if !ok {
+		return nil, e.newErrorCode(CodeInvalidArgument, "graph.not_axes", name)
+	}
+	return axes, nil
+}
+
+func (e *Evaluator) createGraph(stmt *CreateStatement) (*geometry.FunctionGraph, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "graph.usage")
+	}
+	axesName, ok := stmt.Parameters[0].(*Identifier)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "graph.usage")
+	}
+	axes, err := e.axesObject(axesName.Value)
 	if err != nil {
 		return nil, err
 	}
 
-	heightVal, err := e.evalExpression(stmt.Parameters[1])
+	value, err := e.evalExpression(stmt.Parameters[1])
 	if err != nil {
 		return nil, err
 	}
+	source, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "graph.usage")
+	}
+	expr, err := gmMath.ParseExprIn(source, "x")
+	if err != nil {
+		return nil, i18n.Errorfl(e.locale, "graph.invalid_function", source, err)
+	}
 
-	width := widthVal.(float64)
-	height := heightVal.(float64)
-	rect := geometry.NewRectangle(width, height)
-
-	// 如果有位置参数
-	if len(stmt.Parameters) >= 3 {
-		if coord, ok := stmt.Parameters[2].(*CoordinateExpression); ok {
-			x, err := e.evalExpression(coord.X)
+	xRange := axes.XRange()
+	switch len(stmt.Parameters) {
+	case 2:
+	case 4:
+		for i := range xRange {
+			value, err := e.evalExpression(stmt.Parameters[2+i])
 			if err != nil {
 				return nil, err
 			}
-			y, err := e.evalExpression(coord.Y)
-			if err != nil {
-				return nil, err
+			x, ok := value.(float64)
+			if !ok {
+				return nil, i18n.Errorfl(e.locale, "graph.range_type")
 			}
-			rect.MoveTo(gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+			xRange[i] = x
 		}
+		if xRange[0] >= xRange[1] {
+			return nil, i18n.Errorfl(e.locale, "graph.range_type")
+		}
+	default:
+		return nil, i18n.Errorfl(e.locale, "graph.range_type")
 	}
 
-	return rect, nil
-}
-
-// createLine 创建线条
-func (e *Evaluator) createLine(stmt *CreateStatement) (*geometry.Line, error) {
-	if len(stmt.Parameters) < 2 {
-		return nil, fmt.Errorf("line requires start and end coordinate parameters")
+	vars := map[string]float64{}
+	function := func(x float64) float64 {
+		vars["x"] = x
+		return expr.Eval(vars)
 	}
+	return geometry.NewFunctionGraph(axes, function, xRange[0], xRange[1]), nil
+}
 
-	start, ok1 := stmt.Parameters[0].(*CoordinateExpression)
-	end, ok2 := stmt.Parameters[1].(*CoordinateExpression)
-
-	if !ok1 || !ok2 {
-		return nil, fmt.Errorf("line requires coordinate expressions")
+// createCurve 创建参数曲线或极坐标曲线：
+//
+//	create parametric <name> [axes] "<x(t)>" "<y(t)>" [tMin tMax [resolution]]
+//	create polar <name> [axes] "<r(theta)>" [thetaMin thetaMax [resolution]]
+//
+// 参数范围默认为 0 到 2π；指定坐标系时曲线画在该坐标系中
+func (e *Evaluator) createCurve(stmt *CreateStatement, polar bool) (*geometry.ParametricCurve, error) {
+	usage := "curve.parametric_usage"
+	variable, count := "t", 2
+	if polar {
+		usage = "curve.polar_usage"
+		variable, count = "theta", 1
+	}
+
+	params := stmt.Parameters
+	var axes *geometry.CoordinateSystem
+	if len(params) > 0 {
+		if ident, ok := params[0].(*Identifier); ok {
+			var err error
+			if axes, err = e.axesObject(ident.Value); err != nil {
+				return nil, err
+			}
+			params = params[1:]
+		}
 	}
 
-	startX, err := e.evalExpression(start.X)
-	if err != nil {
-		return nil, err
+	if len(params) < count {
+		return nil, i18n.Errorfl(e.locale, usage)
 	}
-	startY, err := e.evalExpression(start.Y)
-	if err != nil {
-		return nil, err
+	exprs := make([]gmMath.Expr, count)
+	for i := range exprs {
+		literal, ok := params[i].(*StringLiteral)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, usage)
+		}
+		expr, err := gmMath.ParseExprIn(literal.Value, variable)
+		if err != nil {
+			return nil, i18n.Errorfl(e.locale, "graph.invalid_function", literal.Value, err)
+		}
+		exprs[i] = expr
 	}
 
-	endX, err := e.evalExpression(end.X)
-	if err != nil {
-		return nil, err
+	numbers := make([]float64, 0, 3)
+	for _, param := range params[count:] {
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "curve.range_type")
+		}
+		numbers = append(numbers, number)
 	}
-	endY, err := e.evalExpression(end.Y)
-	if err != nil {
-		return nil, err
+	tRange := [2]float64{0, 2 * math.Pi}
+	switch len(numbers) {
+	case 0:
+	case 2, 3:
+		tRange = [2]float64{numbers[0], numbers[1]}
+		if tRange[0] == tRange[1] {
+			return nil, i18n.Errorfl(e.locale, "curve.range_type")
+		}
+	default:
+		return nil, i18n.Errorfl(e.locale, "curve.range_type")
 	}
 
-	startVec := gmMath.Vector2{X: startX.(float64), Y: startY.(float64)}
-	endVec := gmMath.Vector2{X: endX.(float64), Y: endY.(float64)}
-
-	return geometry.NewLine(startVec, endVec), nil
-}
-
-// createArrow 创建箭头
-func (e *Evaluator) createArrow(stmt *CreateStatement) (*geometry.Arrow, error) {
-	if len(stmt.Parameters) < 2 {
-		return nil, fmt.Errorf("arrow requires start and end coordinate parameters")
+	vars := map[string]float64{}
+	var curve *geometry.ParametricCurve
+	if polar {
+		curve = geometry.NewPolarCurve(axes, func(theta float64) float64 {
+			vars[variable] = theta
+			return exprs[0].Eval(vars)
+		}, tRange[0], tRange[1])
+	} else {
+		curve = geometry.NewParametricCurve(axes, func(t float64) gmMath.Vector2 {
+			vars[variable] = t
+			return gmMath.Vector2{X: exprs[0].Eval(vars), Y: exprs[1].Eval(vars)}
+		}, tRange[0], tRange[1])
 	}
 
-	start, ok1 := stmt.Parameters[0].(*CoordinateExpression)
-	end, ok2 := stmt.Parameters[1].(*CoordinateExpression)
-
-	if !ok1 || !ok2 {
-		return nil, fmt.Errorf("arrow requires coordinate expressions")
+	if len(numbers) == 3 {
+		resolution, err := e.curveResolution(numbers[2])
+		if err != nil {
+			return nil, err
+		}
+		curve.SetResolution(resolution)
 	}
+	return curve, nil
+}
 
-	startX, err := e.evalExpression(start.X)
-	if err != nil {
-		return nil, err
+// createImplicit 追踪隐函数曲线或等高线：
+//
+//	create implicit <name> <axes> "<f(x, y)>" [level | [level1, level2, ...]]
+//
+// 表达式可以写成方程 "x^2 + y^2 = 4"，此时追踪两边之差等于给定值（默认 0）的曲线
+func (e *Evaluator) createImplicit(stmt *CreateStatement) (*geometry.ContourPlot, error) {
+	if len(stmt.Parameters) < 2 || len(stmt.Parameters) > 3 {
+		return nil, i18n.Errorfl(e.locale, "implicit.usage")
 	}
-	startY, err := e.evalExpression(start.Y)
-	if err != nil {
-		return nil, err
+	axesName, ok := stmt.Parameters[0].(*Identifier)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "implicit.usage")
 	}
-
-	endX, err := e.evalExpression(end.X)
+	axes, err := e.axesObject(axesName.Value)
 	if err != nil {
 		return nil, err
 	}
-	endY, err := e.evalExpression(end.Y)
+	literal, ok := stmt.Parameters[1].(*StringLiteral)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "implicit.usage")
+	}
+	expr, err := parseEquation(literal.Value, "x", "y")
 	if err != nil {
-		return nil, err
+		return nil, i18n.Errorfl(e.locale, "graph.invalid_function", literal.Value, err)
 	}
 
-	startVec := gmMath.Vector2{X: startX.(float64), Y: startY.(float64)}
-	endVec := gmMath.Vector2{X: endX.(float64), Y: endY.(float64)}
+	levels := []float64{0}
+	if len(stmt.Parameters) == 3 {
+		if levels, err = e.evalLevels(stmt.Parameters[2]); err != nil {
+			return nil, err
+		}
+	}
 
-	return geometry.NewArrow(startVec, endVec), nil
+	vars := map[string]float64{}
+	return geometry.NewContourPlot(axes, func(x, y float64) float64 {
+		vars["x"], vars["y"] = x, y
+		return expr.Eval(vars)
+	}, levels), nil
 }
 
-// createPolygon 创建多边形
-func (e *Evaluator) createPolygon(stmt *CreateStatement) (*geometry.Polygon, error) {
-	if len(stmt.Parameters) < 1 {
-		return nil, fmt.Errorf("polygon requires points array parameter")
+// createVectorField 创建向量场或流线：
+//
+//	create vector_field <name> <axes> "(<P(x, y)>, <Q(x, y)>)" [spacing]
+//	create streamlines <name> <axes> "(<P(x, y)>, <Q(x, y)>)" [spacing]
+//
+// 两个分量也可以写成两个字符串 "<P>" "<Q>"；spacing 为箭头或流线起点的间距，默认为坐标系的网格间距
+func (e *Evaluator) createVectorField(stmt *CreateStatement, streamlines bool) (interface{}, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "field.usage")
 	}
-
-	arrayExpr, ok := stmt.Parameters[0].(*ArrayExpression)
+	axesName, ok := stmt.Parameters[0].(*Identifier)
 	if !ok {
-		return nil, fmt.Errorf("polygon requires array of coordinates")
+		return nil, i18n.Errorfl(e.locale, "field.usage")
+	}
+	axes, err := e.axesObject(axesName.Value)
+	if err != nil {
+		return nil, err
 	}
 
-	var points []gmMath.Vector2
-	for _, elem := range arrayExpr.Elements {
-		coord, ok := elem.(*CoordinateExpression)
+	params := stmt.Parameters[1:]
+	var sources []string
+	for len(params) > 0 && len(sources) < 2 {
+		literal, ok := params[0].(*StringLiteral)
 		if !ok {
-			return nil, fmt.Errorf("polygon array must contain coordinate expressions")
+			break
 		}
-
-		x, err := e.evalExpression(coord.X)
-		if err != nil {
+		sources = append(sources, literal.Value)
+		params = params[1:]
+	}
+	var components []string
+	switch len(sources) {
+	case 1:
+		var err error
+		if components, err = e.splitVector(sources[0]); err != nil {
 			return nil, err
 		}
-		y, err := e.evalExpression(coord.Y)
+	case 2:
+		components = sources
+	default:
+		return nil, i18n.Errorfl(e.locale, "field.usage")
+	}
+	exprs := make([]gmMath.Expr, 2)
+	for i, source := range components {
+		expr, err := gmMath.ParseExprIn(source, "x", "y")
 		if err != nil {
-			return nil, err
+			return nil, i18n.Errorfl(e.locale, "graph.invalid_function", source, err)
 		}
+		exprs[i] = expr
+	}
 
-		points = append(points, gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+	spacing := 0.0
+	switch len(params) {
+	case 0:
+	case 1:
+		value, err := e.evalExpression(params[0])
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok || number <= 0 {
+			return nil, i18n.Errorfl(e.locale, "field.spacing_type")
+		}
+		spacing = number
+	default:
+		return nil, i18n.Errorfl(e.locale, "field.usage")
 	}
 
-	return geometry.NewPolygon(points), nil
+	vars := map[string]float64{}
+	function := func(x, y float64) gmMath.Vector2 {
+		vars["x"], vars["y"] = x, y
+		return gmMath.Vector2{X: exprs[0].Eval(vars), Y: exprs[1].Eval(vars)}
+	}
+	if streamlines {
+		return geometry.NewStreamLines(axes, function, spacing), nil
+	}
+	return geometry.NewVectorField(axes, function, spacing), nil
 }
 
-// createText 创建文本对象
-func (e *Evaluator) createText(stmt *CreateStatement) (*geometry.Text, error) {
-	// 检查参数数量：至少需要文本内容和字体大小
+// splitVector 把 "(P, Q)" 拆成两个分量，括号可以省略；只在最外层的逗号处拆分
+func (e *Evaluator) splitVector(source string) ([]string, error) {
+	s := strings.TrimSpace(source)
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = s[1 : len(s)-1]
+	}
+	depth, split := 0, -1
+	for i, ch := range s {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				if split >= 0 {
+					return nil, i18n.Errorfl(e.locale, "field.components", source)
+				}
+				split = i
+			}
+		}
+	}
+	if split < 0 {
+		return nil, i18n.Errorfl(e.locale, "field.components", source)
+	}
+	return []string{s[:split], s[split+1:]}, nil
+}
+
+// curveFunction 解析 y = f(x) 形式的曲线：表达式字符串（包括 derivative(...) 等调用的结果），或已有函数图像的名称
+func (e *Evaluator) curveFunction(param Expression) (func(x float64) float64, error) {
+	if name, ok := param.(*Identifier); ok {
+		graph, ok := e.objects[name.Value].(*geometry.FunctionGraph)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "area.not_graph", name.Value)
+		}
+		return graph.Function(), nil
+	}
+	value, err := e.evalExpression(param)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "area.curve_type")
+	}
+	expr, err := gmMath.ParseExprIn(source, "x")
+	if err != nil {
+		return nil, i18n.Errorfl(e.locale, "graph.invalid_function", source, err)
+	}
+	vars := map[string]float64{}
+	return func(x float64) float64 {
+		vars["x"] = x
+		return expr.Eval(vars)
+	}, nil
+}
+
+// evalRange 解析可选的 x 范围：没有参数时使用坐标系的 x 范围，否则必须是两个不同的数字
+func (e *Evaluator) evalRange(params []Expression, axes *geometry.CoordinateSystem) ([2]float64, error) {
+	xRange := axes.XRange()
+	switch len(params) {
+	case 0:
+		return xRange, nil
+	case 2:
+		for i := range xRange {
+			value, err := e.evalExpression(params[i])
+			if err != nil {
+				return xRange, err
+			}
+			x, ok := value.(float64)
+			if !ok {
+				return xRange, i18n.Errorfl(e.locale, "graph.range_type")
+			}
+			xRange[i] = x
+		}
+		if xRange[0] != xRange[1] {
+			return xRange, nil
+		}
+	}
+	return xRange, i18n.Errorfl(e.locale, "graph.range_type")
+}
+
+// createArea 创建曲线与 x 轴之间或两条曲线之间的区域：
+//
+//	create area <name> <axes> <curve> [<curve2>] [xMin xMax]
+//
+// 曲线可以是以 x 为自变量的表达式字符串或已有函数图像的名称，省略 x 范围时使用坐标系的 x 范围
+func (e *Evaluator) createArea(stmt *CreateStatement) (*geometry.Area, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "area.usage")
+	}
+	axesName, ok := stmt.Parameters[0].(*Identifier)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "area.usage")
+	}
+	axes, err := e.axesObject(axesName.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// 数字之前的一到两个参数为曲线，其余为 x 范围
+	var curves []func(x float64) float64
+	params := stmt.Parameters[1:]
+	for len(params) > 0 && len(curves) < 2 {
+		if _, ok := params[0].(*NumberLiteral); ok {
+			break
+		}
+		curve, err := e.curveFunction(params[0])
+		if err != nil {
+			return nil, err
+		}
+		curves = append(curves, curve)
+		params = params[1:]
+	}
+	if len(curves) == 0 {
+		return nil, i18n.Errorfl(e.locale, "area.usage")
+	}
+	xRange, err := e.evalRange(params, axes)
+	if err != nil {
+		return nil, err
+	}
+
+	var lower func(x float64) float64
+	if len(curves) == 2 {
+		lower = curves[1]
+	}
+	return geometry.NewArea(axes, curves[0], lower, xRange[0], xRange[1]), nil
+}
+
+// createRiemann 创建黎曼和：
+//
+//	create riemann <name> <axes> <curve> [xMin xMax] [n=<count>] [method=left|right|mid|trap]
+//
+// 默认把 x 范围分成 8 个区间，按左端点取高度
+func (e *Evaluator) createRiemann(stmt *CreateStatement) (*geometry.RiemannSum, error) {
+	var params []Expression
+	n, method := 8, geometry.RiemannLeft
+	for _, param := range stmt.Parameters {
+		option, ok := param.(*KeywordArgument)
+		if !ok {
+			params = append(params, param)
+			continue
+		}
+		value, err := e.evalExpression(option.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch option.Name {
+		case "n":
+			if n, err = e.riemannCount(value); err != nil {
+				return nil, err
+			}
+		case "method":
+			if method, err = e.riemannMethod(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, i18n.Errorfl(e.locale, "riemann.unknown_option", option.Name)
+		}
+	}
+
+	if len(params) < 2 {
+		return nil, i18n.Errorfl(e.locale, "riemann.usage")
+	}
+	axesName, ok := params[0].(*Identifier)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "riemann.usage")
+	}
+	axes, err := e.axesObject(axesName.Value)
+	if err != nil {
+		return nil, err
+	}
+	function, err := e.curveFunction(params[1])
+	if err != nil {
+		return nil, err
+	}
+	xRange, err := e.evalRange(params[2:], axes)
+	if err != nil {
+		return nil, err
+	}
+	return geometry.NewRiemannSum(axes, function, xRange[0], xRange[1], n, method), nil
+}
+
+// riemannCount 检查区间数是否为 1 到 10000 之间的整数
+func (e *Evaluator) riemannCount(value interface{}) (int, error) {
+	n, ok := value.(float64)
+	if !ok || n < 1 || n > 10000 || n != math.Trunc(n) {
+		return 0, i18n.Errorfl(e.locale, "riemann.n_type", value)
+	}
+	return int(n), nil
+}
+
+// riemannMethod 解析黎曼和的取样方式
+func (e *Evaluator) riemannMethod(value interface{}) (geometry.RiemannMethod, error) {
+	name, _ := value.(string)
+	method, ok := geometry.ParseRiemannMethod(name)
+	if !ok {
+		return method, i18n.Errorfl(e.locale, "riemann.method_unknown", value, strings.Join(geometry.RiemannMethodNames(), ", "))
+	}
+	return method, nil
+}
+
+// createTracker 创建绑定在函数图像上的跟踪对象：
+//
+//	create tangent <name> <axes> <curve> <x> [length=<length>] [label="<format>"]
+//	create secant <name> <axes> <curve> <x> [dx] [length=<length>] [label="<format>"]
+//	create graph_point <name> <axes> <curve> <x> [label="<format>"]
+//
+// 割线的 dx 默认为 1；曲线可以是以 x 为自变量的表达式字符串或已有函数图像的名称
+func (e *Evaluator) createTracker(stmt *CreateStatement, kind geometry.TrackerKind) (*geometry.GraphTracker, error) {
+	usage := "tracker.usage"
+	if kind == geometry.TrackerSecant {
+		usage = "secant.usage"
+	}
+	var params, options []Expression
+	for _, param := range stmt.Parameters {
+		if _, ok := param.(*KeywordArgument); ok {
+			options = append(options, param)
+		} else {
+			params = append(params, param)
+		}
+	}
+	maxParams := 3
+	if kind == geometry.TrackerSecant {
+		maxParams = 4
+	}
+	if len(params) < 3 || len(params) > maxParams {
+		return nil, i18n.Errorfl(e.locale, usage, stmt.ObjectType.Literal)
+	}
+	axesName, ok := params[0].(*Identifier)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, usage, stmt.ObjectType.Literal)
+	}
+	axes, err := e.axesObject(axesName.Value)
+	if err != nil {
+		return nil, err
+	}
+	function, err := e.curveFunction(params[1])
+	if err != nil {
+		return nil, err
+	}
+	numbers := []float64{0, 1} // x 和 dx
+	for i, param := range params[2:] {
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "tracker.number_type", []string{"x", "dx"}[i], value)
+		}
+		numbers[i] = number
+	}
+
+	tracker := geometry.NewGraphTracker(axes, function, kind, numbers[0], numbers[1])
+	for _, param := range options {
+		option := param.(*KeywordArgument)
+		value, err := e.evalExpression(option.Value)
+		if err != nil {
+			return nil, err
+		}
+		// graph_point 没有直线，不支持 length
+		if option.Name != "label" && (option.Name != "length" || kind == geometry.TrackerPoint) {
+			names := "length, label"
+			if kind == geometry.TrackerPoint {
+				names = "label"
+			}
+			return nil, i18n.Errorfl(e.locale, "tracker.unknown_option", stmt.ObjectType.Literal, option.Name, names)
+		}
+		if err := e.setTrackerProperty(tracker, option.Name, value); err != nil {
+			return nil, err
+		}
+	}
+	return tracker, nil
+}
+
+// setTrackerProperty 设置跟踪对象的 x、dx、length 或 label 属性
+func (e *Evaluator) setTrackerProperty(tracker *geometry.GraphTracker, property string, value interface{}) error {
+	if property == "label" {
+		format, ok := value.(string)
+		if !ok {
+			return i18n.Errorfl(e.locale, "tracker.label_type", value)
+		}
+		tracker.SetLabel(format)
+		return nil
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return i18n.Errorfl(e.locale, "tracker.number_type", property, value)
+	}
+	switch property {
+	case "x":
+		tracker.SetX(number)
+	case "dx":
+		tracker.SetDX(number)
+	case "length":
+		if number < 0 {
+			return i18n.Errorfl(e.locale, "tracker.length_type", value)
+		}
+		tracker.SetLength(number)
+	}
+	return nil
+}
+
+// parseEquation 解析表达式或方程，方程 "a = b" 转换为 a - b
+func parseEquation(source string, variables ...string) (gmMath.Expr, error) {
+	sides := strings.Split(source, "=")
+	if len(sides) != 2 {
+		return gmMath.ParseExprIn(source, variables...)
+	}
+	left, err := gmMath.ParseExprIn(sides[0], variables...)
+	if err != nil {
+		return nil, err
+	}
+	right, err := gmMath.ParseExprIn(sides[1], variables...)
+	if err != nil {
+		return nil, err
+	}
+	return &gmMath.Binary{Op: '-', Left: left, Right: right}, nil
+}
+
+// evalLevels 解析等值：一个数字或数字数组
+func (e *Evaluator) evalLevels(param Expression) ([]float64, error) {
+	elements := []Expression{param}
+	if array, ok := param.(*ArrayExpression); ok {
+		elements = array.Elements
+	}
+	if len(elements) == 0 {
+		return nil, i18n.Errorfl(e.locale, "implicit.levels_type")
+	}
+	levels := make([]float64, len(elements))
+	for i, element := range elements {
+		value, err := e.evalExpression(element)
+		if err != nil {
+			return nil, err
+		}
+		level, ok := value.(float64)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "implicit.levels_type")
+		}
+		levels[i] = level
+	}
+	return levels, nil
+}
+
+// curveResolution 检查采样数是否为不小于 2 的整数
+func (e *Evaluator) curveResolution(value float64) (int, error) {
+	if value < 2 || value != math.Trunc(value) || value > 1e6 {
+		return 0, i18n.Errorfl(e.locale, "curve.resolution_type", value)
+	}
+	return int(value), nil
+}
+
+// createRectangle 创建矩形
+func (e *Evaluator) createRectangle(stmt *CreateStatement) (*geometry.Rectangle, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "rect.size_required")
+	}
+
+	widthVal, err := e.evalExpression(stmt.Parameters[0])
+	if err != nil {
+		return nil, err
+	}
+
+	heightVal, err := e.evalExpression(stmt.Parameters[1])
+	if err != nil {
+		return nil, err
+	}
+
+	width := widthVal.(float64)
+	height := heightVal.(float64)
+	rect := geometry.NewRectangle(width, height)
+
+	// 如果有位置参数
+	if len(stmt.Parameters) >= 3 {
+		if coord, ok := stmt.Parameters[2].(*CoordinateExpression); ok {
+			x, err := e.evalExpression(coord.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := e.evalExpression(coord.Y)
+			if err != nil {
+				return nil, err
+			}
+			rect.MoveTo(gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+		}
+	}
+
+	return rect, nil
+}
+
+// createLine 创建线条
+func (e *Evaluator) createLine(stmt *CreateStatement) (*geometry.Line, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "line.points_required")
+	}
+
+	start, ok1 := stmt.Parameters[0].(*CoordinateExpression)
+	end, ok2 := stmt.Parameters[1].(*CoordinateExpression)
+
+	if !ok1 || !ok2 {
+		return nil, i18n.Errorfl(e.locale, "line.coordinates_required")
+	}
+
+	startX, err := e.evalExpression(start.X)
+	if err != nil {
+		return nil, err
+	}
+	startY, err := e.evalExpression(start.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	endX, err := e.evalExpression(end.X)
+	if err != nil {
+		return nil, err
+	}
+	endY, err := e.evalExpression(end.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	startVec := gmMath.Vector2{X: startX.(float64), Y: startY.(float64)}
+	endVec := gmMath.Vector2{X: endX.(float64), Y: endY.(float64)}
+
+	return geometry.NewLine(startVec, endVec), nil
+}
+
+// createArrow 创建箭头
+func (e *Evaluator) createArrow(stmt *CreateStatement) (*geometry.Arrow, error) {
+	if len(stmt.Parameters) < 2 {
+		return nil, i18n.Errorfl(e.locale, "arrow.points_required")
+	}
+
+	start, ok1 := stmt.Parameters[0].(*CoordinateExpression)
+	end, ok2 := stmt.Parameters[1].(*CoordinateExpression)
+
+	if !ok1 || !ok2 {
+		return nil, i18n.Errorfl(e.locale, "arrow.coordinates_required")
+	}
+
+	startX, err := e.evalExpression(start.X)
+	if err != nil {
+		return nil, err
+	}
+	startY, err := e.evalExpression(start.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	endX, err := e.evalExpression(end.X)
+	if err != nil {
+		return nil, err
+	}
+	endY, err := e.evalExpression(end.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	startVec := gmMath.Vector2{X: startX.(float64), Y: startY.(float64)}
+	endVec := gmMath.Vector2{X: endX.(float64), Y: endY.(float64)}
+
+	return geometry.NewArrow(startVec, endVec), nil
+}
+
+// createPolygon 创建多边形
+func (e *Evaluator) createPolygon(stmt *CreateStatement) (*geometry.Polygon, error) {
+	if len(stmt.Parameters) < 1 {
+		return nil, i18n.Errorfl(e.locale, "polygon.points_required")
+	}
+
+	arrayExpr, ok := stmt.Parameters[0].(*ArrayExpression)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "polygon.array_required")
+	}
+
+	var points []gmMath.Vector2
+	for _, elem := range arrayExpr.Elements {
+		coord, ok := elem.(*CoordinateExpression)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "polygon.coordinates_required")
+		}
+
+		x, err := e.evalExpression(coord.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := e.evalExpression(coord.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+	}
+
+	return geometry.NewPolygon(points), nil
+}
+
+// unescapeText 将文本内容中的 \n、\t 和 \\ 转换为对应字符
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\t`, "\t").Replace(s)
+}
+
+// createText 创建文本对象，内容为字符串数组时创建可逐段设置样式的富文本
+func (e *Evaluator) createText(stmt *CreateStatement) (*geometry.Text, error) {
+	if len(stmt.Parameters) > 0 {
+		if array, ok := stmt.Parameters[0].(*ArrayExpression); ok {
+			return e.createRichText(stmt, array)
+		}
+	}
+
+	text, size, pos, err := e.evalTextArgs(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建文本对象，内容中的 \n 表示换行
+	textObj := geometry.NewText(unescapeText(text), size)
+	textObj.SetSizeRange(e.textSizeRange())
+	if pos != nil {
+		textObj.SetPosition(pos.X, pos.Y)
+	}
+	return textObj, nil
+}
+
+// createMathTex 创建数学公式对象，内容按 LaTeX 数学模式排版
+func (e *Evaluator) createMathTex(stmt *CreateStatement) (*geometry.MathTex, error) {
+	source, size, pos, err := e.evalTextArgs(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := geometry.NewMathTex(source, size)
+	if err != nil {
+		return nil, err
+	}
+	obj.SetSizeRange(e.textSizeRange())
+	if pos != nil {
+		obj.MoveTo(*pos)
+	}
+	return obj, nil
+}
+
+// createTex 创建文本模式公式对象，只有 $...$ 之间按公式排版
+func (e *Evaluator) createTex(stmt *CreateStatement) (*geometry.MathTex, error) {
+	source, size, pos, err := e.evalTextArgs(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := geometry.NewTex(source, size)
+	if err != nil {
+		return nil, err
+	}
+	obj.SetSizeRange(e.textSizeRange())
+	if pos != nil {
+		obj.MoveTo(*pos)
+	}
+	return obj, nil
+}
+
+// createMarkdown 创建 Markdown 文本对象，内容中的 \n 表示换行
+func (e *Evaluator) createMarkdown(stmt *CreateStatement) (*geometry.Markdown, error) {
+	source, size, pos, err := e.evalTextArgs(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	md := geometry.NewMarkdown(unescapeText(source), size)
+	md.SetSizeRange(e.textSizeRange())
+	if pos != nil {
+		md.MoveTo(*pos)
+	}
+	return md, nil
+}
+
+// createRichText 由字符串数组创建富文本，每个元素是一个片段
+func (e *Evaluator) createRichText(stmt *CreateStatement, array *ArrayExpression) (*geometry.Text, error) {
+	if len(array.Elements) == 0 {
+		return nil, i18n.Errorfl(e.locale, "text.spans_empty")
+	}
+	spans := make([]string, len(array.Elements))
+	for i, element := range array.Elements {
+		value, err := e.evalExpression(element)
+		if err != nil {
+			return nil, i18n.Errorfl(e.locale, "text.content_parse", err)
+		}
+		span, ok := value.(string)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "text.span_type", i)
+		}
+		spans[i] = unescapeText(span)
+	}
+
+	size, pos, err := e.evalTextLayoutArgs(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	textObj := geometry.NewRichText(spans, size)
+	textObj.SetSizeRange(e.textSizeRange())
+	if pos != nil {
+		textObj.SetPosition(pos.X, pos.Y)
+	}
+	return textObj, nil
+}
+
+// createPath 由 SVG 路径数据创建路径，坐标使用场景坐标（y 轴向上），可选位置为路径中心
+func (e *Evaluator) createPath(stmt *CreateStatement) (*geometry.Path, error) {
+	if len(stmt.Parameters) < 1 {
+		return nil, i18n.Errorfl(e.locale, "path.data_required")
+	}
+	value, err := e.evalExpression(stmt.Parameters[0])
+	if err != nil {
+		return nil, err
+	}
+	data, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "path.data_required")
+	}
+
+	path, err := geometry.ParseSVGPath(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmt.Parameters) >= 2 {
+		if coord, ok := stmt.Parameters[1].(*CoordinateExpression); ok {
+			pos, err := e.evalCoordinate(coord)
+			if err != nil {
+				return nil, err
+			}
+			path.MoveTo(pos)
+		}
+	}
+	return path, nil
+}
+
+// createSVG 导入 SVG 文件：create svg <name> "<file>" [height] [(x, y)]，
+// 默认保持文件中的尺寸并居中于原点
+func (e *Evaluator) createSVG(stmt *CreateStatement) (*geometry.SVG, error) {
+	if len(stmt.Parameters) < 1 {
+		return nil, i18n.Errorfl(e.locale, "svg.file_required")
+	}
+	value, err := e.evalExpression(stmt.Parameters[0])
+	if err != nil {
+		return nil, err
+	}
+	name, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "svg.file_required")
+	}
+
+	height := 0.0
+	position := gmMath.Vector2{}
+	for _, param := range stmt.Parameters[1:] {
+		if coord, ok := param.(*CoordinateExpression); ok {
+			if position, err = e.evalCoordinate(coord); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		h, ok := value.(float64)
+		if !ok || h <= 0 {
+			return nil, i18n.Errorfl(e.locale, "svg.height_type", value)
+		}
+		height = h
+	}
+
+	filename := e.resolvePath(name)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, i18n.Errorfl(e.locale, "svg.read_failed", filename, err)
+	}
+	svg, err := geometry.ParseSVG(data)
+	if err != nil {
+		return nil, err
+	}
+	if height > 0 {
+		svg.SetHeight(height)
+	}
+	svg.MoveTo(position)
+	return svg, nil
+}
+
+// createImage 导入 PNG 或 JPEG 图像：create image <name> "<file>" [height | width height] [(x, y)]，
+// 只给出高度时按原图比例计算宽度，都不给出时使用像素尺寸
+func (e *Evaluator) createImage(stmt *CreateStatement) (*geometry.Image, error) {
+	if len(stmt.Parameters) < 1 {
+		return nil, i18n.Errorfl(e.locale, "image.file_required")
+	}
+	value, err := e.evalExpression(stmt.Parameters[0])
+	if err != nil {
+		return nil, err
+	}
+	name, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "image.file_required")
+	}
+
+	var sizes []float64
+	var position *gmMath.Vector2
+	for _, param := range stmt.Parameters[1:] {
+		if coord, ok := param.(*CoordinateExpression); ok {
+			pos, err := e.evalCoordinate(coord)
+			if err != nil {
+				return nil, err
+			}
+			position = &pos
+			continue
+		}
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		size, ok := value.(float64)
+		if !ok || size <= 0 || len(sizes) == 2 {
+			return nil, i18n.Errorfl(e.locale, "image.size_type", value)
+		}
+		sizes = append(sizes, size)
+	}
+
+	var width, height float64
+	switch len(sizes) {
+	case 1:
+		height = sizes[0]
+	case 2:
+		width, height = sizes[0], sizes[1]
+	}
+	img, err := geometry.LoadImage(e.resolvePath(name), width, height)
+	if err != nil {
+		return nil, err
+	}
+	if position != nil {
+		img.MoveTo(*position)
+	}
+	return img, nil
+}
+
+// setImageProperty 设置图像的采样方式或裁剪区域
+func (e *Evaluator) setImageProperty(obj interface{}, property string, value interface{}) error {
+	img, ok := obj.(*geometry.Image)
+	if !ok {
+		return e.newErrorCode(CodeUnsupportedProperty, "image.image_only", property)
+	}
+
+	switch property {
+	case "filter":
+		name, ok := value.(string)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "image.invalid_filter", value)
+		}
+		filter, err := geometry.ParseImageFilter(name)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		img.SetFilter(filter)
+	case "crop":
+		if name, ok := value.(string); ok && name == "none" {
+			img.SetCrop(image.Rectangle{})
+			return nil
+		}
+		array, ok := value.(*ArrayExpression)
+		if !ok || len(array.Elements) != 4 {
+			return e.newErrorCode(CodeInvalidArgument, "image.crop_type")
+		}
+		var numbers [4]int
+		for i, element := range array.Elements {
+			v, err := e.evalExpression(element)
+			if err != nil {
+				return err
+			}
+			n, ok := v.(float64)
+			if !ok || n < 0 || (i >= 2 && n <= 0) {
+				return e.newErrorCode(CodeInvalidArgument, "image.crop_type")
+			}
+			numbers[i] = int(math.Round(n))
+		}
+		crop := image.Rect(numbers[0], numbers[1], numbers[0]+numbers[2], numbers[1]+numbers[3])
+		if img.GetImageData() != nil && crop.Intersect(image.Rectangle{Max: img.GetImageData().Bounds().Size()}).Empty() {
+			return e.newErrorCode(CodeInvalidArgument, "image.crop_outside")
+		}
+		img.SetCrop(crop)
+	}
+	return nil
+}
+
+// resolvePath 解析脚本中引用的文件路径：相对路径优先相对于脚本所在目录，
+// 找不到时相对于当前工作目录
+func (e *Evaluator) resolvePath(name string) string {
+	if filepath.IsAbs(name) || e.fileName == "" {
+		return name
+	}
+	candidate := filepath.Join(filepath.Dir(e.fileName), name)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	return name
+}
+
+// evalCoordinate 计算坐标表达式的值
+func (e *Evaluator) evalCoordinate(coord *CoordinateExpression) (gmMath.Vector2, error) {
+	x, err := e.evalExpression(coord.X)
+	if err != nil {
+		return gmMath.Vector2{}, e.newError("eval.parse_x", err)
+	}
+	y, err := e.evalExpression(coord.Y)
+	if err != nil {
+		return gmMath.Vector2{}, e.newError("eval.parse_y", err)
+	}
+	xv, ok := x.(float64)
+	if !ok {
+		return gmMath.Vector2{}, e.newError("position.type", x)
+	}
+	yv, ok := y.(float64)
+	if !ok {
+		return gmMath.Vector2{}, e.newError("position.type", y)
+	}
+	return gmMath.Vector2{X: xv, Y: yv}, nil
+}
+
+// createOutline 把文本转换为字形轮廓路径。参数为已有文本对象的名称时，
+// 该文本被轮廓取代；否则按与 text 相同的参数先创建文本再转换。
+func (e *Evaluator) createOutline(stmt *CreateStatement) (*geometry.Path, error) {
+	if len(stmt.Parameters) == 1 {
+		if ident, ok := stmt.Parameters[0].(*Identifier); ok {
+			text, ok := e.objects[ident.Value].(*geometry.Text)
+			if !ok {
+				return nil, i18n.Errorfl(e.locale, "outline.text_required", ident.Value)
+			}
+			path, err := text.Outline()
+			if err != nil {
+				return nil, err
+			}
+			e.scene.Remove(text)
+			e.removeAnchor(text)
+			delete(e.objects, ident.Value)
+			return path, nil
+		}
+	}
+
+	text, err := e.createText(stmt)
+	if err != nil {
+		return nil, err
+	}
+	e.applyTheme(text)
+	return text.Outline()
+}
+
+// evalTextArgs 解析文本类对象的参数：内容、字号（数字或名称）和可选的位置
+func (e *Evaluator) evalTextArgs(stmt *CreateStatement) (string, float64, *gmMath.Vector2, error) {
+	// 检查参数数量：至少需要文本内容和字体大小
 	if len(stmt.Parameters) < 2 {
-		return nil, fmt.Errorf("文本对象需要至少2个参数：文本内容和字体大小")
+		return "", 0, nil, i18n.Errorfl(e.locale, "text.params_required")
 	}
 
 	// 解析文本内容
 	textVal, err := e.evalExpression(stmt.Parameters[0])
 	if err != nil {
-		return nil, fmt.Errorf("解析文本内容失败: %v", err)
+		return "", 0, nil, i18n.Errorfl(e.locale, "text.content_parse", err)
 	}
 	text, ok := textVal.(string)
 	if !ok {
-		return nil, fmt.Errorf("文本内容必须是字符串")
+		return "", 0, nil, i18n.Errorfl(e.locale, "text.content_type")
+	}
+
+	size, pos, err := e.evalTextLayoutArgs(stmt)
+	return text, size, pos, err
+}
+
+// evalTextLayoutArgs 解析文本内容之后的参数：字号（数字或名称）和可选的位置
+func (e *Evaluator) evalTextLayoutArgs(stmt *CreateStatement) (float64, *gmMath.Vector2, error) {
+	if len(stmt.Parameters) < 2 {
+		return 0, nil, i18n.Errorfl(e.locale, "text.params_required")
 	}
 
 	// 解析字体大小
 	sizeVal, err := e.evalExpression(stmt.Parameters[1])
 	if err != nil {
-		return nil, fmt.Errorf("解析字体大小失败: %v", err)
+		return 0, nil, i18n.Errorfl(e.locale, "text.size_parse", err)
 	}
 
 	var size float64
@@ -768,74 +2166,497 @@ func (e *Evaluator) createText(stmt *CreateStatement) (*geometry.Text, error) {
 		}
 
 		if !sizeFound {
-			return nil, fmt.Errorf("未知字体大小名称: %s", s)
+			return 0, nil, i18n.Errorfl(e.locale, "text.unknown_size_name", s)
+		}
+	default:
+		return 0, nil, i18n.Errorfl(e.locale, "text.size_type")
+	}
+
+	if size <= 0 {
+		return 0, nil, i18n.Errorfl(e.locale, "text.size_positive")
+	}
+
+	// 如果提供了位置坐标（第3个参数），则设置位置
+	if len(stmt.Parameters) >= 3 {
+		if coord, ok := stmt.Parameters[2].(*CoordinateExpression); ok {
+			x, err := e.evalExpression(coord.X)
+			if err != nil {
+				return 0, nil, e.newError("eval.parse_x", err)
+			}
+			y, err := e.evalExpression(coord.Y)
+			if err != nil {
+				return 0, nil, e.newError("eval.parse_y", err)
+			}
+			return size, &gmMath.Vector2{X: x.(float64), Y: y.(float64)}, nil
+		}
+	}
+
+	return size, nil, nil
+}
+
+// evalSetStatement 执行设置语句
+func (e *Evaluator) evalSetStatement(stmt *SetStatement) error {
+	obj, exists := e.objects[stmt.Object.Value]
+	if !exists {
+		return e.unknownObjectError(stmt.Object.Value)
+	}
+
+	value, err := e.evalExpression(stmt.Value)
+	if err != nil {
+		return e.newError("eval.set_value_failed",
+			stmt.Object.Value, stmt.Property.Literal, err)
+	}
+
+	if stmt.Span != nil {
+		return e.setSpanProperty(obj, stmt, value)
+	}
+
+	switch stmt.Property.Type {
+	case TOKEN_COLOR_PROP:
+		return e.setColor(obj, value)
+	case TOKEN_SIZE_PROP:
+		return e.setSize(obj, value)
+	case TOKEN_POSITION_PROP:
+		return e.setPosition(obj, value)
+	case TOKEN_OPACITY_PROP:
+		return e.setOpacity(obj, value)
+	case TOKEN_WIDTH_PROP:
+		return e.setWidth(obj, value)
+	case TOKEN_HEIGHT_PROP:
+		return e.setHeight(obj, value)
+	case TOKEN_VERTEX_PROP:
+		return e.setVertex(obj, stmt.Property.Literal, value)
+	case TOKEN_VERTICES_PROP:
+		return e.setVertices(obj, value)
+	case TOKEN_IDENT:
+		return e.setNamedProperty(obj, stmt.Property.Literal, value)
+	default:
+		return i18n.Errorfl(e.locale, "eval.unknown_property", stmt.Property.Literal)
+	}
+}
+
+// setNamedProperty 设置没有专用关键字的属性
+func (e *Evaluator) setNamedProperty(obj interface{}, property string, value interface{}) error {
+	if name, axes, ok := axesProperty(property); ok {
+		return e.setAxesProperty(obj, property, name, axes, value)
+	}
+	switch property {
+	case "font", "weight", "style":
+		if md, ok := obj.(*geometry.Markdown); ok && property == "font" {
+			return e.setMarkdownProperty(md, property, value)
+		}
+		text, ok := obj.(*geometry.Text)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "font.text_only", property)
+		}
+		return e.setFontProperty(text, property, value)
+	case "stroke_width":
+		width, ok := value.(float64)
+		if !ok || width < 0 {
+			return e.newErrorCode(CodeInvalidArgument, "stroke_width.type")
+		}
+		mobject, ok := obj.(core.Mobject)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "eval.unknown_property", property)
+		}
+		mobject.SetStrokeWidth(width)
+		return nil
+	case "filter", "crop":
+		return e.setImageProperty(obj, property, value)
+	case "resolution":
+		number, _ := value.(float64)
+		resolution, err := e.curveResolution(number)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		switch o := obj.(type) {
+		case *geometry.ParametricCurve:
+			o.SetResolution(resolution)
+		case *geometry.ContourPlot:
+			o.SetResolution(resolution)
+		default:
+			return e.newErrorCode(CodeUnsupportedProperty, "curve.curve_only", property)
+		}
+		return nil
+	case "colormap":
+		switch obj.(type) {
+		case *geometry.ContourPlot, *geometry.VectorField, *geometry.StreamLines:
+		default:
+			return e.newErrorCode(CodeUnsupportedProperty, "colormap.unsupported", property)
+		}
+		colormap, err := e.colormapValue(value)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		switch o := obj.(type) {
+		case *geometry.ContourPlot:
+			o.SetColormap(colormap)
+		case *geometry.VectorField:
+			o.SetColormap(colormap)
+		case *geometry.StreamLines:
+			o.SetColormap(colormap)
+		}
+		return nil
+	case "n", "method":
+		sum, ok := obj.(*geometry.RiemannSum)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "riemann.only", property)
+		}
+		if property == "n" {
+			n, err := e.riemannCount(value)
+			if err != nil {
+				return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+			}
+			sum.SetN(n)
+			return nil
+		}
+		method, err := e.riemannMethod(value)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		sum.SetMethod(method)
+		return nil
+	case "x", "dx", "length", "label":
+		tracker, ok := obj.(*geometry.GraphTracker)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "tracker.only", property)
+		}
+		if err := e.setTrackerProperty(tracker, property, value); err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		return nil
+	case "flow_speed":
+		speed, ok := value.(float64)
+		if !ok || speed < 0 {
+			return e.newErrorCode(CodeInvalidArgument, "field.flow_speed_type")
+		}
+		switch o := obj.(type) {
+		case *geometry.VectorField:
+			o.SetFlowSpeed(speed)
+		case *geometry.StreamLines:
+			o.SetFlowSpeed(speed)
+		default:
+			return e.newErrorCode(CodeUnsupportedProperty, "field.flow_only", property)
+		}
+		return nil
+	case "max_width", "align", "line_spacing", "anchor":
+		if md, ok := obj.(*geometry.Markdown); ok && property != "align" {
+			return e.setMarkdownProperty(md, property, value)
+		}
+		text, ok := obj.(*geometry.Text)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "font.text_only", property)
+		}
+		return e.setTextLayoutProperty(text, property, value)
+	default:
+		return e.newErrorCode(CodeUnsupportedProperty, "eval.unknown_property", property)
+	}
+}
+
+// colormapValue 解析色图名称，none 表示不使用色图
+func (e *Evaluator) colormapValue(value interface{}) (*colors.Colormap, error) {
+	name, ok := value.(string)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "colormap.unknown", value, strings.Join(colors.ColormapNames(), ", "))
+	}
+	if name == "none" {
+		return nil, nil
+	}
+	colormap, ok := colors.ColormapByName(name)
+	if !ok {
+		return nil, i18n.Errorfl(e.locale, "colormap.unknown", name, strings.Join(colors.ColormapNames(), ", "))
+	}
+	return &colormap, nil
+}
+
+// setTextLayoutProperty 设置文本的折行宽度、对齐方式、行距或锚点
+func (e *Evaluator) setTextLayoutProperty(text *geometry.Text, property string, value interface{}) error {
+	switch property {
+	case "max_width":
+		width, ok := value.(float64)
+		if !ok || width < 0 {
+			return e.newErrorCode(CodeInvalidArgument, "text.max_width_type")
+		}
+		text.SetMaxWidth(width)
+	case "line_spacing":
+		spacing, ok := value.(float64)
+		if !ok || spacing <= 0 {
+			return e.newErrorCode(CodeInvalidArgument, "text.line_spacing_type")
+		}
+		text.SetLineSpacing(spacing)
+	case "align":
+		name, _ := value.(string)
+		align, err := geometry.ParseTextAlign(name)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		text.SetAlign(align)
+	case "anchor":
+		name, _ := value.(string)
+		anchor, err := geometry.ParseTextAnchor(name)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		text.SetAnchor(anchor)
+	}
+	return nil
+}
+
+// setMarkdownProperty 设置 Markdown 文本的正文字体、折行宽度、行距或锚点，
+// 字重和字形由 Markdown 标记决定
+func (e *Evaluator) setMarkdownProperty(md *geometry.Markdown, property string, value interface{}) error {
+	switch property {
+	case "font":
+		family, err := e.fontFamily(value)
+		if err != nil {
+			return err
+		}
+		md.SetFont(family)
+	case "max_width":
+		width, ok := value.(float64)
+		if !ok || width < 0 {
+			return e.newErrorCode(CodeInvalidArgument, "text.max_width_type")
+		}
+		md.SetMaxWidth(width)
+	case "line_spacing":
+		spacing, ok := value.(float64)
+		if !ok || spacing <= 0 {
+			return e.newErrorCode(CodeInvalidArgument, "text.line_spacing_type")
+		}
+		md.SetLineSpacing(spacing)
+	case "anchor":
+		name, _ := value.(string)
+		anchor, err := geometry.ParseTextAnchor(name)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		md.SetAnchor(anchor)
+	}
+	return nil
+}
+
+// fontFamily 检查字体族名称是否已注册
+func (e *Evaluator) fontFamily(value interface{}) (string, error) {
+	family, ok := value.(string)
+	if !ok {
+		return "", e.newErrorCode(CodeInvalidArgument, "font.family_type")
+	}
+	registry := fonts.Default()
+	if !registry.HasFamily(family) {
+		return "", e.newErrorCode(CodeInvalidArgument, "font.unknown_family",
+			family, strings.Join(registry.Families(), ", "))
+	}
+	return family, nil
+}
+
+// setFontProperty 设置文本的字体族、字重或字形样式
+func (e *Evaluator) setFontProperty(text *geometry.Text, property string, value interface{}) error {
+	switch property {
+	case "font":
+		family, err := e.fontFamily(value)
+		if err != nil {
+			return err
+		}
+		text.SetFont(family)
+	case "weight":
+		weight, err := e.parseWeight(value)
+		if err != nil {
+			return err
+		}
+		text.SetWeight(weight)
+	case "style":
+		name, ok := value.(string)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "font.invalid_style", fmt.Sprint(value))
+		}
+		style, err := fonts.ParseStyle(name)
+		if err != nil {
+			return &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+		}
+		text.SetStyle(style)
+	}
+	return nil
+}
+
+// parseWeight 解析字重：数字 100-900 或名称
+func (e *Evaluator) parseWeight(value interface{}) (fonts.Weight, error) {
+	var weight fonts.Weight
+	var err error
+	switch v := value.(type) {
+	case float64:
+		weight, err = fonts.WeightFromNumber(v)
+	case string:
+		weight, err = fonts.ParseWeight(v)
+	default:
+		return 0, e.newErrorCode(CodeInvalidArgument, "font.invalid_weight", fmt.Sprint(v))
+	}
+	if err != nil {
+		return 0, &RuntimeError{Position: e.position(), locale: e.locale, Code: CodeInvalidArgument, Err: err}
+	}
+	return weight, nil
+}
+
+// setSpanProperty 设置富文本片段的颜色、字重、下划线或背景高亮
+func (e *Evaluator) setSpanProperty(obj interface{}, stmt *SetStatement, value interface{}) error {
+	text, ok := obj.(*geometry.Text)
+	if !ok || text.SpanCount() == 0 {
+		return e.newErrorCode(CodeUnsupportedProperty, "text.no_spans", stmt.Object.Value)
+	}
+
+	indexVal, err := e.evalExpression(stmt.Span)
+	if err != nil {
+		return err
+	}
+	index, ok := indexVal.(float64)
+	if !ok || index != math.Trunc(index) || index < 0 || int(index) >= text.SpanCount() {
+		return e.newErrorCode(CodeInvalidArgument, "text.span_index", fmt.Sprint(indexVal), text.SpanCount()-1)
+	}
+	i := int(index)
+
+	switch stmt.Property.Literal {
+	case "color":
+		c, err := e.parseColor(value)
+		if err != nil {
+			return err
+		}
+		text.SetSpanColor(i, c)
+	case "weight":
+		weight, err := e.parseWeight(value)
+		if err != nil {
+			return err
+		}
+		text.SetSpanWeight(i, weight)
+	case "underline":
+		on, ok := parseSwitch(value)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "text.underline_type", fmt.Sprint(value))
+		}
+		text.SetSpanUnderline(i, on)
+	case "highlight":
+		if name, ok := value.(string); ok && strings.EqualFold(name, "none") {
+			text.SetSpanHighlight(i, nil)
+			return nil
 		}
+		c, err := e.parseColor(value)
+		if err != nil {
+			return err
+		}
+		text.SetSpanHighlight(i, c)
 	default:
-		return nil, fmt.Errorf("字体大小必须是数字或字体大小名称")
-	}
-
-	if size <= 0 {
-		return nil, fmt.Errorf("字体大小必须大于0")
+		return e.newErrorCode(CodeUnsupportedProperty, "text.span_property", stmt.Property.Literal)
 	}
+	return nil
+}
 
-	// 创建文本对象
-	textObj := geometry.NewText(text, size)
+// axesProperty 解析坐标系的刻度属性：spacing、tick_step、tick_format、tick_pi、hide_zero、
+// tick_rotation、tick_size 作用于两条轴，加 x_、y_ 前缀时只作用于一条轴；x_label、y_label 为坐标轴标题
+func axesProperty(property string) (string, []geometry.Axis, bool) {
+	axes := []geometry.Axis{geometry.AxisX, geometry.AxisY}
+	name := property
+	switch {
+	case strings.HasPrefix(property, "x_"):
+		axes, name = axes[:1], property[2:]
+	case strings.HasPrefix(property, "y_"):
+		axes, name = axes[1:], property[2:]
+	}
+	switch name {
+	case "spacing", "tick_step", "tick_format", "tick_pi", "hide_zero", "tick_rotation", "tick_size":
+		return name, axes, true
+	case "label":
+		return name, axes, len(axes) == 1
+	}
+	return "", nil, false
+}
 
-	// 如果提供了位置坐标（第3个参数），则设置位置
-	if len(stmt.Parameters) >= 3 {
-		if coord, ok := stmt.Parameters[2].(*CoordinateExpression); ok {
-			x, err := e.evalExpression(coord.X)
-			if err != nil {
-				return nil, fmt.Errorf("解析X坐标失败: %v", err)
+// setAxesProperty 设置坐标系的网格间距、刻度标签和坐标轴标题
+func (e *Evaluator) setAxesProperty(obj interface{}, property, name string, axes []geometry.Axis, value interface{}) error {
+	cs, ok := obj.(*geometry.CoordinateSystem)
+	if !ok {
+		return e.newErrorCode(CodeUnsupportedProperty, "axes.only", property)
+	}
+	number, isNumber := value.(float64)
+	text, isText := value.(string)
+	for _, axis := range axes {
+		switch name {
+		case "spacing", "tick_size":
+			if !isNumber || number <= 0 {
+				return e.newErrorCode(CodeInvalidArgument, "axes.positive_type", property, value)
 			}
-			y, err := e.evalExpression(coord.Y)
-			if err != nil {
-				return nil, fmt.Errorf("解析Y坐标失败: %v", err)
+			if name == "spacing" {
+				cs.SetAxisSpacing(axis, number)
+			} else {
+				cs.SetTickSize(axis, number)
+			}
+		case "tick_step":
+			if !isNumber || number < 0 {
+				return e.newErrorCode(CodeInvalidArgument, "axes.tick_step_type", property, value)
+			}
+			cs.SetTickStep(axis, number)
+		case "tick_rotation":
+			if !isNumber {
+				return e.newErrorCode(CodeInvalidArgument, "axes.rotation_type", property, value)
+			}
+			cs.SetTickRotation(axis, number*math.Pi/180)
+		case "tick_format":
+			if !isText || (text != "" && strings.Contains(fmt.Sprintf(text, 1.0), "%!")) {
+				return e.newErrorCode(CodeInvalidArgument, "axes.tick_format", value)
+			}
+			cs.SetTickFormat(axis, text)
+		case "tick_pi", "hide_zero":
+			on, ok := parseSwitch(value)
+			if !ok {
+				return e.newErrorCode(CodeInvalidArgument, "axes.switch_type", property, value)
+			}
+			if name == "tick_pi" {
+				cs.SetPiTicks(axis, on)
+			} else {
+				cs.SetHideZero(axis, on)
+			}
+		case "label":
+			if !isText {
+				return e.newErrorCode(CodeInvalidArgument, "axes.label_type", property, value)
 			}
-			textObj.MoveTo(gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+			cs.SetAxisTitle(axis, text)
 		}
 	}
-
-	return textObj, nil
+	return nil
 }
 
-// evalSetStatement 执行设置语句
-func (e *Evaluator) evalSetStatement(stmt *SetStatement) error {
-	obj, exists := e.objects[stmt.Object.Value]
-	if !exists {
-		return e.newError("对象 '%s' 不存在", stmt.Object.Value)
+// parseSwitch 解析开关值：true/false、on/off、yes/no 或 1/0
+func parseSwitch(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, true
+		}
+	case string:
+		switch strings.ToLower(v) {
+		case "true", "on", "yes":
+			return true, true
+		case "false", "off", "no":
+			return false, true
+		}
 	}
+	return false, false
+}
 
-	value, err := e.evalExpression(stmt.Value)
+// setColor 设置颜色
+func (e *Evaluator) setColor(obj interface{}, value interface{}) error {
+	c, err := e.parseColor(value)
 	if err != nil {
-		return e.newError("设置属性 '%s.%s' 时解析值失败: %v",
-			stmt.Object.Value, stmt.Property.Literal, err)
+		return err
 	}
 
-	switch stmt.Property.Type {
-	case TOKEN_COLOR_PROP:
-		return e.setColor(obj, value)
-	case TOKEN_SIZE_PROP:
-		return e.setSize(obj, value)
-	case TOKEN_POSITION_PROP:
-		return e.setPosition(obj, value)
-	case TOKEN_OPACITY_PROP:
-		return e.setOpacity(obj, value)
-	case TOKEN_WIDTH_PROP:
-		return e.setWidth(obj, value)
-	case TOKEN_HEIGHT_PROP:
-		return e.setHeight(obj, value)
-	case TOKEN_VERTEX_PROP:
-		return e.setVertex(obj, stmt.Property.Literal, value)
-	case TOKEN_VERTICES_PROP:
-		return e.setVertices(obj, value)
-	default:
-		return fmt.Errorf("unknown property: %s", stmt.Property.Literal)
+	if mobject, ok := obj.(interface{ SetColor(color.Color) }); ok {
+		mobject.SetColor(c)
+		return nil
 	}
+
+	return e.newErrorCode(CodeUnsupportedProperty, "color.unsupported")
 }
 
-// setColor 设置颜色
-func (e *Evaluator) setColor(obj interface{}, value interface{}) error {
+// parseColor 解析颜色值：#RRGGBB 或颜色名称
+func (e *Evaluator) parseColor(value interface{}) (color.RGBA, error) {
 	var c color.RGBA
 
 	switch v := value.(type) {
@@ -936,53 +2757,136 @@ func (e *Evaluator) setColor(obj interface{}, value interface{}) error {
 				case "lightpurple":
 					c = colors.LightPurple
 				default:
-					return e.newError("未知颜色名: %s", v)
+					return c, e.newErrorCode(CodeUnknownColor, "color.unknown_name", v)
 				}
 			}
 		}
 	default:
-		return e.newError("颜色必须是字符串（如 '#FF0000' 或颜色名），得到的是 %T", value)
-	}
-
-	if mobject, ok := obj.(interface{ SetColor(color.Color) }); ok {
-		mobject.SetColor(c)
-		return nil
+		return c, e.newError("color.type", value)
 	}
-
-	return e.newError("对象不支持颜色属性")
+	return c, nil
 }
 
 // setPosition 设置位置
 func (e *Evaluator) setPosition(obj interface{}, value interface{}) error {
+	if point, ok := value.(*axesPoint); ok {
+		return e.anchorToAxes(obj, *point)
+	}
 	coord, ok := value.(*CoordinateExpression)
 	if !ok {
-		return e.newError("位置必须是坐标形式 (x, y)，得到的是 %T", value)
+		return e.newError("position.type", value)
 	}
+	e.removeAnchor(obj)
 
 	x, err := e.evalExpression(coord.X)
 	if err != nil {
-		return e.newError("解析X坐标失败: %v", err)
+		return e.newError("eval.parse_x", err)
 	}
 	y, err := e.evalExpression(coord.Y)
 	if err != nil {
-		return e.newError("解析Y坐标失败: %v", err)
+		return e.newError("eval.parse_y", err)
 	}
 
+	pos := gmMath.Vector2{X: x.(float64), Y: y.(float64)}
+	if text, ok := obj.(*geometry.Text); ok {
+		text.SetPosition(pos.X, pos.Y)
+		return nil
+	}
 	if mobject, ok := obj.(interface {
 		MoveTo(gmMath.Vector2) core.Mobject
 	}); ok {
-		mobject.MoveTo(gmMath.Vector2{X: x.(float64), Y: y.(float64)})
+		mobject.MoveTo(pos)
 		return nil
 	}
 
-	return fmt.Errorf("object does not support position property")
+	return e.newErrorCode(CodeUnsupportedProperty, "position.unsupported")
+}
+
+// anchorToAxes 把对象放到坐标系中的点上，之后坐标系移动、缩放或做动画时对象跟着移动
+func (e *Evaluator) anchorToAxes(obj interface{}, point axesPoint) error {
+	mobject, ok := obj.(core.Mobject)
+	if !ok {
+		return e.newErrorCode(CodeUnsupportedProperty, "position.unsupported")
+	}
+	if mobject == core.Mobject(point.axes) {
+		return e.newErrorCode(CodeInvalidArgument, "position.self_axes")
+	}
+	e.removeAnchor(obj)
+	e.anchors = append(e.anchors, axesAnchor{object: mobject, point: point})
+	placeAt(mobject, point.axes.CoordinateToPoint(point.coord))
+	return nil
+}
+
+// placeAt 把对象放到指定位置：文本让锚点位于该处，其他对象移动中心
+func placeAt(obj core.Mobject, pos gmMath.Vector2) {
+	if text, ok := obj.(*geometry.Text); ok {
+		text.SetPosition(pos.X, pos.Y)
+		return
+	}
+	obj.MoveTo(pos)
+}
+
+// removeAnchor 解除对象与坐标系的绑定
+func (e *Evaluator) removeAnchor(obj interface{}) {
+	for i, anchor := range e.anchors {
+		if anchor.object == obj {
+			e.anchors = append(e.anchors[:i], e.anchors[i+1:]...)
+			return
+		}
+	}
+}
+
+// followAxes 在每帧渲染前调用：坐标系移动或缩放后，重新生成画在它上面的函数图像、面积、向量场等，
+// 并把用坐标系坐标定位的对象移到坐标系当前状态下的位置
+func (e *Evaluator) followAxes() {
+	for _, obj := range e.objects {
+		axes := boundAxes(obj)
+		if axes == nil || e.axesRevisions[obj] == axes.Revision() {
+			continue
+		}
+		switch o := obj.(type) {
+		case *geometry.FunctionGraph:
+			o.Generate()
+		case *geometry.ParametricCurve:
+			o.Generate()
+		case *geometry.ContourPlot:
+			o.Generate()
+		case *geometry.VectorField:
+			o.Generate()
+		case *geometry.StreamLines:
+			o.Generate()
+		case *geometry.Area:
+			o.Generate()
+		case *geometry.RiemannSum:
+			o.Generate()
+		case *geometry.GraphTracker:
+			o.Generate()
+		}
+		e.axesRevisions[obj] = axes.Revision()
+	}
+	for _, anchor := range e.anchors {
+		placeAt(anchor.object, anchor.point.axes.CoordinateToPoint(anchor.point.coord))
+	}
+}
+
+// axesBound 画在坐标系上的对象
+type axesBound interface {
+	Axes() *geometry.CoordinateSystem
+}
+
+// boundAxes 返回对象所在的坐标系，不依赖坐标系的对象返回 nil
+func boundAxes(obj interface{}) *geometry.CoordinateSystem {
+	if bound, ok := obj.(axesBound); ok {
+		return bound.Axes()
+	}
+	return nil
 }
 
 // setOpacity 设置透明度
 func (e *Evaluator) setOpacity(obj interface{}, value interface{}) error {
 	opacity, ok := value.(float64)
 	if !ok {
-		return fmt.Errorf("opacity must be a number")
+		return e.newErrorCode(CodeInvalidArgument, "opacity.type")
 	}
 
 	if mobject, ok := obj.(interface{ SetFillOpacity(float64) }); ok {
@@ -990,14 +2894,14 @@ func (e *Evaluator) setOpacity(obj interface{}, value interface{}) error {
 		return nil
 	}
 
-	return fmt.Errorf("object does not support opacity property")
+	return e.newErrorCode(CodeUnsupportedProperty, "opacity.unsupported")
 }
 
 // setSize, setWidth, setHeight 等其他属性设置方法...
 func (e *Evaluator) setSize(obj interface{}, value interface{}) error {
 	size, ok := value.(float64)
 	if !ok {
-		return fmt.Errorf("size must be a number")
+		return e.newErrorCode(CodeInvalidArgument, "size.type")
 	}
 
 	if circle, ok := obj.(*geometry.Circle); ok {
@@ -1005,24 +2909,40 @@ func (e *Evaluator) setSize(obj interface{}, value interface{}) error {
 		return nil
 	}
 
-	return fmt.Errorf("object does not support size property")
+	return e.newErrorCode(CodeUnsupportedProperty, "size.unsupported")
 }
 
 func (e *Evaluator) setWidth(obj interface{}, value interface{}) error {
-	// 实现宽度设置
-	return fmt.Errorf("width property not yet implemented")
+	if img, ok := obj.(*geometry.Image); ok {
+		width, ok := value.(float64)
+		if !ok || width <= 0 {
+			return e.newErrorCode(CodeInvalidArgument, "image.size_type", value)
+		}
+		_, height := img.GetDimensions()
+		img.SetSize(width, height)
+		return nil
+	}
+	return e.newErrorCode(CodeUnsupportedProperty, "width.not_implemented")
 }
 
 func (e *Evaluator) setHeight(obj interface{}, value interface{}) error {
-	// 实现高度设置
-	return fmt.Errorf("height property not yet implemented")
+	if img, ok := obj.(*geometry.Image); ok {
+		height, ok := value.(float64)
+		if !ok || height <= 0 {
+			return e.newErrorCode(CodeInvalidArgument, "image.size_type", value)
+		}
+		width, _ := img.GetDimensions()
+		img.SetSize(width, height)
+		return nil
+	}
+	return e.newErrorCode(CodeUnsupportedProperty, "height.not_implemented")
 }
 
 // setVertex 设置三角形的单个顶点
 func (e *Evaluator) setVertex(obj interface{}, property string, value interface{}) error {
 	triangle, ok := obj.(*geometry.Triangle)
 	if !ok {
-		return e.newError("vertex properties are only supported for triangle objects")
+		return e.newErrorCode(CodeUnsupportedProperty, "vertex.triangle_only")
 	}
 
 	// 确定顶点索引
@@ -1035,22 +2955,22 @@ func (e *Evaluator) setVertex(obj interface{}, property string, value interface{
 	case "vertex3":
 		vertexIndex = 2
 	default:
-		return e.newError("unknown vertex property: %s. Use vertex1, vertex2, or vertex3", property)
+		return e.newErrorCode(CodeUnsupportedProperty, "vertex.unknown_property", property)
 	}
 
 	// 解析坐标值
 	coord, ok := value.(*CoordinateExpression)
 	if !ok {
-		return e.newError("vertex must be a coordinate (x, y), got %T", value)
+		return e.newErrorCode(CodeInvalidArgument, "vertex.type", value)
 	}
 
 	x, err := e.evalExpression(coord.X)
 	if err != nil {
-		return e.newError("invalid vertex X coordinate: %v", err)
+		return e.newErrorCode(CodeInvalidArgument, "vertex.value_invalid_x", err)
 	}
 	y, err := e.evalExpression(coord.Y)
 	if err != nil {
-		return e.newError("invalid vertex Y coordinate: %v", err)
+		return e.newErrorCode(CodeInvalidArgument, "vertex.value_invalid_y", err)
 	}
 
 	// 设置顶点
@@ -1064,33 +2984,33 @@ func (e *Evaluator) setVertex(obj interface{}, property string, value interface{
 func (e *Evaluator) setVertices(obj interface{}, value interface{}) error {
 	triangle, ok := obj.(*geometry.Triangle)
 	if !ok {
-		return e.newError("vertices property is only supported for triangle objects")
+		return e.newErrorCode(CodeUnsupportedProperty, "vertices.triangle_only")
 	}
 
 	// 解析顶点数组
 	array, ok := value.(*ArrayExpression)
 	if !ok {
-		return e.newError("vertices must be an array of coordinates [(x1,y1), (x2,y2), (x3,y3)], got %T", value)
+		return e.newErrorCode(CodeInvalidArgument, "vertices.type", value)
 	}
 
 	if len(array.Elements) != 3 {
-		return e.newError("triangle vertices array must contain exactly 3 coordinates, got %d", len(array.Elements))
+		return e.newErrorCode(CodeInvalidArgument, "vertices.count", len(array.Elements))
 	}
 
 	var vertices [3]gmMath.Vector2
 	for i, element := range array.Elements {
 		coord, ok := element.(*CoordinateExpression)
 		if !ok {
-			return e.newError("vertex %d must be a coordinate (x,y), got %T", i+1, element)
+			return e.newErrorCode(CodeInvalidArgument, "vertices.element_type", i+1, element)
 		}
 
 		x, err := e.evalExpression(coord.X)
 		if err != nil {
-			return e.newError("invalid vertex %d X coordinate: %v", i+1, err)
+			return e.newErrorCode(CodeInvalidArgument, "vertex.invalid_x", i+1, err)
 		}
 		y, err := e.evalExpression(coord.Y)
 		if err != nil {
-			return e.newError("invalid vertex %d Y coordinate: %v", i+1, err)
+			return e.newErrorCode(CodeInvalidArgument, "vertex.invalid_y", i+1, err)
 		}
 
 		vertices[i] = gmMath.Vector2{X: x.(float64), Y: y.(float64)}
@@ -1102,21 +3022,47 @@ func (e *Evaluator) setVertices(obj interface{}, value interface{}) error {
 	return nil
 }
 
+// numericProperty 返回可以用 animate value 连续改变的数值属性的读写函数
+func numericProperty(obj core.Mobject, property string) (get func() float64, set func(float64), ok bool) {
+	switch property {
+	case "opacity":
+		return obj.GetFillOpacity, obj.SetFillOpacity, true
+	case "stroke_width":
+		return obj.GetStrokeWidth, obj.SetStrokeWidth, true
+	}
+
+	if sum, isSum := obj.(*geometry.RiemannSum); isSum && property == "n" {
+		return func() float64 { return float64(sum.N()) },
+			func(v float64) { sum.SetN(int(math.Round(v))) }, true
+	}
+	if tracker, isTracker := obj.(*geometry.GraphTracker); isTracker {
+		switch property {
+		case "x":
+			return tracker.X, func(v float64) { tracker.SetX(v) }, true
+		case "dx":
+			return tracker.DX, func(v float64) { tracker.SetDX(v) }, true
+		case "length":
+			return tracker.Length, func(v float64) { tracker.SetLength(v) }, true
+		}
+	}
+	return nil, nil, false
+}
+
 // evalAnimateStatement 执行动画语句
 func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
 	objName := stmt.Object.Value
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return e.newErrorCode(CodeUnsupportedProperty, "anim.not_animatable", objName)
 	}
 
 	durationVal, err := e.evalExpression(stmt.Duration)
@@ -1129,11 +3075,11 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 	switch stmt.Animation.Type {
 	case TOKEN_MOVE:
 		if len(stmt.Parameters) < 1 {
-			return fmt.Errorf("move animation requires target position")
+			return e.newErrorCode(CodeInvalidArgument, "anim.move_target")
 		}
 		coordExpr, ok := stmt.Parameters[0].(*CoordinateExpression)
 		if !ok {
-			return fmt.Errorf("move parameter must be coordinate")
+			return e.newErrorCode(CodeInvalidArgument, "anim.move_type")
 		}
 		xVal, err := e.evalExpression(coordExpr.X)
 		if err != nil {
@@ -1147,7 +3093,7 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 		anim = animation.NewMoveToAnimation(mobj, endPos, duration)
 	case TOKEN_SCALE:
 		if len(stmt.Parameters) < 1 {
-			return fmt.Errorf("scale animation requires scale factor")
+			return e.newErrorCode(CodeInvalidArgument, "anim.scale_factor")
 		}
 		scaleVal, err := e.evalExpression(stmt.Parameters[0])
 		if err != nil {
@@ -1156,7 +3102,7 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 		anim = animation.NewScaleAnimation(mobj, scaleVal.(float64), duration)
 	case TOKEN_ROTATE:
 		if len(stmt.Parameters) < 1 {
-			return fmt.Errorf("rotate animation requires angle")
+			return e.newErrorCode(CodeInvalidArgument, "anim.rotate_angle")
 		}
 		angleVal, err := e.evalExpression(stmt.Parameters[0])
 		if err != nil {
@@ -1171,12 +3117,12 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 		anim = animation.NewBouncingBallAnimation(mobj, duration)
 	case TOKEN_COLOR:
 		if len(stmt.Parameters) < 1 {
-			return fmt.Errorf("color animation requires target color")
+			return e.newErrorCode(CodeInvalidArgument, "anim.color_target")
 		}
 		// 解析颜色参数
 		colorExpr, ok := stmt.Parameters[0].(*StringLiteral)
 		if !ok {
-			return fmt.Errorf("color parameter must be a string")
+			return e.newErrorCode(CodeInvalidArgument, "anim.color_type")
 		}
 		colorStr := colorExpr.Value
 		var endColor color.RGBA
@@ -1193,18 +3139,31 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 		anim = animation.NewColorAnimation(mobj, endColor, duration)
 	case TOKEN_PATH:
 		if len(stmt.Parameters) < 1 {
-			return fmt.Errorf("path animation requires path points array")
+			return e.newErrorCode(CodeInvalidArgument, "anim.path_points")
+		}
+		// 以路径或曲线对象作为运动路径
+		if ident, ok := stmt.Parameters[0].(*Identifier); ok {
+			target, exists := e.objects[ident.Value]
+			if !exists {
+				return e.unknownObjectError(ident.Value)
+			}
+			path, ok := target.(interface{ Polyline() []gmMath.Vector2 })
+			if !ok {
+				return e.newErrorCode(CodeInvalidArgument, "anim.path_target", ident.Value)
+			}
+			anim = animation.NewPathAnimation(mobj, path.Polyline(), duration)
+			break
 		}
 		// 解析路径点数组
 		arrayExpr, ok := stmt.Parameters[0].(*ArrayExpression)
 		if !ok {
-			return fmt.Errorf("path parameter must be an array of coordinates")
+			return e.newErrorCode(CodeInvalidArgument, "anim.path_type")
 		}
 		var pathPoints []gmMath.Vector2
 		for _, element := range arrayExpr.Elements {
 			coordExpr, ok := element.(*CoordinateExpression)
 			if !ok {
-				return fmt.Errorf("path points must be coordinates")
+				return e.newErrorCode(CodeInvalidArgument, "anim.path_point_type")
 			}
 			xVal, err := e.evalExpression(coordExpr.X)
 			if err != nil {
@@ -1217,14 +3176,41 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 			pathPoints = append(pathPoints, gmMath.NewVector2(xVal.(float64), yVal.(float64)))
 		}
 		anim = animation.NewPathAnimation(mobj, pathPoints, duration)
+	case TOKEN_FLOW:
+		flowing, ok := obj.(animation.Flowing)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "anim.flow_target", objName)
+		}
+		anim = animation.NewFlowAnimation(flowing, duration)
+	case TOKEN_VALUE:
+		if len(stmt.Parameters) < 2 {
+			return e.newErrorCode(CodeInvalidArgument, "anim.value_params")
+		}
+		propExpr, ok := stmt.Parameters[0].(*StringLiteral)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "anim.value_params")
+		}
+		targetVal, err := e.evalExpression(stmt.Parameters[1])
+		if err != nil {
+			return err
+		}
+		target, ok := targetVal.(float64)
+		if !ok {
+			return e.newErrorCode(CodeInvalidArgument, "anim.value_params")
+		}
+		get, set, ok := numericProperty(mobj, propExpr.Value)
+		if !ok {
+			return e.newErrorCode(CodeUnsupportedProperty, "anim.value_property", objName, propExpr.Value)
+		}
+		anim = animation.NewValueAnimation(mobj, get, set, target, duration)
 	case TOKEN_ELASTIC:
 		if len(stmt.Parameters) < 2 {
-			return fmt.Errorf("elastic animation requires property and target value")
+			return e.newErrorCode(CodeInvalidArgument, "anim.elastic_params")
 		}
 		// 解析属性参数
 		propExpr, ok := stmt.Parameters[0].(*StringLiteral)
 		if !ok {
-			return fmt.Errorf("elastic property must be a string")
+			return e.newErrorCode(CodeInvalidArgument, "anim.elastic_property_type")
 		}
 		propStr := propExpr.Value
 
@@ -1235,7 +3221,7 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 		}
 		anim = animation.NewElasticAnimation(mobj, propStr, targetVal.(float64), duration.Seconds())
 	default:
-		return fmt.Errorf("unsupported animation type: %s", stmt.Animation.Literal)
+		return i18n.Errorfl(e.locale, "anim.unsupported", stmt.Animation.Literal)
 	}
 
 	// 将动画添加到序列中，而不是立即播放
@@ -1248,11 +3234,11 @@ func (e *Evaluator) evalAnimateStatement(stmt *AnimateStatement) error {
 func (e *Evaluator) AnimateMove(objName string, x float64, y float64, duration float64) error {
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return i18n.Errorfl(e.locale, "anim.not_animatable", objName)
 	}
 	endPos := gmMath.NewVector2(x, y)
 	anim := animation.NewMoveToAnimation(mobj, endPos, time.Duration(duration*float64(time.Second)))
@@ -1264,11 +3250,11 @@ func (e *Evaluator) AnimateMove(objName string, x float64, y float64, duration f
 func (e *Evaluator) AnimateScale(objName string, scale float64, duration float64) error {
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return i18n.Errorfl(e.locale, "anim.not_animatable", objName)
 	}
 	anim := animation.NewScaleAnimation(mobj, scale, time.Duration(duration*float64(time.Second)))
 	e.scene.PlayAnimation(anim)
@@ -1279,11 +3265,11 @@ func (e *Evaluator) AnimateScale(objName string, scale float64, duration float64
 func (e *Evaluator) AnimateRotate(objName string, angle float64, duration float64) error {
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return i18n.Errorfl(e.locale, "anim.not_animatable", objName)
 	}
 	anim := animation.NewRotateAnimation(mobj, angle, time.Duration(duration*float64(time.Second)))
 	e.scene.PlayAnimation(anim)
@@ -1294,11 +3280,11 @@ func (e *Evaluator) AnimateRotate(objName string, angle float64, duration float6
 func (e *Evaluator) AnimateFadeIn(objName string, duration float64) error {
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return i18n.Errorfl(e.locale, "anim.not_animatable", objName)
 	}
 	anim := animation.NewFadeInAnimation(mobj, time.Duration(duration*float64(time.Second)))
 	e.scene.PlayAnimation(anim)
@@ -1309,11 +3295,11 @@ func (e *Evaluator) AnimateFadeIn(objName string, duration float64) error {
 func (e *Evaluator) AnimateFadeOut(objName string, duration float64) error {
 	obj, ok := e.objects[objName]
 	if !ok {
-		return fmt.Errorf("object '%s' not found", objName)
+		return e.unknownObjectError(objName)
 	}
 	mobj, ok := obj.(core.Mobject)
 	if !ok {
-		return fmt.Errorf("object '%s' is not animatable", objName)
+		return i18n.Errorfl(e.locale, "anim.not_animatable", objName)
 	}
 	anim := animation.NewFadeOutAnimation(mobj, time.Duration(duration*float64(time.Second)))
 	e.scene.PlayAnimation(anim)
@@ -1323,9 +3309,10 @@ func (e *Evaluator) AnimateFadeOut(objName string, duration float64) error {
 // evalRenderStatement 执行渲染语句
 func (e *Evaluator) evalRenderStatement(stmt *RenderStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
+	e.followAxes()
 	e.scene.RenderFrame()
 	return nil
 }
@@ -1333,7 +3320,7 @@ func (e *Evaluator) evalRenderStatement(stmt *RenderStatement) error {
 // evalRenderFramesStatement 执行渲染帧序列语句
 func (e *Evaluator) evalRenderFramesStatement(stmt *RenderFramesStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
 	// 解析参数
@@ -1342,6 +3329,9 @@ func (e *Evaluator) evalRenderFramesStatement(stmt *RenderFramesStatement) error
 		return err
 	}
 	frameRate := int(frameRateVal.(float64))
+	if e.frameRate > 0 {
+		frameRate = e.frameRate
+	}
 
 	durationVal, err := e.evalExpression(stmt.Duration)
 	if err != nil {
@@ -1355,11 +3345,47 @@ func (e *Evaluator) evalRenderFramesStatement(stmt *RenderFramesStatement) error
 	}
 	outputDir := outputDirVal.(string)
 
-	// 创建序列帧渲染器
+	fsr := e.newFrameSequenceRenderer(outputDir, frameRate, duration)
+	frameRate = fsr.GetFrameRate()
+	if err := e.renderTimeline(fsr, outputDir); err != nil {
+		return err
+	}
+
+	// 自定义输出目标时帧不在磁盘上，无法生成视频
+	if e.sink != nil {
+		return nil
+	}
+
+	// 尝试自动生成视频
+	return e.generateVideo(outputDir, frameRate, duration)
+}
+
+// newFrameSequenceRenderer 创建使用当前场景尺寸、取消上下文、帧输出目标和进度回调的序列帧渲染器
+func (e *Evaluator) newFrameSequenceRenderer(outputDir string, frameRate int, duration float64) *renderer.FrameSequenceRenderer {
+	if frameRate <= 0 {
+		frameRate = e.rt().FPS
+	}
 	fsr := renderer.NewFrameSequenceRenderer(outputDir, frameRate, duration, e.scene.GetWidth(), e.scene.GetHeight())
+	fsr.SetContext(e.ctx)
+	fsr.SetSink(e.sink)
+	fsr.SetLogger(e.logger)
+	fsr.SetProgressFunc(func(frame, total int) {
+		e.framesRendered++
+		if e.progress != nil {
+			e.progress(frame, total)
+		}
+		if frame%10 == 1 || frame == total {
+			e.logger.Debug(i18n.Tl(e.locale, "log.render_progress"), "frame", frame, "total", total,
+				"percent", float64(frame)/float64(total)*100)
+		}
+	})
+	return fsr
+}
 
-	// 计算总帧数
-	totalFrames := int(duration * float64(frameRate))
+// renderTimeline 按动画的先后顺序排出时间轴，逐帧更新动画并交给 fsr 渲染
+func (e *Evaluator) renderTimeline(fsr *renderer.FrameSequenceRenderer, outputDir string) error {
+	totalFrames := fsr.GetFrameCount()
+	frameRate := fsr.GetFrameRate()
 
 	// 计算动画的累积时间
 	var animationTimeMap []struct {
@@ -1377,11 +3403,8 @@ func (e *Evaluator) evalRenderFramesStatement(stmt *RenderFramesStatement) error
 	}
 
 	// 渲染每一帧
-	fmt.Printf("🎬 开始渲染序列帧...\n")
-	fmt.Printf("   输出目录: %s\n", outputDir)
-	fmt.Printf("   帧率: %d fps\n", frameRate)
-	fmt.Printf("   总帧数: %d\n", totalFrames)
-	fmt.Printf("   动画数量: %d\n", len(e.animations))
+	e.logger.Info(i18n.Tl(e.locale, "log.sequence_start"), "output", outputDir, "fps", frameRate,
+		"frames", totalFrames, "animations", len(e.animations))
 
 	start := time.Now()
 
@@ -1410,40 +3433,33 @@ func (e *Evaluator) evalRenderFramesStatement(stmt *RenderFramesStatement) error
 		}
 
 		// 渲染当前帧
+		e.followAxes()
 		if err := fsr.RenderFrame(e.scene, frame); err != nil {
-			return fmt.Errorf("渲染第 %d 帧失败: %v", frame, err)
-		}
-
-		// 显示进度
-		if frame%10 == 0 || frame == totalFrames-1 {
-			progress := float64(frame+1) / float64(totalFrames) * 100
-			fmt.Printf("   进度: %.1f%% (%d/%d)\n", progress, frame+1, totalFrames)
+			return i18n.Errorfl(e.locale, "render.frame_failed", frame, err)
 		}
 	}
 
 	elapsed := time.Since(start)
-	fmt.Printf("✅ 序列帧渲染完成！耗时: %v\n", elapsed)
-
-	// 尝试自动生成视频
-	return e.generateVideo(outputDir, frameRate)
+	e.logger.Info(i18n.Tl(e.locale, "log.sequence_done"), "output", outputDir, "frames", totalFrames, "elapsed", elapsed)
+	return nil
 }
 
 // generateVideo 自动生成视频
-func (e *Evaluator) generateVideo(outputDir string, frameRate int) error {
-	fmt.Printf("\n🎥 正在尝试自动生成视频...\n")
+func (e *Evaluator) generateVideo(outputDir string, frameRate int, duration float64) error {
+	e.logger.Info(i18n.Tl(e.locale, "log.video_try"), "output", outputDir)
 
 	// 检查FFmpeg是否可用
-	_, err := exec.LookPath("ffmpeg")
+	ffmpeg := e.rt().FFmpegPath
+	_, err := exec.LookPath(ffmpeg)
 	if err != nil {
-		fmt.Printf("⚠️  FFmpeg 未找到，跳过自动视频生成\n")
-		fmt.Printf("   请手动安装 FFmpeg 或使用在线工具转换\n")
-		e.generateManualInstructions(outputDir, frameRate)
+		e.logger.Warn(i18n.Tl(e.locale, "log.ffmpeg_not_found"))
+		e.generateManualInstructions(outputDir, frameRate, duration)
 		return nil
 	}
 
 	// 生成MP4视频
 	mp4Path := filepath.Join(outputDir, "animation.mp4")
-	mp4Cmd := exec.Command("ffmpeg",
+	mp4Cmd := exec.CommandContext(e.ctx, ffmpeg,
 		"-framerate", fmt.Sprintf("%d", frameRate),
 		"-i", filepath.Join(outputDir, "frame_%06d.png"),
 		"-c:v", "libx264",
@@ -1453,11 +3469,11 @@ func (e *Evaluator) generateVideo(outputDir string, frameRate int) error {
 		"-y", // 覆盖已存在的文件
 		mp4Path)
 
-	fmt.Printf("   生成 MP4: %s\n", mp4Path)
+	e.logger.Info(i18n.Tl(e.locale, "log.mp4_start"), "path", mp4Path)
 	if err := mp4Cmd.Run(); err != nil {
-		fmt.Printf("❌ MP4 生成失败: %v\n", err)
+		e.logger.Error(i18n.Tl(e.locale, "log.mp4_failed"), "path", mp4Path, "error", err)
 	} else {
-		fmt.Printf("✅ MP4 生成成功!\n")
+		e.logger.Info(i18n.Tl(e.locale, "log.mp4_done"), "path", mp4Path)
 	}
 
 	// 生成GIF动画
@@ -1465,7 +3481,7 @@ func (e *Evaluator) generateVideo(outputDir string, frameRate int) error {
 
 	// 首先生成调色板
 	palettePath := filepath.Join(outputDir, "palette.png")
-	paletteCmd := exec.Command("ffmpeg",
+	paletteCmd := exec.CommandContext(e.ctx, ffmpeg,
 		"-framerate", fmt.Sprintf("%d", frameRate),
 		"-i", filepath.Join(outputDir, "frame_%06d.png"),
 		"-vf", "fps=30,scale=800:450:flags=lanczos,palettegen",
@@ -1473,10 +3489,10 @@ func (e *Evaluator) generateVideo(outputDir string, frameRate int) error {
 		palettePath)
 
 	if err := paletteCmd.Run(); err != nil {
-		fmt.Printf("❌ 调色板生成失败: %v\n", err)
+		e.logger.Error(i18n.Tl(e.locale, "log.palette_failed"), "path", palettePath, "error", err)
 	} else {
 		// 生成GIF
-		gifCmd := exec.Command("ffmpeg",
+		gifCmd := exec.CommandContext(e.ctx, ffmpeg,
 			"-framerate", fmt.Sprintf("%d", frameRate),
 			"-i", filepath.Join(outputDir, "frame_%06d.png"),
 			"-i", palettePath,
@@ -1484,71 +3500,34 @@ func (e *Evaluator) generateVideo(outputDir string, frameRate int) error {
 			"-y",
 			gifPath)
 
-		fmt.Printf("   生成 GIF: %s\n", gifPath)
+		e.logger.Info(i18n.Tl(e.locale, "log.gif_start"), "path", gifPath)
 		if err := gifCmd.Run(); err != nil {
-			fmt.Printf("❌ GIF 生成失败: %v\n", err)
+			e.logger.Error(i18n.Tl(e.locale, "log.gif_failed"), "path", gifPath, "error", err)
 		} else {
-			fmt.Printf("✅ GIF 生成成功!\n")
+			e.logger.Info(i18n.Tl(e.locale, "log.gif_done"), "path", gifPath)
 		}
 	}
 
-	fmt.Printf("\n🎉 视频生成完成!\n")
-	fmt.Printf("   📹 MP4: %s\n", mp4Path)
-	fmt.Printf("   🎞️ GIF: %s\n", gifPath)
+	e.logger.Info(i18n.Tl(e.locale, "log.video_done"), "mp4", mp4Path, "gif", gifPath)
 
-	return nil
+	return e.ctx.Err()
 }
 
 // generateManualInstructions 生成手动操作说明
-func (e *Evaluator) generateManualInstructions(outputDir string, frameRate int) {
+func (e *Evaluator) generateManualInstructions(outputDir string, frameRate int, duration float64) {
 	instructionsPath := filepath.Join(outputDir, "VIDEO_INSTRUCTIONS.md")
 
-	content := fmt.Sprintf(`# 视频生成说明
-
-## 自动生成 (需要 FFmpeg)
-
-### 安装 FFmpeg
-- Windows: 下载 https://ffmpeg.org/download.html 并添加到 PATH
-- macOS: brew install ffmpeg
-- Linux: sudo apt install ffmpeg
-
-### 生成命令
-
-#### MP4 视频:
-`+"```bash"+`
-ffmpeg -framerate %d -i "frame_%%06d.png" -c:v libx264 -preset medium -crf 23 -pix_fmt yuv420p animation.mp4
-`+"```"+`
-
-#### GIF 动画:
-`+"```bash"+`
-ffmpeg -framerate %d -i "frame_%%06d.png" -vf "fps=30,scale=800:450:flags=lanczos,palettegen" palette.png
-ffmpeg -framerate %d -i "frame_%%06d.png" -i palette.png -filter_complex "fps=30,scale=800:450:flags=lanczos[x];[x][1:v]paletteuse" animation.gif
-`+"```"+`
-
-## 在线转换
-
-如果无法安装 FFmpeg，可使用在线工具:
-1. 将所有 PNG 文件打包为 ZIP
-2. 上传到 ezgif.com 或类似网站
-3. 设置帧率为 %d fps
-4. 下载生成的视频
-
-## 文件说明
-
-- frame_000000.png ~ frame_NNNNNN.png: 序列帧图像
-- 建议帧率: %d fps
-- 总时长: %.1f 秒
-`, frameRate, frameRate, frameRate, frameRate, float64(len(os.Args))/float64(frameRate))
+	content := i18n.Tl(e.locale, "video.instructions", frameRate, duration)
 
 	if err := os.WriteFile(instructionsPath, []byte(content), 0644); err == nil {
-		fmt.Printf("💾 说明文档已保存: %s\n", instructionsPath)
+		e.logger.Info(i18n.Tl(e.locale, "log.instructions_saved"), "path", instructionsPath)
 	}
 }
 
 // evalSaveStatement 执行保存语句
 func (e *Evaluator) evalSaveStatement(stmt *SaveStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
 	filename, err := e.evalExpression(stmt.Filename)
@@ -1557,10 +3536,10 @@ func (e *Evaluator) evalSaveStatement(stmt *SaveStatement) error {
 	}
 
 	// 创建输出目录结构
-	outputDir := fmt.Sprintf("output/%s/frames", e.projectName)
+	outputDir := e.rt().OutputPath(e.projectName, "frames")
 	err = os.MkdirAll(outputDir, 0755)
 	if err != nil {
-		return fmt.Errorf("failed to create output directory: %v", err)
+		return i18n.Errorfl(e.locale, "io.mkdir_failed", outputDir, err)
 	}
 
 	// 构建完整的文件路径并确保PNG扩展名
@@ -1585,7 +3564,7 @@ func (e *Evaluator) saveImageFile(fullPath string) error {
 	// 确保目录存在
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败 '%s': %v", dir, err)
+		return i18n.Errorfl(e.locale, "io.mkdir_failed", dir, err)
 	}
 
 	// 获取图像 - 修复接口类型断言
@@ -1596,16 +3575,16 @@ func (e *Evaluator) saveImageFile(fullPath string) error {
 		// 创建文件
 		file, err := os.Create(fullPath)
 		if err != nil {
-			return fmt.Errorf("创建输出文件失败 '%s': %v", fullPath, err)
+			return i18n.Errorfl(e.locale, "io.create_file_failed", fullPath, err)
 		}
 		defer file.Close()
 
 		// 编码为PNG
 		if err := png.Encode(file, img); err != nil {
-			return fmt.Errorf("PNG编码失败: %v", err)
+			return i18n.Errorfl(e.locale, "io.png_encode_failed", err)
 		}
 	} else {
-		return fmt.Errorf("不支持的渲染器类型")
+		return i18n.Errorfl(e.locale, "render.unsupported_renderer")
 	}
 
 	return nil
@@ -1614,7 +3593,7 @@ func (e *Evaluator) saveImageFile(fullPath string) error {
 // evalExportStatement 执行导出语句 - 导出序列帧动画
 func (e *Evaluator) evalExportStatement(stmt *ExportStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
 	filename, err := e.evalExpression(stmt.Filename)
@@ -1623,7 +3602,7 @@ func (e *Evaluator) evalExportStatement(stmt *ExportStatement) error {
 	}
 
 	// 默认参数
-	fps := 60.0
+	fps := float64(e.rt().FPS)
 	duration := 5.0
 
 	// 解析可选参数
@@ -1643,13 +3622,17 @@ func (e *Evaluator) evalExportStatement(stmt *ExportStatement) error {
 		duration = durationVal.(float64)
 	}
 
+	if e.frameRate > 0 {
+		fps = float64(e.frameRate)
+	}
+
 	return e.renderAnimationSequence(filename.(string), float64(fps), duration)
 }
 
 // evalVideoStatement 执行视频语句 - 直接生成视频文件
 func (e *Evaluator) evalVideoStatement(stmt *VideoStatement) error {
 	if e.scene == nil {
-		return fmt.Errorf("no scene defined")
+		return e.newErrorCode(CodeNoScene, "eval.no_scene")
 	}
 
 	filename, err := e.evalExpression(stmt.Filename)
@@ -1680,8 +3663,12 @@ func (e *Evaluator) evalWaitStatement(stmt *WaitStatement) error {
 		return err
 	}
 
-	time.Sleep(time.Duration(duration.(float64)) * time.Second)
-	return nil
+	select {
+	case <-time.After(time.Duration(duration.(float64)) * time.Second):
+		return nil
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	}
 }
 
 // evalLoopStatement 执行循环语句
@@ -1694,6 +3681,9 @@ func (e *Evaluator) evalLoopStatement(stmt *LoopStatement) error {
 	loopCount := int(count.(float64))
 	for i := 0; i < loopCount; i++ {
 		for _, s := range stmt.Statements {
+			if err := e.ctx.Err(); err != nil {
+				return err
+			}
 			err := e.evalStatement(s)
 			if err != nil {
 				return err
@@ -1708,20 +3698,25 @@ func (e *Evaluator) evalLoopStatement(stmt *LoopStatement) error {
 func (e *Evaluator) evalCleanStatement(stmt *CleanStatement) error {
 	var dirsToClean []string
 
-	// 如果没有指定目录，则默认清空output和scripts
+	// 如果没有指定目录，则默认清空输出根目录和scripts
 	if len(stmt.Dirs) == 0 {
-		dirsToClean = []string{"output", "scripts"}
+		dirsToClean = []string{e.rt().OutputRoot, "scripts"}
 	} else {
 		// 解析指定的目录
 		for _, dirExpr := range stmt.Dirs {
 			dirValue, err := e.evalExpression(dirExpr)
 			if err != nil {
-				return e.newError("解析目录名失败: %v", err)
+				return e.newError("clean.dir_parse", err)
 			}
 
 			dirStr, ok := dirValue.(string)
 			if !ok {
-				return e.newError("目录名必须是字符串，得到的是: %T", dirValue)
+				return e.newError("clean.dir_type", dirValue)
+			}
+
+			// 确保脚本给出的目录名合法，防止安全问题
+			if strings.Contains(dirStr, "..") || strings.Contains(dirStr, "/") || strings.Contains(dirStr, "\\") {
+				return e.newError("clean.invalid_dir", dirStr)
 			}
 			dirsToClean = append(dirsToClean, dirStr)
 		}
@@ -1729,18 +3724,14 @@ func (e *Evaluator) evalCleanStatement(stmt *CleanStatement) error {
 
 	// 执行清空操作
 	for _, dir := range dirsToClean {
-		// 确保目录名合法，防止安全问题
-		if strings.Contains(dir, "..") || strings.Contains(dir, "/") || strings.Contains(dir, "\\") {
-			return e.newError("非法目录路径: %s", dir)
-		}
 
 		// 清空目录内容，但保留目录本身
 		err := cleanDirectory(dir)
 		if err != nil {
-			return e.newError("清空目录 '%s' 失败: %v", dir, err)
+			return e.newError("clean.failed", dir, err)
 		}
 
-		fmt.Printf("已清空目录: %s\n", dir)
+		e.logger.Info(i18n.Tl(e.locale, "log.dir_cleaned"), "dir", dir)
 	}
 
 	return nil
@@ -1795,9 +3786,97 @@ func (e *Evaluator) evalExpression(expr Expression) (interface{}, error) {
 		return node, nil // 返回坐标表达式本身，由调用者处理
 	case *ArrayExpression:
 		return node, nil // 返回数组表达式本身，由调用者处理
+	case *CallExpression:
+		return e.evalCall(node)
 	default:
-		return nil, fmt.Errorf("unknown expression type: %T", expr)
+		return nil, i18n.Errorfl(e.locale, "eval.unknown_expression", expr)
+	}
+}
+
+// evalCall 执行内置函数调用：
+//
+//	derivative("<expression>" [, "<variable>"])  符号求导，变量默认为 x，返回化简后的导函数
+//	simplify("<expression>")                     化简表达式
+//
+// 结果为表达式字符串，可以直接用于 graph 等对象或显示为文本
+func (e *Evaluator) evalCall(call *CallExpression) (interface{}, error) {
+	switch call.Function {
+	case "derivative":
+		args, err := e.stringArgs(call.Arguments, "call.derivative_usage")
+		if err != nil {
+			return nil, err
+		}
+		if len(args) < 1 || len(args) > 2 {
+			return nil, i18n.Errorfl(e.locale, "call.derivative_usage")
+		}
+		variable := "x"
+		if len(args) == 2 {
+			variable = strings.TrimSpace(args[1])
+		}
+		expr, err := gmMath.ParseExpr(args[0])
+		if err != nil {
+			return nil, i18n.Errorfl(e.locale, "graph.invalid_function", args[0], err)
+		}
+		derivative, err := gmMath.Derivative(expr, variable)
+		if err != nil {
+			return nil, err
+		}
+		return derivative.String(), nil
+	case "simplify":
+		args, err := e.stringArgs(call.Arguments, "call.simplify_usage")
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 1 {
+			return nil, i18n.Errorfl(e.locale, "call.simplify_usage")
+		}
+		expr, err := gmMath.ParseExpr(args[0])
+		if err != nil {
+			return nil, i18n.Errorfl(e.locale, "graph.invalid_function", args[0], err)
+		}
+		return gmMath.Simplify(expr).String(), nil
+	}
+	if axes, ok := e.objects[call.Function].(*geometry.CoordinateSystem); ok {
+		return e.evalAxesPoint(axes, call)
+	}
+	return nil, i18n.Errorfl(e.locale, "call.unknown_function", call.Function, "derivative, simplify, <axes>(x, y)")
+}
+
+// evalAxesPoint 计算 <axes>(x, y)：坐标系中的点，用作 position 时对象跟随坐标系
+func (e *Evaluator) evalAxesPoint(axes *geometry.CoordinateSystem, call *CallExpression) (*axesPoint, error) {
+	if len(call.Arguments) != 2 {
+		return nil, i18n.Errorfl(e.locale, "call.axes_usage", call.Function)
 	}
+	var coord [2]float64
+	for i, arg := range call.Arguments {
+		value, err := e.evalExpression(arg)
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, "call.axes_usage", call.Function)
+		}
+		coord[i] = number
+	}
+	return &axesPoint{axes: axes, coord: gmMath.Vector2{X: coord[0], Y: coord[1]}}, nil
+}
+
+// stringArgs 计算函数调用的参数：参数求值出错时返回该错误，不是字符串时返回用法 usage
+func (e *Evaluator) stringArgs(params []Expression, usage string) ([]string, error) {
+	args := make([]string, len(params))
+	for i, param := range params {
+		value, err := e.evalExpression(param)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := value.(string)
+		if !ok {
+			return nil, i18n.Errorfl(e.locale, usage)
+		}
+		args[i] = s
+	}
+	return args, nil
 }
 
 // GetErrors 返回执行错误
@@ -1815,131 +3894,99 @@ func (e *Evaluator) GetObjects() map[string]interface{} {
 	return e.objects
 }
 
-/*
-// createMarkdown 创建Markdown对象
-func (e *Evaluator) createMarkdown(stmt *CreateStatement) (interface{}, error) {
-	// 该功能已被移除以简化项目
-	return nil, fmt.Errorf("markdown功能已被移除")
-}
-
-// createTex 创建TeX对象
-func (e *Evaluator) createTex(stmt *CreateStatement) (interface{}, error) {
-	// 该功能已被移除以简化项目
-	return nil, fmt.Errorf("TeX功能已被移除")
-}
-
-// createTexWithLatex 创建TeX对象（使用latex库）
-func (e *Evaluator) createTexWithLatex(stmt *CreateStatement) (interface{}, error) {
-	// 该功能已被移除以简化项目
-	return nil, fmt.Errorf("TeX功能已被移除")
-}
-
-// createMathTex 创建数学TeX对象
-func (e *Evaluator) createMathTex(stmt *CreateStatement) (interface{}, error) {
-	// 该功能已被移除以简化项目
-	return nil, fmt.Errorf("数学TeX功能已被移除")
-}
-*/
-
-// renderAnimationSequence 渲染动画序列为帧图片
-func (e *Evaluator) renderAnimationSequence(filename string, fps, duration float64) error {
+// GetImage 获取场景渲染器当前画布的图像，没有场景时返回 nil
+func (e *Evaluator) GetImage() image.Image {
 	if e.scene == nil {
-		return fmt.Errorf("没有活动的场景")
+		return nil
 	}
-
-	// 获取渲染器
-	rendererInterface := e.scene.GetRenderer()
-	if rendererInterface == nil {
-		return fmt.Errorf("没有设置渲染器")
+	if canvasRenderer, ok := e.scene.GetRenderer().(*renderer.CanvasRenderer); ok {
+		return canvasRenderer.GetImage()
 	}
+	return nil
+}
 
-	canvasRenderer, ok := rendererInterface.(*renderer.CanvasRenderer)
-	if !ok {
-		return fmt.Errorf("渲染器类型不支持")
+// renderAnimationSequence 渲染动画序列并用 FFmpeg 合成视频 filename。
+// 项目帧目录中已有 save 生成的帧时直接用这些帧合成；设置了自定义帧输出目标时只输出帧，不生成视频
+func (e *Evaluator) renderAnimationSequence(filename string, fps, duration float64) error {
+	if e.scene == nil {
+		return i18n.Errorfl(e.locale, "render.no_active_scene")
 	}
 
 	// 检查是否已经存在帧文件（通过save命令生成的）
 	// 如果存在，则使用这些帧而不是生成新的
-	projectFrameDir := fmt.Sprintf("output/%s/frames", e.projectName)
-	if _, err := os.Stat(projectFrameDir); err == nil {
-		// 帧目录存在，检查是否有帧文件
-		entries, _ := os.ReadDir(projectFrameDir)
-		if len(entries) > 0 {
-			// 使用现有的帧文件生成视频
-			fmt.Printf("使用已存在的帧文件生成视频: %s\n", filename)
-
-			// 使用FFmpeg合成视频，使用项目帧目录中的文件
-			ffmpegCmd := fmt.Sprintf("ffmpeg -r %.2f -i output/%s/frames/%s_%%02d.png -c:v libx264 -pix_fmt yuv420p %s", fps, e.projectName, e.projectName, filename)
-
-			cmd := exec.Command("cmd", "/C", ffmpegCmd)
-			_, err := cmd.CombinedOutput()
-			if err != nil {
-				fmt.Printf("⚠️ FFmpeg未安装或执行失败\n")
-				fmt.Printf("您可以手动使用FFmpeg合成视频: %s\n", ffmpegCmd)
-				return nil // 不返回错误，只是警告
-			}
-
-			fmt.Printf("动画视频已生成: %s\n", filename)
-			return nil
+	projectFrameDir := e.rt().OutputPath(e.projectName, "frames")
+	if entries, err := os.ReadDir(projectFrameDir); err == nil && len(entries) > 0 && e.sink == nil {
+		e.logger.Info(i18n.Tl(e.locale, "log.video_from_frames"), "output", filename)
+		encoded, err := e.encodeVideo(filename,
+			"-r", fmt.Sprintf("%.2f", fps),
+			"-i", filepath.Join(projectFrameDir, e.projectName+"_%02d.png"))
+		if err != nil || !encoded {
+			return err
 		}
+		e.logger.Info(i18n.Tl(e.locale, "log.animation_video_done"), "output", filename)
+		return nil
 	}
 
-	// 如果没有现有帧，则生成新的动画帧（原有逻辑）
-	// 计算总帧数
-	totalFrames := int(fps * duration)
+	// 没有现有帧时按动画时间轴生成新的帧
 	frameDir := fmt.Sprintf("%s_frames", strings.TrimSuffix(filename, ".mp4"))
+	fsr := e.newFrameSequenceRenderer(frameDir, int(math.Round(fps)), duration)
+	if err := e.renderTimeline(fsr, frameDir); err != nil {
+		return err
+	}
 
-	// 创建帧目录
-	err := os.MkdirAll(frameDir, 0755)
-	if err != nil {
-		return fmt.Errorf("创建帧目录失败: %v", err)
+	// 自定义输出目标时帧不在磁盘上，无法生成视频
+	if e.sink != nil {
+		return nil
 	}
 
-	// 准备动画时间轴
-	dt := 1.0 / fps
+	encoded, err := e.encodeVideo(filename,
+		"-r", fmt.Sprintf("%d", fsr.GetFrameRate()),
+		"-i", filepath.Join(frameDir, "frame_%06d.png"))
+	if err != nil {
+		return err
+	}
+	if !encoded {
+		// 没有 FFmpeg 时保留序列帧和手动合成的说明
+		e.generateManualInstructions(frameDir, fsr.GetFrameRate(), duration)
+		return nil
+	}
 
-	// 渲染每一帧
-	for frame := 0; frame < totalFrames; frame++ {
-		currentTime := float64(frame) * dt
+	// 清理临时帧文件
+	os.RemoveAll(frameDir)
 
-		// 清空画布
-		canvasRenderer.Clear(1.0, 1.0, 1.0)
+	e.logger.Info(i18n.Tl(e.locale, "log.animation_video_done"), "output", filename)
+	return nil
+}
 
-		// 更新并渲染所有对象
-		for _, obj := range e.scene.GetObjects() {
-			// 如果对象支持动画更新
-			if mobject, ok := obj.(interface{ UpdateAnimation(float64) }); ok {
-				mobject.UpdateAnimation(currentTime)
-			}
-			// 渲染对象
-			canvasRenderer.Render(obj)
-		}
+// encodeVideo 用 FFmpeg 把 input 参数指定的帧编码为 H.264 视频 filename，
+// FFmpeg 随上下文取消而终止，失败时返回包含其输出的错误。
+// 找不到 FFmpeg 时只给出警告和手动合成的命令，返回 false
+func (e *Evaluator) encodeVideo(filename string, input ...string) (bool, error) {
+	ffmpeg := e.rt().FFmpegPath
+	args := make([]string, 0, len(input)+7)
+	args = append(args, input...)
+	args = append(args, "-c:v", "libx264", "-pix_fmt", "yuv420p", "-y", filename)
 
-		// 保存当前帧
-		framePath := fmt.Sprintf("%s/frame_%04d.png", frameDir, frame)
-		err := canvasRenderer.SaveFrame(framePath)
-		if err != nil {
-			return fmt.Errorf("渲染第%d帧失败: %v", frame, err)
-		}
+	if _, err := exec.LookPath(ffmpeg); err != nil {
+		e.logger.Warn(i18n.Tl(e.locale, "log.ffmpeg_not_found"))
+		e.logger.Info(i18n.Tl(e.locale, "log.ffmpeg_commands"), "command", ffmpeg+" "+strings.Join(args, " "))
+		return false, nil
 	}
 
-	// 使用FFmpeg合成视频
-	ffmpegCmd := fmt.Sprintf("ffmpeg -r %.2f -i %s/frame_%%04d.png -c:v libx264 -pix_fmt yuv420p %s", fps, frameDir, filename)
-
-	cmd := exec.Command("cmd", "/C", ffmpegCmd)
-	_, err = cmd.CombinedOutput()
+	output, err := exec.CommandContext(e.ctx, ffmpeg, args...).CombinedOutput()
+	if ctxErr := e.ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
 	if err != nil {
-		fmt.Printf("⚠️ FFmpeg未安装或执行失败，帧图片已保存到: %s\n", frameDir)
-		fmt.Printf("您可以手动使用FFmpeg合成视频: %s\n", ffmpegCmd)
-		return nil // 不返回错误，只是警告
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			err = fmt.Errorf("%w: %s", err, detail)
+		}
+		return false, i18n.Errorfl(e.locale, "video.ffmpeg_failed", filename, err)
 	}
+	return true, nil
+}
 
-	// 清理临时帧文件
-	os.RemoveAll(frameDir)
-
-	fmt.Printf("动画视频已生成: %s\n", filename)
-	return nil
-} // renderVideoDirectly 直接渲染视频文件
+// renderVideoDirectly 直接渲染视频文件
 func (e *Evaluator) renderVideoDirectly(filename string, fps, duration float64) error {
 	// 对于直接视频渲染，我们也使用帧序列方法
 	// 这确保了与现有渲染系统的兼容性
@@ -2,11 +2,14 @@ package interpreter
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"render2go/geometry"
+	"render2go/internal/i18n"
 	"strings"
 )
 
@@ -14,6 +17,8 @@ import (
 type Interpreter struct {
 	evaluator *Evaluator
 	debug     bool
+	logger    *slog.Logger
+	locale    i18n.Locale // 错误和日志消息的语言，为空时使用进程默认语言
 }
 
 // NewInterpreter 创建新的解释器实例
@@ -21,14 +26,30 @@ func NewInterpreter(debug bool) *Interpreter {
 	return &Interpreter{
 		evaluator: NewEvaluator(),
 		debug:     debug,
+		logger:    slog.Default(),
 	}
 }
 
+// SetLogger 设置状态日志输出（同时作用于求值器）
+func (i *Interpreter) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	i.logger = logger
+	i.evaluator.SetLogger(logger)
+}
+
+// SetLocale 设置错误和日志消息的语言（同时作用于求值器），为空时使用进程默认语言
+func (i *Interpreter) SetLocale(l i18n.Locale) {
+	i.locale = l
+	i.evaluator.SetLocale(l)
+}
+
 // RunFile 执行脚本文件
 func (i *Interpreter) RunFile(filename string) error {
 	file, err := os.Open(filename)
 	if err != nil {
-		return fmt.Errorf("failed to open file %s: %w", filename, err)
+		return i18n.Errorfl(i.locale, "error.open_file", filename, err)
 	}
 	defer file.Close()
 
@@ -47,7 +68,7 @@ func (i *Interpreter) RunReader(reader io.Reader, source string) error {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to read from %s: %w", source, err)
+		return i18n.Errorfl(i.locale, "error.read_source", source, err)
 	}
 
 	return i.RunString(content.String(), source)
@@ -55,9 +76,7 @@ func (i *Interpreter) RunReader(reader io.Reader, source string) error {
 
 // RunString 直接执行脚本字符串
 func (i *Interpreter) RunString(script, source string) error {
-	if i.debug {
-		fmt.Printf("🔍 Parsing script from %s...\n", source)
-	}
+	i.logger.Debug(i18n.Tl(i.locale, "log.parse_script"), "source", source)
 
 	// 词法分析
 	lexer := NewLexer(script)
@@ -79,12 +98,15 @@ func (i *Interpreter) RunString(script, source string) error {
 
 	// 语法分析
 	parser := NewParser(lexer)
+	parser.SetLocale(i.locale)
 	program := parser.ParseProgram()
 
 	// 检查解析错误
-	errors := parser.Errors()
-	if len(errors) > 0 {
-		return fmt.Errorf("parsing errors:\n%s", strings.Join(errors, "\n"))
+	if errs := parser.ParseErrors(); len(errs) > 0 {
+		for _, err := range errs {
+			err.File = source
+		}
+		return errs
 	}
 
 	if i.debug {
@@ -94,35 +116,32 @@ func (i *Interpreter) RunString(script, source string) error {
 	}
 
 	// 执行程序
-	if i.debug {
-		fmt.Println("🚀 Executing...")
-	}
+	i.logger.Debug(i18n.Tl(i.locale, "log.execute_start"), "source", source)
+	i.evaluator.SetFileName(source)
 
 	err := i.evaluator.Evaluate(program)
 	if err != nil {
-		return fmt.Errorf("execution error: %w", err)
+		// 执行错误已带有位置和代码，只包装其他错误
+		var runtimeErr *RuntimeError
+		var unknownErr *UnknownObjectError
+		if errors.As(err, &runtimeErr) || errors.As(err, &unknownErr) {
+			return err
+		}
+		return i18n.Errorfl(i.locale, "error.execution", err)
 	}
 
 	// 检查执行错误
 	execErrors := i.evaluator.GetErrors()
 	if len(execErrors) > 0 {
-		return fmt.Errorf("execution errors:\n%s", strings.Join(execErrors, "\n"))
+		return i18n.Errorfl(i.locale, "error.executions", strings.Join(execErrors, "\n"))
 	}
 
-	if i.debug {
-		fmt.Println("✅ Execution completed successfully!")
-	}
+	i.logger.Debug(i18n.Tl(i.locale, "log.execute_done"), "source", source)
 
 	// 自动修复PNG文件扩展名
-	if i.debug {
-		fmt.Println("🔧 Attempting to fix PNG extensions...")
-	}
 	err = i.fixPNGExtensions()
-	if err != nil && i.debug {
-		fmt.Printf("⚠️ Warning: Failed to fix PNG extensions: %v\n", err)
-	}
-	if i.debug {
-		fmt.Println("✅ PNG extension fix completed")
+	if err != nil {
+		i.logger.Warn(i18n.Tl(i.locale, "log.fix_png_failed"), "error", err)
 	}
 
 	return nil
@@ -130,10 +149,7 @@ func (i *Interpreter) RunString(script, source string) error {
 
 // RunInteractive 运行交互式模式
 func (i *Interpreter) RunInteractive() {
-	fmt.Println("🎬 Render2Go Script Interpreter")
-	fmt.Println("Type your commands or 'exit' to quit")
-	fmt.Println("Commands: scene, create, set, animate, render, save, wait, loop")
-	fmt.Println()
+	fmt.Println(i18n.Tl(i.locale, "repl.banner"))
 
 	scanner := bufio.NewScanner(os.Stdin)
 	lineNumber := 1
@@ -152,7 +168,7 @@ func (i *Interpreter) RunInteractive() {
 		}
 
 		if line == "exit" || line == "quit" {
-			fmt.Println("👋 Goodbye!")
+			fmt.Println(i18n.Tl(i.locale, "repl.goodbye"))
 			break
 		}
 
@@ -163,19 +179,21 @@ func (i *Interpreter) RunInteractive() {
 
 		if line == "debug on" {
 			i.debug = true
-			fmt.Println("🔍 Debug mode enabled")
+			fmt.Println(i18n.Tl(i.locale, "repl.debug_on"))
 			continue
 		}
 
 		if line == "debug off" {
 			i.debug = false
-			fmt.Println("🔍 Debug mode disabled")
+			fmt.Println(i18n.Tl(i.locale, "repl.debug_off"))
 			continue
 		}
 
 		if line == "clear" {
 			i.evaluator = NewEvaluator()
-			fmt.Println("🧹 Interpreter state cleared")
+			i.evaluator.SetLogger(i.logger)
+			i.evaluator.SetLocale(i.locale)
+			fmt.Println(i18n.Tl(i.locale, "repl.cleared"))
 			continue
 		}
 
@@ -185,9 +203,9 @@ func (i *Interpreter) RunInteractive() {
 		}
 
 		// 执行单行命令
-		err := i.RunString(line, fmt.Sprintf("line %d", lineNumber))
+		err := i.RunString(line, i18n.Tl(i.locale, "repl.line_source", lineNumber))
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+			fmt.Println(i18n.Tl(i.locale, "repl.error", err))
 		}
 
 		lineNumber++
@@ -196,62 +214,18 @@ func (i *Interpreter) RunInteractive() {
 
 // printHelp 打印帮助信息
 func (i *Interpreter) printHelp() {
-	fmt.Println(`
-📚 Render2Go Script Language Help
-
-Scene Management:
-  scene <width> <height> "name"     - Create a new scene
-
-Object Creation:
-  create circle <name> <radius> [(<x>, <y>)]
-  create rectangle <name> <width> <height> [(<x>, <y>)]
-  create line <name> (<x1>, <y1>) (<x2>, <y2>)
-  create arrow <name> (<x1>, <y1>) (<x2>, <y2>)
-  create text <name> "text" <size> [(<x>, <y>)]
-  create polygon <name> [(<x1>, <y1>), (<x2>, <y2>), ...]
-
-Property Setting:
-  set <object>.color = #RRGGBB | deepblue | midblue | purpleblue | cyanblue | darkcolor | lightpurple
-  set <object>.position = (<x>, <y>)
-  set <object>.opacity = <value>
-  set <object>.size = <value>
-
-Rendering:
-  render                           - Render current frame
-  save "filename"                  - Save current frame
-
-Control Flow:
-  wait <seconds>                   - Wait for specified time
-  loop <count> { ... }            - Repeat commands
-
-Interactive Commands:
-  help                            - Show this help
-  debug on/off                    - Toggle debug mode
-  clear                           - Clear interpreter state
-  objects                         - List created objects
-  exit/quit                       - Exit interpreter
-
-Color Names:
-  deepblue, midblue, purpleblue, cyanblue, darkcolor, lightpurple
-
-Examples:
-  scene 800 600 "my_animation"
-  create circle c1 50 (400, 300)
-  set c1.color = #576DA2
-  set c1.opacity = 0.8
-  render
-  save "frame.png"`)
+	fmt.Println(i18n.Tl(i.locale, "repl.help"))
 }
 
 // listObjects 列出已创建的对象
 func (i *Interpreter) listObjects() {
 	objects := i.evaluator.GetObjects()
 	if len(objects) == 0 {
-		fmt.Println("📦 No objects created yet")
+		fmt.Println(i18n.Tl(i.locale, "repl.no_objects"))
 		return
 	}
 
-	fmt.Println("📦 Created Objects:")
+	fmt.Println(i18n.Tl(i.locale, "repl.objects"))
 	for name, obj := range objects {
 		objType := "unknown"
 		switch obj.(type) {
@@ -267,6 +241,38 @@ func (i *Interpreter) listObjects() {
 			objType = "polygon"
 		case *geometry.Text:
 			objType = "text"
+		case *geometry.MathTex:
+			objType = "mathtex"
+		case *geometry.Markdown:
+			objType = "markdown"
+		case *geometry.Path:
+			objType = "path"
+		case *geometry.SVG:
+			objType = "svg"
+		case *geometry.Image:
+			objType = "image"
+		case *geometry.FunctionGraph:
+			objType = "graph"
+		case *geometry.ParametricCurve:
+			objType = "parametric"
+		case *geometry.ContourPlot:
+			objType = "implicit"
+		case *geometry.VectorField:
+			objType = "vector_field"
+		case *geometry.StreamLines:
+			objType = "streamlines"
+		case *geometry.Area:
+			objType = "area"
+		case *geometry.RiemannSum:
+			objType = "riemann"
+		case *geometry.GraphTracker:
+			objType = "graph_point"
+			switch obj.(*geometry.GraphTracker).Kind() {
+			case geometry.TrackerTangent:
+				objType = "tangent"
+			case geometry.TrackerSecant:
+				objType = "secant"
+			}
 		}
 		fmt.Printf("  %s: %s\n", name, objType)
 	}
@@ -279,7 +285,7 @@ func (i *Interpreter) GetEvaluator() *Evaluator {
 
 // fixPNGExtensions 自动修复输出目录中的PNG文件扩展名
 func (i *Interpreter) fixPNGExtensions() error {
-	outputPath := "output"
+	outputPath := i.evaluator.rt().OutputRoot
 
 	// 检查输出目录是否存在
 	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
@@ -320,21 +326,16 @@ func (i *Interpreter) fixPNGExtensions() error {
 
 					// 重命名文件添加.png扩展名
 					newPath := path + ".png"
-					if i.debug {
-						fmt.Printf("🔧 Attempting to rename: %s -> %s\n", path, newPath)
-					}
 					err = os.Rename(path, newPath)
 					if err != nil {
-						if i.debug {
-							fmt.Printf("❌ Rename failed: %v\n", err)
-						}
+						i.logger.Debug(i18n.Tl(i.locale, "log.rename_failed"), "path", path, "error", err)
 						// 如果重命名失败，尝试复制+删除
 						err = i.copyAndDelete(path, newPath)
-						if err == nil && i.debug {
-							fmt.Printf("🔧 Fixed PNG extension via copy+delete: %s -> %s\n", filepath.Base(path), filepath.Base(newPath))
+						if err == nil {
+							i.logger.Debug(i18n.Tl(i.locale, "log.png_fixed_by_copy"), "from", filepath.Base(path), "to", filepath.Base(newPath))
 						}
-					} else if i.debug {
-						fmt.Printf("🔧 Fixed PNG extension: %s -> %s\n", filepath.Base(path), filepath.Base(newPath))
+					} else {
+						i.logger.Debug(i18n.Tl(i.locale, "log.png_fixed"), "from", filepath.Base(path), "to", filepath.Base(newPath))
 					}
 				}
 			}()
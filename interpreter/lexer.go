@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 // TokenType 代表不同的标记类型
@@ -51,6 +52,20 @@ const (
 	TOKEN_MARKDOWN          // markdown
 	TOKEN_TEX               // tex
 	TOKEN_MATHTEX           // mathtex (通用数学公式)
+	TOKEN_OUTLINE           // outline (文字轮廓路径)
+	TOKEN_SVG               // svg (SVG 文件导入)
+	TOKEN_IMAGE             // image (PNG、JPEG 图像)
+	TOKEN_GRAPH             // graph (坐标系中的函数图像)
+	TOKEN_PARAMETRIC        // parametric (参数曲线)
+	TOKEN_POLAR             // polar (极坐标曲线)
+	TOKEN_IMPLICIT          // implicit 或 contour (隐函数曲线与等高线)
+	TOKEN_VECTOR_FIELD      // vector_field (向量场)
+	TOKEN_STREAMLINES       // streamlines (流线)
+	TOKEN_AREA              // area (曲线下的面积)
+	TOKEN_RIEMANN           // riemann (黎曼和)
+	TOKEN_TANGENT           // tangent (函数图像的切线)
+	TOKEN_SECANT            // secant (函数图像的割线)
+	TOKEN_GRAPH_POINT       // graph_point (函数图像上的点)
 	TOKEN_COORDINATE_SYSTEM // coordinate_system 或 coord_system
 
 	// 动画类型
@@ -63,6 +78,8 @@ const (
 	TOKEN_COLOR    // color
 	TOKEN_PATH     // path
 	TOKEN_ELASTIC  // elastic
+	TOKEN_FLOW     // flow
+	TOKEN_VALUE    // value
 
 	// 属性
 	TOKEN_COLOR_PROP     // color
@@ -73,7 +90,6 @@ const (
 	TOKEN_HEIGHT_PROP    // height
 	TOKEN_VERTEX_PROP    // vertex (用于 vertex1, vertex2, vertex3)
 	TOKEN_VERTICES_PROP  // vertices
-	TOKEN_AREA_PROP      // area
 	TOKEN_PERIMETER_PROP // perimeter
 
 	// 运算符
@@ -141,6 +157,21 @@ var keywords = map[string]TokenType{
 	"markdown":          TOKEN_MARKDOWN,
 	"tex":               TOKEN_TEX,
 	"mathtex":           TOKEN_MATHTEX,
+	"outline":           TOKEN_OUTLINE,
+	"svg":               TOKEN_SVG,
+	"image":             TOKEN_IMAGE,
+	"graph":             TOKEN_GRAPH,
+	"parametric":        TOKEN_PARAMETRIC,
+	"polar":             TOKEN_POLAR,
+	"implicit":          TOKEN_IMPLICIT,
+	"contour":           TOKEN_IMPLICIT,
+	"vector_field":      TOKEN_VECTOR_FIELD,
+	"streamlines":       TOKEN_STREAMLINES,
+	"area":              TOKEN_AREA,
+	"riemann":           TOKEN_RIEMANN,
+	"tangent":           TOKEN_TANGENT,
+	"secant":            TOKEN_SECANT,
+	"graph_point":       TOKEN_GRAPH_POINT,
 	"coordinate_system": TOKEN_COORDINATE_SYSTEM,
 	"coord_system":      TOKEN_COORDINATE_SYSTEM,
 	"axes":              TOKEN_COORDINATE_SYSTEM,
@@ -153,6 +184,8 @@ var keywords = map[string]TokenType{
 	"colorchange":       TOKEN_COLOR,
 	"path":              TOKEN_PATH,
 	"elastic":           TOKEN_ELASTIC,
+	"flow":              TOKEN_FLOW,
+	"value":             TOKEN_VALUE,
 	"color":             TOKEN_COLOR_PROP,
 	"size":              TOKEN_SIZE_PROP,
 	"position":          TOKEN_POSITION_PROP,
@@ -163,7 +196,6 @@ var keywords = map[string]TokenType{
 	"vertex2":           TOKEN_VERTEX_PROP,
 	"vertex3":           TOKEN_VERTEX_PROP,
 	"vertices":          TOKEN_VERTICES_PROP,
-	"area":              TOKEN_AREA_PROP,
 	"perimeter":         TOKEN_PERIMETER_PROP,
 }
 
@@ -180,6 +212,12 @@ func NewLexer(input string) *Lexer {
 
 // readChar 读取下一个字符
 func (l *Lexer) readChar() {
+	// 换行符本身属于当前行，行号在读取其后的字符时才递增
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+
 	if l.readPosition >= len(l.input) {
 		l.ch = 0 // ASCII NUL 表示 EOF
 	} else {
@@ -187,13 +225,11 @@ func (l *Lexer) readChar() {
 	}
 	l.position = l.readPosition
 	l.readPosition++
-
-	if l.ch == '\n' {
-		l.line++
-		l.column = 0
-	} else {
-		l.column++
+	// 列号按字符计：UTF-8 多字节字符的后续字节不计列，中文等字符各占一列
+	if !utf8.RuneStart(l.ch) {
+		return
 	}
+	l.column++
 }
 
 // peekChar 查看下一个字符但不移动位置
@@ -273,59 +309,62 @@ func (l *Lexer) NextToken() Token {
 
 	l.skipWhitespace()
 
+	// 记录标记起始位置，多字符标记读完后行列已经移动
+	line, column := l.line, l.column
+
 	switch l.ch {
 	case '=':
-		tok = Token{Type: TOKEN_ASSIGN, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_ASSIGN, Literal: string(l.ch), Line: line, Column: column}
 	case '+':
-		tok = Token{Type: TOKEN_PLUS, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_PLUS, Literal: string(l.ch), Line: line, Column: column}
 	case '-':
 		// 检查下一个字符是否是数字，如果是则视为负数的一部分
 		if isDigit(l.peekChar()) {
 			// 读取整个负数
 			tok.Type = TOKEN_NUMBER
 			tok.Literal = l.readNumber()
-			tok.Line = l.line
-			tok.Column = l.column
+			tok.Line = line
+			tok.Column = column
 			return tok
 		} else {
 			// 否则视为减号操作符
-			tok = Token{Type: TOKEN_MINUS, Literal: string(l.ch), Line: l.line, Column: l.column}
+			tok = Token{Type: TOKEN_MINUS, Literal: string(l.ch), Line: line, Column: column}
 		}
 	case '*':
-		tok = Token{Type: TOKEN_MULTIPLY, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_MULTIPLY, Literal: string(l.ch), Line: line, Column: column}
 	case '/':
 		if l.peekChar() == '/' {
 			l.skipComment()
 			return l.NextToken() // 递归获取下一个标记
 		}
-		tok = Token{Type: TOKEN_DIVIDE, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_DIVIDE, Literal: string(l.ch), Line: line, Column: column}
 	case ',':
-		tok = Token{Type: TOKEN_COMMA, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_COMMA, Literal: string(l.ch), Line: line, Column: column}
 	case '(':
-		tok = Token{Type: TOKEN_LPAREN, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_LPAREN, Literal: string(l.ch), Line: line, Column: column}
 	case ')':
-		tok = Token{Type: TOKEN_RPAREN, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_RPAREN, Literal: string(l.ch), Line: line, Column: column}
 	case '{':
-		tok = Token{Type: TOKEN_LBRACE, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_LBRACE, Literal: string(l.ch), Line: line, Column: column}
 	case '}':
-		tok = Token{Type: TOKEN_RBRACE, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_RBRACE, Literal: string(l.ch), Line: line, Column: column}
 	case '[':
-		tok = Token{Type: TOKEN_LBRACKET, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_LBRACKET, Literal: string(l.ch), Line: line, Column: column}
 	case ']':
-		tok = Token{Type: TOKEN_RBRACKET, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_RBRACKET, Literal: string(l.ch), Line: line, Column: column}
 	case '.':
-		tok = Token{Type: TOKEN_DOT, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_DOT, Literal: string(l.ch), Line: line, Column: column}
 	case ':':
-		tok = Token{Type: TOKEN_COLON, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_COLON, Literal: string(l.ch), Line: line, Column: column}
 	case ';':
-		tok = Token{Type: TOKEN_SEMICOLON, Literal: string(l.ch), Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_SEMICOLON, Literal: string(l.ch), Line: line, Column: column}
 	case '\n':
-		tok = Token{Type: TOKEN_NEWLINE, Literal: "\\n", Line: l.line, Column: l.column}
+		tok = Token{Type: TOKEN_NEWLINE, Literal: "\\n", Line: line, Column: column}
 	case '"':
 		tok.Type = TOKEN_STRING
 		tok.Literal = l.readString()
-		tok.Line = l.line
-		tok.Column = l.column
+		tok.Line = line
+		tok.Column = column
 	case '#':
 		// 检查是否为注释
 		if l.peekChar() == ' ' || l.peekChar() == '\t' || isLetter(l.peekChar()) {
@@ -336,30 +375,30 @@ func (l *Lexer) NextToken() Token {
 			// 这是颜色值
 			tok.Type = TOKEN_HEX_COLOR
 			tok.Literal = l.readColor()
-			tok.Line = l.line
-			tok.Column = l.column
+			tok.Line = line
+			tok.Column = column
 			return tok // 不调用 readChar()，因为 readColor 已经处理了
 		}
 	case 0:
 		tok.Literal = ""
 		tok.Type = TOKEN_EOF
-		tok.Line = l.line
-		tok.Column = l.column
+		tok.Line = line
+		tok.Column = column
 	default:
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = lookupIdent(tok.Literal)
-			tok.Line = l.line
-			tok.Column = l.column
+			tok.Line = line
+			tok.Column = column
 			return tok // 不调用 readChar()，因为 readIdentifier 已经处理了
 		} else if isDigit(l.ch) {
 			tok.Type = TOKEN_NUMBER
 			tok.Literal = l.readNumber()
-			tok.Line = l.line
-			tok.Column = l.column
+			tok.Line = line
+			tok.Column = column
 			return tok // 不调用 readChar()，因为 readNumber 已经处理了
 		} else {
-			tok = Token{Type: TOKEN_ILLEGAL, Literal: string(l.ch), Line: l.line, Column: l.column}
+			tok = Token{Type: TOKEN_ILLEGAL, Literal: string(l.ch), Line: line, Column: column}
 		}
 	}
 
@@ -485,6 +524,34 @@ func (tt TokenType) String() string {
 		return "TEX"
 	case TOKEN_MATHTEX:
 		return "MATHTEX"
+	case TOKEN_OUTLINE:
+		return "OUTLINE"
+	case TOKEN_SVG:
+		return "SVG"
+	case TOKEN_IMAGE:
+		return "IMAGE"
+	case TOKEN_GRAPH:
+		return "GRAPH"
+	case TOKEN_PARAMETRIC:
+		return "PARAMETRIC"
+	case TOKEN_POLAR:
+		return "POLAR"
+	case TOKEN_IMPLICIT:
+		return "IMPLICIT"
+	case TOKEN_VECTOR_FIELD:
+		return "VECTOR_FIELD"
+	case TOKEN_STREAMLINES:
+		return "STREAMLINES"
+	case TOKEN_AREA:
+		return "AREA"
+	case TOKEN_RIEMANN:
+		return "RIEMANN"
+	case TOKEN_TANGENT:
+		return "TANGENT"
+	case TOKEN_SECANT:
+		return "SECANT"
+	case TOKEN_GRAPH_POINT:
+		return "GRAPH_POINT"
 	case TOKEN_MOVE:
 		return "MOVE"
 	case TOKEN_SCALE:
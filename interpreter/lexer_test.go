@@ -0,0 +1,65 @@
+package interpreter
+
+import "testing"
+
+func TestLexerPositions(t *testing.T) {
+	type position struct {
+		literal      string
+		line, column int
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []position
+	}{
+		{
+			name:  "ascii",
+			input: `create circle c 50 (0, 0)`,
+			want: []position{
+				{"create", 1, 1}, {"circle", 1, 8}, {"c", 1, 15}, {"50", 1, 17},
+				{"(", 1, 20}, {"0", 1, 21}, {",", 1, 22}, {"0", 1, 24}, {")", 1, 25},
+			},
+		},
+		{
+			name:  "columns count characters in CJK strings",
+			input: `create text t "你好世界" 24 (0, 0)`,
+			want: []position{
+				{"create", 1, 1}, {"text", 1, 8}, {"t", 1, 13}, {"你好世界", 1, 15}, {"24", 1, 22},
+				{"(", 1, 25}, {"0", 1, 26}, {",", 1, 27}, {"0", 1, 29}, {")", 1, 30},
+			},
+		},
+		{
+			name:  "accented string and comment before a newline",
+			input: "set a.text = \"é\" # 注释\nshow a",
+			want: []position{
+				{"set", 1, 1}, {"a", 1, 5}, {".", 1, 6}, {"text", 1, 7}, {"=", 1, 12}, {"é", 1, 14},
+				{"\\n", 1, 22}, {"show", 2, 1}, {"a", 2, 6},
+			},
+		},
+		{
+			name:  "new line resets the column",
+			input: "\"αβ\" x\n\t  y",
+			want: []position{
+				{"αβ", 1, 1}, {"x", 1, 6}, {"\\n", 1, 7}, {"y", 2, 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer(tt.input)
+			var got []position
+			for tok := l.NextToken(); tok.Type != TOKEN_EOF; tok = l.NextToken() {
+				got = append(got, position{tok.Literal, tok.Line, tok.Column})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d tokens %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("token %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package interpreter
 
 import (
 	"fmt"
+	"render2go/internal/i18n"
 	"strconv"
 	"strings"
 )
@@ -100,6 +101,34 @@ func (ae *ArrayExpression) String() string {
 	return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
 }
 
+// 函数调用 derivative("x^2")，函数名与左括号之间不能有空格
+type CallExpression struct {
+	Token     Token // 函数名
+	Function  string
+	Arguments []Expression
+}
+
+func (ce *CallExpression) expressionNode() {}
+func (ce *CallExpression) String() string {
+	var args []string
+	for _, a := range ce.Arguments {
+		args = append(args, a.String())
+	}
+	return fmt.Sprintf("%s(%s)", ce.Function, strings.Join(args, ", "))
+}
+
+// 命名参数 n=8
+type KeywordArgument struct {
+	Token Token // 参数名
+	Name  string
+	Value Expression
+}
+
+func (ka *KeywordArgument) expressionNode() {}
+func (ka *KeywordArgument) String() string {
+	return fmt.Sprintf("%s=%s", ka.Name, ka.Value.String())
+}
+
 // 语句类型
 
 // 场景声明语句
@@ -136,12 +165,16 @@ func (cs *CreateStatement) String() string {
 type SetStatement struct {
 	Token    Token
 	Object   *Identifier
+	Span     Expression // 富文本片段序号，如 set eq.span[2].color 中的 2；未指定时为 nil
 	Property Token
 	Value    Expression
 }
 
 func (ss *SetStatement) statementNode() {}
 func (ss *SetStatement) String() string {
+	if ss.Span != nil {
+		return fmt.Sprintf("set %s.span[%s].%s = %s", ss.Object.String(), ss.Span.String(), ss.Property.Literal, ss.Value.String())
+	}
 	return fmt.Sprintf("set %s.%s = %s", ss.Object.String(), ss.Property.Literal, ss.Value.String())
 }
 
@@ -273,14 +306,15 @@ type Parser struct {
 	curToken  Token
 	peekToken Token
 
-	errors []string
+	errors []*ParseError
+	locale i18n.Locale // 错误消息的语言，为空时使用当前语言
 }
 
 // NewParser 创建新的语法分析器
 func NewParser(l *Lexer) *Parser {
 	p := &Parser{
 		lexer:  l,
-		errors: []string{},
+		errors: []*ParseError{},
 	}
 
 	// 读取两个标记，设置 curToken 和 peekToken
@@ -290,6 +324,11 @@ func NewParser(l *Lexer) *Parser {
 	return p
 }
 
+// SetLocale 设置错误消息的语言，为空时使用当前语言
+func (p *Parser) SetLocale(l i18n.Locale) {
+	p.locale = l
+}
+
 // nextToken 移动到下一个标记
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
@@ -382,7 +421,7 @@ func (p *Parser) parseCreateStatement() *CreateStatement {
 	}
 	stmt.ObjectType = p.curToken
 
-	if !p.expectPeek(TOKEN_IDENT) {
+	if !p.expectPeekName() {
 		return nil
 	}
 	stmt.Name = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
@@ -397,6 +436,16 @@ func (p *Parser) parseCreateStatement() *CreateStatement {
 		!p.peekTokenIs(TOKEN_VIDEO) && !p.peekTokenIs(TOKEN_WAIT) && !p.peekTokenIs(TOKEN_LOOP) {
 		p.nextToken()
 		expr := p.parseExpression()
+		// 名称后紧跟 = 时为命名参数，如 n=8 method=mid
+		if ident, ok := expr.(*Identifier); ok && p.peekTokenIs(TOKEN_ASSIGN) {
+			p.nextToken()
+			p.nextToken()
+			value := p.parseExpression()
+			if value == nil {
+				continue
+			}
+			expr = &KeywordArgument{Token: ident.Token, Name: ident.Value, Value: value}
+		}
 		if expr != nil {
 			parameters = append(parameters, expr)
 		}
@@ -417,7 +466,7 @@ func (p *Parser) parseCreateStatement() *CreateStatement {
 func (p *Parser) parseSetStatement() *SetStatement {
 	stmt := &SetStatement{Token: p.curToken}
 
-	if !p.expectPeek(TOKEN_IDENT) {
+	if !p.expectPeekName() {
 		return nil
 	}
 	stmt.Object = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
@@ -429,6 +478,16 @@ func (p *Parser) parseSetStatement() *SetStatement {
 	if !p.expectPeekProperty() {
 		return nil
 	}
+
+	// 富文本片段：span[<序号>].<属性>
+	if p.curToken.Literal == "span" && p.peekTokenIs(TOKEN_LBRACKET) {
+		p.nextToken()
+		p.nextToken()
+		stmt.Span = p.parseExpression()
+		if !p.expectPeek(TOKEN_RBRACKET) || !p.expectPeek(TOKEN_DOT) || !p.expectPeekProperty() {
+			return nil
+		}
+	}
 	stmt.Property = p.curToken
 
 	if !p.expectPeek(TOKEN_ASSIGN) {
@@ -450,7 +509,7 @@ func (p *Parser) parseAnimateStatement() *AnimateStatement {
 	}
 	stmt.Animation = p.curToken
 
-	if !p.expectPeek(TOKEN_IDENT) {
+	if !p.expectPeekName() {
 		return nil
 	}
 	stmt.Object = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
@@ -467,16 +526,25 @@ func (p *Parser) parseAnimateStatement() *AnimateStatement {
 			}
 		}
 	case TOKEN_PATH:
-		// 路径动画：一个数组参数
-		if p.peekTokenIs(TOKEN_LBRACKET) {
+		// 路径动画：一个坐标数组参数，或用作运动路径的路径、曲线对象名称
+		if p.peekTokenIs(TOKEN_LBRACKET) || p.peekTokenIs(TOKEN_IDENT) || isObjectType(p.peekToken.Type) {
 			p.nextToken()
 			expr := p.parseExpression()
 			if expr != nil {
 				stmt.Parameters = append(stmt.Parameters, expr)
 			}
 		}
-	case TOKEN_ELASTIC:
-		// 弹性动画：两个参数（字符串和数字或负数）
+	case TOKEN_SCALE, TOKEN_ROTATE:
+		// 缩放和旋转动画：一个数字参数（缩放倍数或弧度，可以是负数），之后才是时长
+		if p.peekTokenIs(TOKEN_MINUS) || p.peekTokenIs(TOKEN_NUMBER) {
+			p.nextToken()
+			expr := p.parseExpression()
+			if expr != nil {
+				stmt.Parameters = append(stmt.Parameters, expr)
+			}
+		}
+	case TOKEN_ELASTIC, TOKEN_VALUE:
+		// 弹性动画和数值动画：两个参数（字符串和数字或负数）
 		// 解析第一个参数（属性名）
 		if p.peekTokenIs(TOKEN_STRING) {
 			p.nextToken()
@@ -673,9 +741,14 @@ func (p *Parser) parseLoopStatement() *LoopStatement {
 
 // parseExpression 解析表达式
 func (p *Parser) parseExpression() Expression {
-	switch p.curToken.Type {
-	case TOKEN_IDENT:
+	if p.curTokenIs(TOKEN_IDENT) || isObjectType(p.curToken.Type) {
+		if p.peekTokenIs(TOKEN_LPAREN) && p.peekToken.Line == p.curToken.Line &&
+			p.peekToken.Column == p.curToken.Column+len(p.curToken.Literal) {
+			return p.parseCallExpression()
+		}
 		return &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+	switch p.curToken.Type {
 	case TOKEN_NUMBER:
 		return p.parseNumberLiteral()
 	case TOKEN_STRING:
@@ -698,8 +771,7 @@ func (p *Parser) parseNumberLiteral() *NumberLiteral {
 
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(p.curToken, CodeInvalidNumber, "parse.invalid_number", p.curToken.Literal)
 		return nil
 	}
 
@@ -731,6 +803,14 @@ func (p *Parser) parseCoordinateExpression() *CoordinateExpression {
 	return &CoordinateExpression{X: x, Y: y}
 }
 
+// parseCallExpression 解析函数调用，当前记号为函数名
+func (p *Parser) parseCallExpression() *CallExpression {
+	call := &CallExpression{Token: p.curToken, Function: p.curToken.Literal}
+	p.nextToken()
+	call.Arguments = p.parseExpressionList(TOKEN_RPAREN)
+	return call
+}
+
 // parseArrayExpression 解析数组表达式
 func (p *Parser) parseArrayExpression() *ArrayExpression {
 	array := &ArrayExpression{Token: p.curToken}
@@ -783,21 +863,40 @@ func (p *Parser) expectPeek(t TokenType) bool {
 	}
 }
 
-func (p *Parser) expectPeekObjectType() bool {
-	types := []TokenType{TOKEN_CIRCLE, TOKEN_TRIANGLE, TOKEN_RECT, TOKEN_LINE, TOKEN_ARROW, TOKEN_POLYGON, TOKEN_TEXT, TOKEN_MARKDOWN, TOKEN_TEX, TOKEN_MATHTEX, TOKEN_COORDINATE_SYSTEM}
-	for _, t := range types {
-		if p.peekTokenIs(t) {
-			p.nextToken()
+// objectTypes create 语句可以创建的对象类型，与 objectTypeNames 一一对应
+var objectTypes = []TokenType{TOKEN_CIRCLE, TOKEN_TRIANGLE, TOKEN_RECT, TOKEN_LINE, TOKEN_ARROW, TOKEN_POLYGON, TOKEN_TEXT, TOKEN_MARKDOWN, TOKEN_TEX, TOKEN_MATHTEX, TOKEN_OUTLINE, TOKEN_PATH, TOKEN_SVG, TOKEN_IMAGE, TOKEN_COORDINATE_SYSTEM, TOKEN_GRAPH, TOKEN_PARAMETRIC, TOKEN_POLAR, TOKEN_IMPLICIT, TOKEN_VECTOR_FIELD, TOKEN_STREAMLINES, TOKEN_AREA, TOKEN_RIEMANN, TOKEN_TANGENT, TOKEN_SECANT, TOKEN_GRAPH_POINT}
+
+var objectTypeNames = []string{"circle", "triangle", "rectangle", "line", "arrow", "polygon", "text", "markdown", "tex", "mathtex", "outline", "path", "svg", "image", "coordinate_system", "graph", "parametric", "polar", "implicit", "vector_field", "streamlines", "area", "riemann", "tangent", "secant", "graph_point"}
+
+func isObjectType(t TokenType) bool {
+	for _, ot := range objectTypes {
+		if t == ot {
 			return true
 		}
 	}
+	return false
+}
 
-	typeNames := []string{"circle", "triangle", "rectangle", "line", "arrow", "polygon", "text", "markdown", "tex", "mathtex", "coordinate_system"}
-	p.errors = append(p.errors, fmt.Sprintf("行 %d: 需要对象类型（%s），但得到了 '%s'",
-		p.peekToken.Line, strings.Join(typeNames, ", "), p.peekToken.Literal))
+func (p *Parser) expectPeekObjectType() bool {
+	if isObjectType(p.peekToken.Type) {
+		p.nextToken()
+		return true
+	}
+
+	p.addError(p.peekToken, CodeExpectedObjectType, "parse.expected_object_type",
+		strings.Join(objectTypeNames, ", "), p.peekToken.Literal)
 	return false
 }
 
+// expectPeekName 期望对象名称。对象类型关键字（如 axes、text）也可以用作名称
+func (p *Parser) expectPeekName() bool {
+	if isObjectType(p.peekToken.Type) {
+		p.nextToken()
+		return true
+	}
+	return p.expectPeek(TOKEN_IDENT)
+}
+
 func (p *Parser) expectPeekProperty() bool {
 	properties := []TokenType{TOKEN_COLOR_PROP, TOKEN_SIZE_PROP, TOKEN_POSITION_PROP, TOKEN_OPACITY_PROP, TOKEN_WIDTH_PROP, TOKEN_HEIGHT_PROP, TOKEN_VERTEX_PROP, TOKEN_VERTICES_PROP}
 	for _, t := range properties {
@@ -806,23 +905,28 @@ func (p *Parser) expectPeekProperty() bool {
 			return true
 		}
 	}
-	propNames := []string{"color_prop", "size", "position", "opacity", "width", "height", "vertex1", "vertex2", "vertex3", "vertices"}
-	p.errors = append(p.errors, fmt.Sprintf("行 %d: 需要属性名（%s），但得到了 '%s'",
-		p.peekToken.Line, strings.Join(propNames, ", "), p.peekToken.Literal))
+	// 其余属性（font、weight、style 等）以普通标识符出现，由执行器按名称处理
+	if p.peekTokenIs(TOKEN_IDENT) {
+		p.nextToken()
+		return true
+	}
+	propNames := []string{"color_prop", "size", "position", "opacity", "width", "height", "vertex1", "vertex2", "vertex3", "vertices", "font", "weight", "style", "max_width", "align", "line_spacing", "anchor", "stroke_width", "filter", "crop", "resolution", "colormap", "flow_speed", "n", "method", "x", "dx", "length", "label", "x_label", "y_label", "[x_|y_]spacing", "[x_|y_]tick_step", "[x_|y_]tick_format", "[x_|y_]tick_pi", "[x_|y_]hide_zero", "[x_|y_]tick_rotation", "[x_|y_]tick_size", "span[<n>].<property>"}
+	p.addError(p.peekToken, CodeExpectedProperty, "parse.expected_property",
+		strings.Join(propNames, ", "), p.peekToken.Literal)
 	return false
 }
 
 func (p *Parser) expectPeekAnimationType() bool {
-	animations := []TokenType{TOKEN_MOVE, TOKEN_SCALE, TOKEN_ROTATE, TOKEN_FADE_IN, TOKEN_FADE_OUT, TOKEN_BOUNCE, TOKEN_COLOR, TOKEN_PATH, TOKEN_ELASTIC}
+	animations := []TokenType{TOKEN_MOVE, TOKEN_SCALE, TOKEN_ROTATE, TOKEN_FADE_IN, TOKEN_FADE_OUT, TOKEN_BOUNCE, TOKEN_COLOR, TOKEN_PATH, TOKEN_ELASTIC, TOKEN_FLOW, TOKEN_VALUE}
 	for _, t := range animations {
 		if p.peekTokenIs(t) {
 			p.nextToken()
 			return true
 		}
 	}
-	animNames := []string{"move", "scale", "rotate", "fadein", "fadeout", "bounce", "colorchange", "path", "elastic"}
-	p.errors = append(p.errors, fmt.Sprintf("行 %d: 需要动画类型（%s），但得到了 '%s'",
-		p.peekToken.Line, strings.Join(animNames, ", "), p.peekToken.Literal))
+	animNames := []string{"move", "scale", "rotate", "fadein", "fadeout", "bounce", "colorchange", "path", "elastic", "flow", "value"}
+	p.addError(p.peekToken, CodeExpectedAnimation, "parse.expected_animation",
+		strings.Join(animNames, ", "), p.peekToken.Literal)
 	return false
 }
 
@@ -831,11 +935,11 @@ func (p *Parser) peekError(t TokenType) {
 
 	switch t {
 	case TOKEN_NUMBER:
-		expected = "数字"
+		expected = i18n.Tl(p.locale, "parse.token.number")
 	case TOKEN_STRING:
-		expected = "字符串"
+		expected = i18n.Tl(p.locale, "parse.token.string")
 	case TOKEN_IDENT:
-		expected = "标识符"
+		expected = i18n.Tl(p.locale, "parse.token.identifier")
 	case TOKEN_ASSIGN:
 		expected = "="
 	case TOKEN_LPAREN:
@@ -858,28 +962,44 @@ func (p *Parser) peekError(t TokenType) {
 		expected = fmt.Sprintf("%s", t)
 	}
 
-	msg := fmt.Sprintf("行 %d: 需要 %s，但得到了 '%s'",
-		p.peekToken.Line, expected, p.peekToken.Literal)
-	p.errors = append(p.errors, msg)
+	code := CodeUnexpectedToken
+	if p.peekToken.Type == TOKEN_EOF {
+		code = CodeUnexpectedEOF
+	}
+	p.addError(p.peekToken, code, "parse.expected_token", expected, p.peekToken.Literal)
 }
 
 func (p *Parser) noPrefixParseFnError(t TokenType) {
-	var suggestion string
-
 	switch t {
 	case TOKEN_ILLEGAL:
-		suggestion = "请检查输入是否存在非法字符"
+		p.addError(p.curToken, CodeIllegalCharacter, "parse.illegal_character", p.curToken.Literal)
 	case TOKEN_EOF:
-		suggestion = "脚本意外结束"
+		p.addError(p.curToken, CodeUnexpectedEOF, "parse.unexpected_eof")
 	default:
-		suggestion = fmt.Sprintf("未知语句类型: %s", t)
+		p.addError(p.curToken, CodeUnknownStatement, "parse.unknown_statement", t)
 	}
+}
 
-	msg := fmt.Sprintf("行 %d: %s", p.curToken.Line, suggestion)
-	p.errors = append(p.errors, msg)
+// addError 记录一条位于 tok 处的语法错误，key 为消息表中的键
+func (p *Parser) addError(tok Token, code ErrorCode, key string, args ...interface{}) {
+	p.errors = append(p.errors, &ParseError{
+		Position: positionOf(tok),
+		Code:     code,
+		Message:  i18n.Tl(p.locale, key, args...),
+		locale:   p.locale,
+	})
 }
 
-// Errors 返回解析错误
+// Errors 返回解析错误的文本描述
 func (p *Parser) Errors() []string {
+	messages := make([]string, len(p.errors))
+	for i, err := range p.errors {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
+// ParseErrors 返回结构化的解析错误
+func (p *Parser) ParseErrors() ParseErrors {
 	return p.errors
 }
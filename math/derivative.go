@@ -0,0 +1,163 @@
+package math
+
+import (
+	"render2go/internal/i18n"
+)
+
+// Derivative 对表达式关于变量 variable 符号求导，返回化简后的导函数。
+// 其他变量和命名常量视为常数；floor、ceil、round、sign 的导数取 0（除间断点外成立），
+// min、max、mod 不可导，返回错误
+func Derivative(e Expr, variable string) (Expr, error) {
+	d, err := derive(e, variable)
+	if err != nil {
+		return nil, err
+	}
+	return Simplify(d), nil
+}
+
+// dependsOn 表达式是否含有变量 variable
+func dependsOn(e Expr, variable string) bool {
+	for _, name := range ExprVariables(e) {
+		if name == variable {
+			return true
+		}
+	}
+	return false
+}
+
+// lit 数字常量，求导结果中的化简留到最后统一进行
+func lit(v float64) Expr { return &Number{Value: v} }
+
+// binary 二元运算
+func binary(op byte, a, b Expr) Expr { return &Binary{Op: op, Left: a, Right: b} }
+
+// unaryCall 单参数函数调用
+func unaryCall(f string, x Expr) Expr { return &Call{Func: f, Args: []Expr{x}} }
+
+// derive 按求导法则逐层求导，结果未化简
+func derive(e Expr, v string) (Expr, error) {
+	if !dependsOn(e, v) {
+		return lit(0), nil
+	}
+	switch e := e.(type) {
+	case *Variable:
+		return lit(1), nil
+	case *Negate:
+		d, err := derive(e.X, v)
+		if err != nil {
+			return nil, err
+		}
+		return negate(d), nil
+	case *Binary:
+		return deriveBinary(e, v)
+	case *Call:
+		return deriveCall(e, v)
+	}
+	return lit(0), nil
+}
+
+func deriveBinary(e *Binary, v string) (Expr, error) {
+	u, w := e.Left, e.Right
+	du, err := derive(u, v)
+	if err != nil {
+		return nil, err
+	}
+	dw, err := derive(w, v)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Op {
+	case '+':
+		return binary('+', du, dw), nil
+	case '-':
+		return binary('-', du, dw), nil
+	case '*':
+		return binary('+', binary('*', du, w), binary('*', u, dw)), nil
+	case '/':
+		return binary('/', binary('-', binary('*', du, w), binary('*', u, dw)), binary('^', w, lit(2))), nil
+	}
+
+	// 幂：指数为常数时 (u^n)' = n*u^(n-1)*u'，底数为常数时 (a^w)' = a^w*ln(a)*w'，
+	// 否则 (u^w)' = u^w*(w'*ln(u) + w*u'/u)
+	switch {
+	case !dependsOn(w, v):
+		return binary('*', binary('*', w, binary('^', u, binary('-', w, lit(1)))), du), nil
+	case !dependsOn(u, v):
+		if base, ok := u.(*Variable); ok && base.Name == "e" {
+			return binary('*', e, dw), nil
+		}
+		return binary('*', binary('*', e, unaryCall("ln", u)), dw), nil
+	}
+	return binary('*', e, binary('+', binary('*', dw, unaryCall("ln", u)), binary('/', binary('*', w, du), u))), nil
+}
+
+func deriveCall(e *Call, v string) (Expr, error) {
+	if e.Func == "atan2" {
+		// atan2(y, x)' = (x*y' - y*x')/(x^2 + y^2)
+		y, x := e.Args[0], e.Args[1]
+		dy, err := derive(y, v)
+		if err != nil {
+			return nil, err
+		}
+		dx, err := derive(x, v)
+		if err != nil {
+			return nil, err
+		}
+		return binary('/', binary('-', binary('*', x, dy), binary('*', y, dx)), binary('+', binary('^', x, lit(2)), binary('^', y, lit(2)))), nil
+	}
+	if len(e.Args) != 1 {
+		return nil, i18n.Errorf("expr.not_differentiable", e.Func)
+	}
+
+	u := e.Args[0]
+	du, err := derive(u, v)
+	if err != nil {
+		return nil, err
+	}
+	var outer Expr // 外层函数在 u 处的导数
+	switch e.Func {
+	case "sin":
+		outer = unaryCall("cos", u)
+	case "cos":
+		outer = negate(unaryCall("sin", u))
+	case "tan":
+		outer = binary('^', unaryCall("sec", u), lit(2))
+	case "cot":
+		outer = negate(binary('^', unaryCall("csc", u), lit(2)))
+	case "sec":
+		outer = binary('*', unaryCall("sec", u), unaryCall("tan", u))
+	case "csc":
+		outer = negate(binary('*', unaryCall("csc", u), unaryCall("cot", u)))
+	case "asin":
+		outer = binary('/', lit(1), unaryCall("sqrt", binary('-', lit(1), binary('^', u, lit(2)))))
+	case "acos":
+		outer = negate(binary('/', lit(1), unaryCall("sqrt", binary('-', lit(1), binary('^', u, lit(2))))))
+	case "atan":
+		outer = binary('/', lit(1), binary('+', lit(1), binary('^', u, lit(2))))
+	case "sinh":
+		outer = unaryCall("cosh", u)
+	case "cosh":
+		outer = unaryCall("sinh", u)
+	case "tanh":
+		outer = binary('-', lit(1), binary('^', unaryCall("tanh", u), lit(2)))
+	case "exp":
+		outer = e
+	case "ln", "log":
+		outer = binary('/', lit(1), u)
+	case "log10":
+		outer = binary('/', lit(1), binary('*', u, unaryCall("ln", lit(10))))
+	case "log2":
+		outer = binary('/', lit(1), binary('*', u, unaryCall("ln", lit(2))))
+	case "sqrt":
+		outer = binary('/', lit(1), binary('*', lit(2), e))
+	case "cbrt":
+		outer = binary('/', lit(1), binary('*', lit(3), binary('^', e, lit(2))))
+	case "abs":
+		outer = unaryCall("sign", u)
+	case "floor", "ceil", "round", "sign":
+		return lit(0), nil
+	default:
+		return nil, i18n.Errorf("expr.not_differentiable", e.Func)
+	}
+	return binary('*', outer, du), nil
+}
@@ -0,0 +1,124 @@
+package math
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"x + 0", "x"},
+		{"1*x", "x"},
+		{"x^1", "x"},
+		{"x^0", "1"},
+		{"2*3 + 4", "10"},
+		{"x + x", "2*x"},
+		{"x - x", "0"},
+		{"3*x - x + 2 - 5", "2*x - 3"},
+		{"x/3 + x/6", "x / 2"},
+		{"x*0.1 + x*0.2", "3*x / 10"},
+		{"0.1 + 0.2", "3 / 10"},
+		{"x/3 - 2/3", "x / 3 - 2 / 3"},
+		{"-(2 - x)", "x - 2"},
+		{"-(x + 1)", "-x - 1"},
+		{"-(-(x + 1))", "x + 1"},
+		{"-(a + b) - (c - 2)", "-a - b - c + 2"},
+		{"2*(x + 1) - x", "x + 2"},
+		{"x*x*x", "x^3"},
+		{"x^2 / x", "x"},
+		{"x / x^3", "1 / x^2"},
+		{"(2*x)^2", "4*x^2"},
+		{"(-x)^3", "-x^3"},
+		{"sin(x)*cos(x) + cos(x)*sin(x)", "2*cos(x)*sin(x)"},
+		{"x^0.5 * x^0.25", "x^(3 / 4)"},
+		{"(x^2)^(1/3)", "x^(2 / 3)"},
+		{"x^(-1/2)", "1 / x^(1 / 2)"},
+		{"4^(1/2)", "2"},
+		{"2^0.5", "2^(1 / 2)"},
+		{"cos(0)", "1"},
+		{"sqrt(2)", "sqrt(2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := ParseExpr(tt.input)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.input, err)
+			}
+			if got := Simplify(e).String(); got != tt.want {
+				t.Errorf("Simplify(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	tests := []struct {
+		input    string
+		variable string
+		want     string
+	}{
+		{"5", "x", "0"},
+		{"x", "x", "1"},
+		{"x^3 - 2*x", "x", "3*x^2 - 2"},
+		{"x/7 + 1", "x", "1 / 7"},
+		{"x^2/3", "x", "2*x / 3"},
+		{"x^(1/3)", "x", "1 / (3*x^(2 / 3))"},
+		{"x^(2/3)", "x", "2 / (3*x^(1 / 3))"},
+		{"x^1.5", "x", "3*x^(1 / 2) / 2"},
+		{"x^0.1", "x", "1 / (10*x^(9 / 10))"},
+		{"1/x", "x", "-1 / x^2"},
+		{"sqrt(x)", "x", "1 / (2*sqrt(x))"},
+		{"sin(x)*x", "x", "x*cos(x) + sin(x)"},
+		{"sin(x)^2", "x", "2*cos(x)*sin(x)"},
+		{"exp(-x^2/2)", "x", "-x*exp(-x^2 / 2)"},
+		{"e^x", "x", "e^x"},
+		{"x^x", "x", "x^x*(ln(x) + 1)"},
+		{"a*t^2 + b*t", "t", "2*a*t + b"},
+		{"a*x^2", "a", "x^2"},
+		{"abs(x)", "x", "sign(x)"},
+		{"floor(x)", "x", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := ParseExpr(tt.input)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.input, err)
+			}
+			d, err := Derivative(e, tt.variable)
+			if err != nil {
+				t.Fatalf("Derivative(%q, %q) error: %v", tt.input, tt.variable, err)
+			}
+			if got := d.String(); got != tt.want {
+				t.Errorf("Derivative(%q, %q) = %q, want %q", tt.input, tt.variable, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerivativeErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"min(x, 1)", "min"},
+		{"max(x, 2*x)", "max"},
+		{"mod(x, 3)", "mod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := ParseExpr(tt.input)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.input, err)
+			}
+			if _, err := Derivative(e, "x"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Derivative(%q) error = %v, want it to mention %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
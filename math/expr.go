@@ -0,0 +1,519 @@
+package math
+
+import (
+	"math"
+	"render2go/internal/i18n"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Expr 数学表达式的语法树，例如 "sin(x) * x"、"x^2 - 3*x + 1"
+type Expr interface {
+	// Eval 以给定的变量值计算表达式，未知变量和定义域之外的运算得到 NaN
+	Eval(vars map[string]float64) float64
+	// String 返回表达式文本，只在必要时加括号
+	String() string
+}
+
+// Number 数字常量
+type Number struct {
+	Value float64
+}
+
+// Variable 变量或命名常量（pi、e、tau）
+type Variable struct {
+	Name string
+}
+
+// Negate 取负
+type Negate struct {
+	X Expr
+}
+
+// Binary 二元运算，Op 为 + - * / ^ 之一
+type Binary struct {
+	Op          byte
+	Left, Right Expr
+}
+
+// Call 函数调用
+type Call struct {
+	Func string
+	Args []Expr
+}
+
+// namedConstants 表达式中可以直接使用的常量
+var namedConstants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+}
+
+// exprFunction 内置函数：参数个数和实现
+type exprFunction struct {
+	arity int
+	fn    func(args []float64) float64
+}
+
+func unary(fn func(float64) float64) exprFunction {
+	return exprFunction{1, func(a []float64) float64 { return fn(a[0]) }}
+}
+
+var exprFunctions = map[string]exprFunction{
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"cot":   unary(func(x float64) float64 { return 1 / math.Tan(x) }),
+	"sec":   unary(func(x float64) float64 { return 1 / math.Cos(x) }),
+	"csc":   unary(func(x float64) float64 { return 1 / math.Sin(x) }),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"exp":   unary(math.Exp),
+	"ln":    unary(math.Log),
+	"log":   unary(math.Log),
+	"log10": unary(math.Log10),
+	"log2":  unary(math.Log2),
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"abs":   unary(math.Abs),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"round": unary(math.Round),
+	"sign": unary(func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return 0
+	}),
+	"atan2": {2, func(a []float64) float64 { return math.Atan2(a[0], a[1]) }},
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+	"mod":   {2, func(a []float64) float64 { return math.Mod(a[0], a[1]) }},
+}
+
+func (n *Number) Eval(map[string]float64) float64 { return n.Value }
+
+func (v *Variable) Eval(vars map[string]float64) float64 {
+	if value, ok := vars[v.Name]; ok {
+		return value
+	}
+	if value, ok := namedConstants[v.Name]; ok {
+		return value
+	}
+	return math.NaN()
+}
+
+func (n *Negate) Eval(vars map[string]float64) float64 { return -n.X.Eval(vars) }
+
+func (b *Binary) Eval(vars map[string]float64) float64 {
+	l, r := b.Left.Eval(vars), b.Right.Eval(vars)
+	switch b.Op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	case '/':
+		return l / r
+	case '^':
+		return math.Pow(l, r)
+	}
+	return math.NaN()
+}
+
+func (c *Call) Eval(vars map[string]float64) float64 {
+	f, ok := exprFunctions[c.Func]
+	if !ok || len(c.Args) != f.arity {
+		return math.NaN()
+	}
+	args := make([]float64, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.Eval(vars)
+	}
+	return f.fn(args)
+}
+
+// 运算符优先级，用于 String 决定是否加括号
+const (
+	precSum = iota + 1
+	precProduct
+	precUnary
+	precPower
+	precAtom
+)
+
+func precedence(e Expr) int {
+	switch e := e.(type) {
+	case *Binary:
+		switch e.Op {
+		case '+', '-':
+			return precSum
+		case '*', '/':
+			return precProduct
+		default:
+			return precPower
+		}
+	case *Negate:
+		return precUnary
+	case *Number:
+		if e.Value < 0 {
+			return precUnary
+		}
+	}
+	return precAtom
+}
+
+// wrap 当子表达式优先级低于 min 时加括号
+func wrap(e Expr, min int) string {
+	if precedence(e) < min {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+func (n *Number) String() string {
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+func (v *Variable) String() string { return v.Name }
+
+func (n *Negate) String() string { return "-" + wrap(n.X, precUnary+1) }
+
+func (b *Binary) String() string {
+	p := precedence(b)
+	switch b.Op {
+	case '^':
+		// 右结合：左侧需要更高优先级
+		return wrap(b.Left, precPower+1) + "^" + wrap(b.Right, precUnary)
+	case '-', '/':
+		// 左结合：右侧同级也要加括号
+		return wrap(b.Left, p) + " " + string(b.Op) + " " + wrap(b.Right, p+1)
+	case '*':
+		return wrap(b.Left, p) + "*" + wrap(b.Right, p+1)
+	}
+	return wrap(b.Left, p) + " " + string(b.Op) + " " + wrap(b.Right, p)
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.String()
+	}
+	return c.Func + "(" + strings.Join(args, ", ") + ")"
+}
+
+// ExprVariables 返回表达式中用到的变量名（不含命名常量），按字母排序
+func ExprVariables(e Expr) []string {
+	seen := make(map[string]bool)
+	var walk func(Expr)
+	walk = func(e Expr) {
+		switch e := e.(type) {
+		case *Variable:
+			if _, constant := namedConstants[e.Name]; !constant {
+				seen[e.Name] = true
+			}
+		case *Negate:
+			walk(e.X)
+		case *Binary:
+			walk(e.Left)
+			walk(e.Right)
+		case *Call:
+			for _, arg := range e.Args {
+				walk(arg)
+			}
+		}
+	}
+	walk(e)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseExprIn 解析表达式并检查只使用了 allowed 中的变量
+func ParseExprIn(src string, allowed ...string) (Expr, error) {
+	e, err := ParseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range ExprVariables(e) {
+		found := false
+		for _, a := range allowed {
+			if name == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i18n.Errorf("expr.unknown_variable", name, strings.Join(allowed, ", "))
+		}
+	}
+	return e, nil
+}
+
+// ParseExpr 解析数学表达式。支持 + - * / ^（右结合）、括号、一元负号、
+// 函数调用如 sin(x)、常量 pi、e、tau，以及数字与变量或括号相邻时的隐式乘法如 2x、3(x+1)
+func ParseExpr(src string) (Expr, error) {
+	p := &exprParser{src: src}
+	p.next()
+	if p.err == nil && p.tok.kind == exprEOF {
+		return nil, i18n.Errorf("expr.empty")
+	}
+	e, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil || p.tok.kind != exprEOF {
+		return nil, p.unexpected()
+	}
+	return e, nil
+}
+
+type exprTokenKind int
+
+const (
+	exprEOF exprTokenKind = iota
+	exprNumber
+	exprIdent
+	exprOp // + - * / ^ ( ) ,
+)
+
+type exprToken struct {
+	kind  exprTokenKind
+	text  string
+	value float64
+	pos   int // 从 1 开始的字符位置
+}
+
+// exprParser 递归下降解析器
+type exprParser struct {
+	src string
+	pos int
+	tok exprToken
+	err error
+}
+
+// next 读取下一个记号，出错时记录在 p.err 中并返回 EOF
+func (p *exprParser) next() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = exprToken{kind: exprEOF, pos: start + 1}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) && (isExprDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		// 科学计数法，只有 e 后面跟数字时才算，以便 2e 表示 2 乘以常量 e
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			exp := p.pos + 1
+			if exp < len(p.src) && (p.src[exp] == '+' || p.src[exp] == '-') {
+				exp++
+			}
+			if exp < len(p.src) && isExprDigit(p.src[exp]) {
+				p.pos = exp
+				for p.pos < len(p.src) && isExprDigit(p.src[p.pos]) {
+					p.pos++
+				}
+			}
+		}
+		text := p.src[start:p.pos]
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			p.err = i18n.Errorf("expr.invalid_number", text, start+1)
+			p.tok = exprToken{kind: exprEOF, pos: start + 1}
+			return
+		}
+		p.tok = exprToken{kind: exprNumber, text: text, value: value, pos: start + 1}
+	case isExprLetter(c):
+		for p.pos < len(p.src) && (isExprLetter(p.src[p.pos]) || isExprDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = exprToken{kind: exprIdent, text: p.src[start:p.pos], pos: start + 1}
+	case strings.IndexByte("+-*/^(),", c) >= 0:
+		p.pos++
+		// ** 是 ^ 的别名
+		if c == '*' && p.pos < len(p.src) && p.src[p.pos] == '*' {
+			p.pos++
+			c = '^'
+		}
+		p.tok = exprToken{kind: exprOp, text: string(c), pos: start + 1}
+	default:
+		p.err = i18n.Errorf("expr.unexpected_char", string(c), start+1)
+		p.tok = exprToken{kind: exprEOF, pos: start + 1}
+	}
+}
+
+func isExprDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isExprLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func (p *exprParser) isOp(op string) bool {
+	return p.tok.kind == exprOp && p.tok.text == op
+}
+
+// unexpected 返回当前记号处的错误
+func (p *exprParser) unexpected() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.tok.kind == exprEOF {
+		return i18n.Errorf("expr.unexpected_end")
+	}
+	return i18n.Errorf("expr.unexpected_token", p.tok.text, p.tok.pos)
+}
+
+// sum := product (('+' | '-') product)*
+func (p *exprParser) sum() (Expr, error) {
+	left, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.tok.text[0]
+		p.next()
+		right, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// product := unary (('*' | '/') unary | 隐式乘法)*
+func (p *exprParser) product() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.isOp("*") || p.isOp("/"):
+			op = p.tok.text[0]
+			p.next()
+		case p.tok.kind == exprNumber || p.tok.kind == exprIdent || p.isOp("("):
+			op = '*'
+		default:
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+}
+
+// unary := ('-' | '+') unary | power
+func (p *exprParser) unary() (Expr, error) {
+	if p.isOp("-") || p.isOp("+") {
+		negate := p.isOp("-")
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			return &Negate{X: x}, nil
+		}
+		return x, nil
+	}
+	return p.power()
+}
+
+// power := primary ('^' unary)?，右结合，-x^2 表示 -(x^2)
+func (p *exprParser) power() (Expr, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("^") {
+		p.next()
+		exponent, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: '^', Left: base, Right: exponent}, nil
+	}
+	return base, nil
+}
+
+// primary := number | ident | ident '(' args ')' | '(' sum ')'
+func (p *exprParser) primary() (Expr, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == exprNumber:
+		p.next()
+		return &Number{Value: tok.value}, nil
+	case tok.kind == exprIdent:
+		p.next()
+		f, isFunc := exprFunctions[tok.text]
+		if !p.isOp("(") {
+			if isFunc {
+				return nil, i18n.Errorf("expr.call_paren", tok.text, tok.pos)
+			}
+			return &Variable{Name: tok.text}, nil
+		}
+		if !isFunc {
+			return nil, i18n.Errorf("expr.unknown_function", tok.text, tok.pos)
+		}
+		p.next()
+		var args []Expr
+		for !p.isOp(")") {
+			arg, err := p.sum()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if !p.isOp(",") {
+				break
+			}
+			p.next()
+		}
+		if !p.isOp(")") {
+			return nil, p.unexpected()
+		}
+		p.next()
+		if len(args) != f.arity {
+			return nil, i18n.Errorf("expr.arg_count", tok.text, f.arity, len(args))
+		}
+		return &Call{Func: tok.text, Args: args}, nil
+	case p.isOp("("):
+		p.next()
+		e, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			if p.err == nil && p.tok.kind == exprEOF {
+				return nil, i18n.Errorf("expr.missing_paren", tok.pos)
+			}
+			return nil, p.unexpected()
+		}
+		p.next()
+		return e, nil
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,106 @@
+package math
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseExpr(t *testing.T) {
+	vars := map[string]float64{"x": 2, "y": 3}
+	tests := []struct {
+		src       string
+		want      string
+		value     float64
+		variables []string
+	}{
+		{"1 + 2*3", "1 + 2*3", 7, nil},
+		{"(1+2)*3", "(1 + 2)*3", 9, nil},
+		{"2^3^2", "2^3^2", 512, nil},
+		{"-x^2", "-x^2", -4, []string{"x"}},
+		{"-(-x)", "-(-x)", 2, []string{"x"}},
+		{"x - (y - 1)", "x - (y - 1)", 0, []string{"x", "y"}},
+		{"x/(y*2)", "x / (y*2)", 1.0 / 3, []string{"x", "y"}},
+		{"2x", "2*x", 4, []string{"x"}},
+		{"3(x+1)", "3*(x + 1)", 9, []string{"x"}},
+		{"x y", "x*y", 6, []string{"x", "y"}},
+		{"2 pi", "2*pi", 2 * math.Pi, nil},
+		{"e", "e", math.E, nil},
+		{"tau", "tau", 2 * math.Pi, nil},
+		{"1e3 + 2.5e-1", "1000 + 0.25", 1000.25, nil},
+		{"sin(pi/2)", "sin(pi / 2)", 1, nil},
+		{"abs(-x)", "abs(-x)", 2, []string{"x"}},
+		{"atan2(y, x)", "atan2(y, x)", math.Atan2(3, 2), []string{"x", "y"}},
+		{"max(x, y)", "max(x, y)", 3, []string{"x", "y"}},
+		{"1/0", "1 / 0", math.Inf(1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := ParseExpr(tt.src)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error: %v", tt.src, err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("ParseExpr(%q).String() = %q, want %q", tt.src, got, tt.want)
+			}
+			if got := e.Eval(vars); math.Abs(got-tt.value) > 1e-12 && got != tt.value {
+				t.Errorf("ParseExpr(%q).Eval = %g, want %g", tt.src, got, tt.value)
+			}
+			got := ExprVariables(e)
+			if len(got) == 0 {
+				got = nil
+			}
+			if !reflect.DeepEqual(got, tt.variables) {
+				t.Errorf("ExprVariables(%q) = %v, want %v", tt.src, got, tt.variables)
+			}
+		})
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"1 +",
+		"(1",
+		"x)",
+		",x",
+		"1 $ 2",
+		"1..2",
+		"sin x",
+		"foo(1)",
+		"sin(1, 2)",
+		"min(x, y, 1)",
+	}
+
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			if _, err := ParseExpr(src); err == nil {
+				t.Errorf("ParseExpr(%q) succeeded, want error", src)
+			}
+		})
+	}
+}
+
+func TestParseExprIn(t *testing.T) {
+	tests := []struct {
+		src     string
+		allowed []string
+		wantErr bool
+	}{
+		{"x^2 + 1", []string{"x"}, false},
+		{"2*pi*x", []string{"x"}, false},
+		{"x*y", []string{"x", "y"}, false},
+		{"x*y", []string{"x"}, true},
+		{"t + 1", []string{"x"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := ParseExprIn(tt.src, tt.allowed...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseExprIn(%q, %v) error = %v, want error %v", tt.src, tt.allowed, err, tt.wantErr)
+			}
+		})
+	}
+}
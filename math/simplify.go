@@ -0,0 +1,481 @@
+package math
+
+import (
+	"math"
+	"sort"
+)
+
+// Simplify 化简表达式：合并数字常量和同类项，把乘除整理为"系数 * 因子 / 分母"的形式，
+// 同底的幂合并指数，并去掉 x + 0、1*x、x^1 等平凡运算。
+// 有理数的系数和指数保持为精确的分数（如 x/3 + x/6 得到 x / 2），
+// 函数调用只在结果为整数时折叠（如 cos(0)），sqrt(2) 等保持原样
+func Simplify(e Expr) Expr {
+	switch e := e.(type) {
+	case *Negate:
+		if b, ok := e.X.(*Binary); ok && (b.Op == '+' || b.Op == '-') {
+			return simplifySum(e)
+		}
+		return simplifyProduct(e)
+	case *Binary:
+		switch e.Op {
+		case '+', '-':
+			return simplifySum(e)
+		case '^':
+			return simplifyPower(Simplify(e.Left), Simplify(e.Right))
+		}
+		return simplifyProduct(e)
+	case *Call:
+		args := make([]Expr, len(e.Args))
+		numeric := true
+		for i, arg := range e.Args {
+			args[i] = Simplify(arg)
+			_, isNumber := args[i].(*Number)
+			numeric = numeric && isNumber
+		}
+		call := &Call{Func: e.Func, Args: args}
+		if numeric {
+			if v := call.Eval(nil); exactConstant(v) {
+				return &Number{Value: v}
+			}
+		}
+		return call
+	}
+	return e
+}
+
+// exactConstant 常量折叠的结果是否精确，只折叠为整数
+func exactConstant(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0) && v == math.Trunc(v) && math.Abs(v) < 1e15
+}
+
+// numberValue 表达式是否为数字常量
+func numberValue(e Expr) (float64, bool) {
+	if n, ok := e.(*Number); ok {
+		return n.Value, true
+	}
+	return 0, false
+}
+
+// sameExpr 两个表达式在结构上是否相同
+func sameExpr(a, b Expr) bool {
+	return a.String() == b.String()
+}
+
+// fraction 有理数 num/den，den 为正整数；不能精确表示为分数的数字 den 为 1
+type fraction struct {
+	num, den float64
+}
+
+// newFraction 把数字表示为分数
+func newFraction(v float64) fraction {
+	num, den := rational(v)
+	return fraction{num, den}
+}
+
+// reduceFraction 约分并把符号放在分子上；分母过大无法精确表示时退化为小数
+func reduceFraction(num, den float64) fraction {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := gcd(math.Abs(num), den); g > 1 {
+		num, den = num/g, den/g
+	}
+	if den > 1e15 {
+		return fraction{num / den, 1}
+	}
+	return fraction{num, den}
+}
+
+func (f fraction) add(g fraction) fraction {
+	return reduceFraction(f.num*g.den+g.num*f.den, f.den*g.den)
+}
+
+func (f fraction) mul(g fraction) fraction {
+	return reduceFraction(f.num*g.num, f.den*g.den)
+}
+
+func (f fraction) div(g fraction) fraction {
+	return reduceFraction(f.num*g.den, f.den*g.num)
+}
+
+func (f fraction) neg() fraction {
+	return fraction{-f.num, f.den}
+}
+
+func (f fraction) value() float64 {
+	return f.num / f.den
+}
+
+// expr 把分数写成表达式：整数为数字，否则为 num / den
+func (f fraction) expr() Expr {
+	if f.den == 1 {
+		return &Number{Value: f.num}
+	}
+	return &Binary{Op: '/', Left: &Number{Value: f.num}, Right: &Number{Value: f.den}}
+}
+
+// fractionValue 表达式是否为有理数常量：数字、取负或数字之商
+func fractionValue(e Expr) (fraction, bool) {
+	switch e := e.(type) {
+	case *Number:
+		return newFraction(e.Value), true
+	case *Negate:
+		f, ok := fractionValue(e.X)
+		return f.neg(), ok
+	case *Binary:
+		if e.Op != '/' {
+			break
+		}
+		n, nok := numberValue(e.Left)
+		d, dok := numberValue(e.Right)
+		if nok && dok && d != 0 {
+			return newFraction(n).div(newFraction(d)), true
+		}
+	}
+	return fraction{}, false
+}
+
+// sumTerm 和式中的一项：系数乘以因子，因子为 nil 时是常数项
+type sumTerm struct {
+	coef   fraction
+	factor Expr
+}
+
+// simplifySum 展开加减运算和取负，合并同类项；常数项合并后放在最后，其余各项保持出现的顺序
+func simplifySum(e Expr) Expr {
+	var terms []sumTerm
+	constant := fraction{0, 1}
+	var collect func(e Expr, sign fraction)
+	collect = func(e Expr, sign fraction) {
+		switch b := e.(type) {
+		case *Negate:
+			collect(b.X, sign.neg())
+			return
+		case *Binary:
+			if b.Op == '+' || b.Op == '-' {
+				collect(b.Left, sign)
+				if b.Op == '-' {
+					sign = sign.neg()
+				}
+				collect(b.Right, sign)
+				return
+			}
+		}
+		coef, factor := splitCoefficient(Simplify(e))
+		coef = coef.mul(sign)
+		if factor == nil {
+			constant = constant.add(coef)
+			return
+		}
+		if b, ok := factor.(*Binary); ok && (b.Op == '+' || b.Op == '-') {
+			// 化简后仍是和式（如 2*(a + b)），把系数分配进去
+			for _, t := range sumTerms(b) {
+				collect(t.factor, coef.mul(t.coef))
+			}
+			return
+		}
+		for i := range terms {
+			if sameExpr(terms[i].factor, factor) {
+				terms[i].coef = terms[i].coef.add(coef)
+				return
+			}
+		}
+		terms = append(terms, sumTerm{coef, factor})
+	}
+	collect(e, fraction{1, 1})
+
+	var result Expr
+	add := func(coef fraction, factor Expr) {
+		if coef.num == 0 {
+			return
+		}
+		term := fraction{math.Abs(coef.num), coef.den}.expr()
+		if factor != nil {
+			term = simplifyProduct(&Binary{Op: '*', Left: term, Right: factor})
+		}
+		switch {
+		case result == nil && coef.num < 0:
+			result = negate(term)
+		case result == nil:
+			result = term
+		case coef.num < 0:
+			result = &Binary{Op: '-', Left: result, Right: term}
+		default:
+			result = &Binary{Op: '+', Left: result, Right: term}
+		}
+	}
+	for _, t := range terms {
+		add(t.coef, t.factor)
+	}
+	add(constant, nil)
+	if result == nil {
+		return &Number{Value: 0}
+	}
+	return result
+}
+
+// sumTerms 把已化简的和式拆成带符号的各项，系数为 ±1
+func sumTerms(e Expr) []sumTerm {
+	b, ok := e.(*Binary)
+	if !ok || (b.Op != '+' && b.Op != '-') {
+		return []sumTerm{{fraction{1, 1}, e}}
+	}
+	terms := sumTerms(b.Left)
+	for _, t := range sumTerms(b.Right) {
+		if b.Op == '-' {
+			t.coef = t.coef.neg()
+		}
+		terms = append(terms, t)
+	}
+	return terms
+}
+
+// negate 取负：数字直接变号，积和商把负号放在最左边的因子上，如 -2*x 而不是 -(2*x)
+func negate(e Expr) Expr {
+	switch e := e.(type) {
+	case *Number:
+		return &Number{Value: -e.Value}
+	case *Negate:
+		return e.X
+	case *Binary:
+		if e.Op == '*' || e.Op == '/' {
+			return &Binary{Op: e.Op, Left: negate(e.Left), Right: e.Right}
+		}
+	}
+	return &Negate{X: e}
+}
+
+// splitCoefficient 把已化简的项拆成有理系数和其余部分，其余部分为 nil 表示整项是常数
+func splitCoefficient(e Expr) (fraction, Expr) {
+	switch e := e.(type) {
+	case *Number:
+		return newFraction(e.Value), nil
+	case *Negate:
+		coef, rest := splitCoefficient(e.X)
+		return coef.neg(), rest
+	case *Binary:
+		switch e.Op {
+		case '*':
+			coef, rest := splitCoefficient(e.Left)
+			if rest == nil {
+				return coef, e.Right
+			}
+			return coef, &Binary{Op: '*', Left: rest, Right: e.Right}
+		case '/':
+			if d, ok := numberValue(e.Right); ok && d != 0 {
+				coef, rest := splitCoefficient(e.Left)
+				return coef.div(newFraction(d)), rest
+			}
+			coef, rest := splitCoefficient(e.Left)
+			if rest == nil {
+				rest = &Number{Value: 1}
+			}
+			return coef, &Binary{Op: '/', Left: rest, Right: e.Right}
+		}
+	}
+	return fraction{1, 1}, e
+}
+
+// productFactor 积中的一个因子 base^exponent
+type productFactor struct {
+	base     Expr
+	exponent Expr
+}
+
+// simplifyProduct 展开乘除和取负，数字合并为有理系数，同底的因子合并指数。
+// 结果中指数为负数的因子和系数的分母放在除号之后
+func simplifyProduct(e Expr) Expr {
+	num, den := 1.0, 1.0
+	var factors []productFactor
+	var collect func(e Expr, inverse bool)
+	collect = func(e Expr, inverse bool) {
+		switch b := e.(type) {
+		case *Negate:
+			num = -num
+			collect(b.X, inverse)
+			return
+		case *Binary:
+			if b.Op == '*' || b.Op == '/' {
+				collect(b.Left, inverse)
+				collect(b.Right, inverse != (b.Op == '/'))
+				return
+			}
+		}
+		s := Simplify(e)
+		if v, ok := numberValue(s); ok && (v != 0 || !inverse) {
+			n, d := rational(v)
+			if inverse {
+				n, d = d, n
+			}
+			num, den = num*n, den*d
+			return
+		}
+		if _, ok := s.(*Negate); ok {
+			collect(s, inverse)
+			return
+		}
+		if b, ok := s.(*Binary); ok && (b.Op == '*' || b.Op == '/') {
+			collect(s, inverse)
+			return
+		}
+		base, exponent := s, Expr(&Number{Value: 1})
+		if b, ok := s.(*Binary); ok && b.Op == '^' {
+			base, exponent = b.Left, b.Right
+		}
+		if inverse {
+			exponent = Simplify(negate(exponent))
+		}
+		for i := range factors {
+			if sameExpr(factors[i].base, base) {
+				factors[i].exponent = Simplify(&Binary{Op: '+', Left: factors[i].exponent, Right: exponent})
+				return
+			}
+		}
+		factors = append(factors, productFactor{base, exponent})
+	}
+	collect(e, false)
+
+	if num == 0 {
+		return &Number{Value: 0}
+	}
+	coef := reduceFraction(num, den)
+	num, den = coef.num, coef.den
+
+	// 因子按种类和文本排序，使 sin(x)*cos(x) 与 cos(x)*sin(x) 能作为同类项合并
+	sort.SliceStable(factors, func(i, j int) bool {
+		ri, rj := factorRank(factors[i].base), factorRank(factors[j].base)
+		if ri != rj {
+			return ri < rj
+		}
+		return factors[i].base.String() < factors[j].base.String()
+	})
+	var numerator, denominator []Expr
+	for _, f := range factors {
+		if q, ok := fractionValue(f.exponent); ok && q.num < 0 {
+			denominator = append(denominator, simplifyPower(f.base, q.neg().expr()))
+			continue
+		}
+		p := simplifyPower(f.base, f.exponent)
+		if v, ok := numberValue(p); ok {
+			num *= v
+			continue
+		}
+		numerator = append(numerator, p)
+	}
+
+	sign := 1.0
+	if num < 0 {
+		sign, num = -1, -num
+	}
+	if num != 1 || len(numerator) == 0 {
+		numerator = append([]Expr{&Number{Value: num}}, numerator...)
+	}
+	if den != 1 {
+		denominator = append([]Expr{&Number{Value: den}}, denominator...)
+	}
+	result := chain(numerator)
+	if sign < 0 {
+		result = negate(result)
+	}
+	if len(denominator) > 0 {
+		result = &Binary{Op: '/', Left: result, Right: chain(denominator)}
+	}
+	return result
+}
+
+// chain 把因子依次相乘
+func chain(factors []Expr) Expr {
+	result := factors[0]
+	for _, f := range factors[1:] {
+		result = &Binary{Op: '*', Left: result, Right: f}
+	}
+	return result
+}
+
+// factorRank 因子在积中的排列顺序：变量、以数字为底的幂、和式、函数调用
+func factorRank(base Expr) int {
+	switch base.(type) {
+	case *Variable:
+		return 0
+	case *Number:
+		return 1
+	case *Binary:
+		return 2
+	}
+	return 3
+}
+
+// rational 把数字表示为分母不超过 1000 的分数，不能精确表示时分母为 1
+func rational(v float64) (float64, float64) {
+	if v == math.Trunc(v) {
+		return v, 1
+	}
+	for d := 2.0; d <= 1000; d++ {
+		if n := v * d; math.Abs(n-math.Round(n)) < 1e-9 {
+			return math.Round(n), d
+		}
+	}
+	return v, 1
+}
+
+// gcd 两个非负整数的最大公约数，非整数时返回 1
+func gcd(a, b float64) float64 {
+	if a != math.Trunc(a) || b != math.Trunc(b) {
+		return 1
+	}
+	for b != 0 {
+		a, b = b, math.Mod(a, b)
+	}
+	return a
+}
+
+// simplifyPower 化简 base^exponent，base 和 exponent 已化简；有理数指数写成分数
+func simplifyPower(base, exponent Expr) Expr {
+	q, expNumber := fractionValue(exponent)
+	exp := q.value()
+	b, baseNumber := numberValue(base)
+	switch {
+	case expNumber && exp == 0:
+		return &Number{Value: 1}
+	case expNumber && exp == 1:
+		return base
+	case baseNumber && b == 1:
+		return &Number{Value: 1}
+	case baseNumber && b == 0 && expNumber && exp > 0:
+		return &Number{Value: 0}
+	case baseNumber && expNumber:
+		if v := math.Pow(b, exp); exactConstant(v) {
+			return &Number{Value: v}
+		}
+	}
+	if expNumber && exp == math.Trunc(exp) {
+		switch inner := base.(type) {
+		case *Binary:
+			// 整数次幂分配到积和商的各因子上
+			if inner.Op == '*' || inner.Op == '/' {
+				return simplifyProduct(&Binary{Op: inner.Op,
+					Left:  &Binary{Op: '^', Left: inner.Left, Right: exponent},
+					Right: &Binary{Op: '^', Left: inner.Right, Right: exponent}})
+			}
+		case *Negate:
+			p := simplifyPower(inner.X, exponent)
+			if math.Mod(exp, 2) == 0 {
+				return p
+			}
+			return negate(p)
+		}
+	}
+	// (u^a)^b = u^(a*b)，只在 a 为整数时成立
+	if inner, ok := base.(*Binary); ok && inner.Op == '^' && expNumber {
+		if a, ok := fractionValue(inner.Right); ok && a.den == 1 && a.num == math.Trunc(a.num) {
+			return simplifyPower(inner.Left, a.mul(q).expr())
+		}
+	}
+	if expNumber && exp < 0 {
+		return &Binary{Op: '/', Left: &Number{Value: 1}, Right: simplifyPower(base, q.neg().expr())}
+	}
+	if expNumber {
+		exponent = q.expr()
+	}
+	return &Binary{Op: '^', Left: base, Right: exponent}
+}
@@ -0,0 +1,610 @@
+// Package mathtex 是不依赖外部 TeX 安装的纯 Go 数学公式排版器。
+//
+// 支持 LaTeX 数学模式的常用子集：分式（\frac）、上下标、根式（\sqrt、\sqrt[n]）、
+// 希腊字母、常用运算符和关系符、函数名（\sin、\lim 等）、\left...\right 定界符、
+// \text，以及 matrix、pmatrix、bmatrix、Bmatrix、vmatrix、Vmatrix 和 cases 环境。
+// 排版结果是由字符、实心矩形和折线组成的 Box，字符通过 fonts 注册表绘制。
+package mathtex
+
+import (
+	"math"
+	"render2go/fonts"
+	gmMath "render2go/math"
+
+	"golang.org/x/image/font"
+)
+
+// Glyph 排版后的单个字符，坐标以公式基线左端为原点，Y 轴向上
+type Glyph struct {
+	Rune rune
+	X, Y float64 // 字符基线起点
+	Size float64
+	Font fonts.Spec
+}
+
+// Face 返回绘制该字符使用的字形，与排版时测量使用的字形一致
+func (g Glyph) Face() (font.Face, error) {
+	return fonts.Default().Face(g.Font, g.Size, string(g.Rune))
+}
+
+// Rule 实心矩形（分数线、根号上横线），X、Y 为左下角
+type Rule struct {
+	X, Y, Width, Height float64
+}
+
+// Stroke 折线描边（根号、可伸缩定界符）
+type Stroke struct {
+	Points []gmMath.Vector2
+	Width  float64
+}
+
+// Box 排版盒子，Height 为基线以上高度，Depth 为基线以下深度
+type Box struct {
+	Width, Height, Depth float64
+
+	Glyphs  []Glyph
+	Rules   []Rule
+	Strokes []Stroke
+}
+
+// place 将 other 平移 (dx, dy) 后并入 b，同时扩展 b 的高度和深度（宽度由调用方维护）
+func (b *Box) place(other *Box, dx, dy float64) {
+	for _, g := range other.Glyphs {
+		g.X += dx
+		g.Y += dy
+		b.Glyphs = append(b.Glyphs, g)
+	}
+	for _, r := range other.Rules {
+		r.X += dx
+		r.Y += dy
+		b.Rules = append(b.Rules, r)
+	}
+	for _, s := range other.Strokes {
+		points := make([]gmMath.Vector2, len(s.Points))
+		for i, p := range s.Points {
+			points[i] = gmMath.Vector2{X: p.X + dx, Y: p.Y + dy}
+		}
+		b.Strokes = append(b.Strokes, Stroke{Points: points, Width: s.Width})
+	}
+	b.Height = math.Max(b.Height, other.Height+dy)
+	b.Depth = math.Max(b.Depth, other.Depth-dy)
+}
+
+// Options 排版选项
+type Options struct {
+	Size     float64 // 基准字号（像素）
+	Family   string  // 字体族，为空时使用默认字体
+	TextMode bool    // 文本模式：只有 $...$ 内按公式排版
+}
+
+// Layout 解析并排版公式
+func Layout(src string, opts Options) (*Box, error) {
+	var tree node
+	var err error
+	if opts.TextMode {
+		tree, err = parseText(src)
+	} else {
+		tree, err = parse(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l := &layouter{family: opts.Family, base: opts.Size}
+	box, _ := l.layout(tree, style{size: opts.Size, display: !opts.TextMode})
+	return box, nil
+}
+
+// style 当前排版样式
+type style struct {
+	size      float64
+	script    int  // 上下标层级
+	display   bool // 显示模式：大型运算符放大、上下限居中
+	fracDepth int  // 分式嵌套层数
+}
+
+// layouter 排版器
+type layouter struct {
+	family string
+	base   float64
+}
+
+// scriptStyle 上下标使用的样式
+func (l *layouter) scriptStyle(st style) style {
+	st.script++
+	if st.script == 1 {
+		st.size = l.base * 0.7
+	} else {
+		st.size = l.base * 0.5
+	}
+	return st
+}
+
+// 排版常量，单位为 em
+const (
+	axisHeight    = 0.25 // 数学轴（分数线、减号中心）高度
+	ruleThickness = 0.05
+	fracGap       = 0.12
+	scriptGap     = 0.1
+)
+
+// thickness 线条粗细，至少一个像素
+func thickness(st style) float64 {
+	return math.Max(1, ruleThickness*st.size)
+}
+
+// layout 排版节点，返回盒子和用于计算间距的原子类别
+func (l *layouter) layout(n node, st style) (*Box, atomClass) {
+	switch n := n.(type) {
+	case *groupNode:
+		return l.layoutList(n.items, st), classOrd
+	case *symbolNode:
+		return l.layoutSymbol(n, st), n.class
+	case *textNode:
+		return l.layoutText(n.text, n.bold, st), n.class
+	case *spaceNode:
+		return &Box{Width: n.em * st.size}, classOrd
+	case *fracNode:
+		return l.layoutFrac(n, st), classInner
+	case *scriptsNode:
+		return l.layoutScripts(n, st)
+	case *sqrtNode:
+		return l.layoutSqrt(n, st), classOrd
+	case *matrixNode:
+		return l.layoutMatrix(n, st), classInner
+	case *delimNode:
+		return l.layoutDelim(n, st), classInner
+	}
+	return &Box{}, classOrd
+}
+
+// layoutList 横向排列原子，按类别插入间距
+func (l *layouter) layoutList(items []node, st style) *Box {
+	box := &Box{}
+	prev := atomClass(-1)
+	for _, item := range items {
+		child, class := l.layout(item, st)
+
+		// 出现在开头或运算符之后的二元运算符按普通符号处理（如负号）
+		if class == classBin {
+			switch prev {
+			case -1, classBin, classOp, classRel, classOpen, classPunct:
+				class = classOrd
+			}
+		}
+		if prev >= 0 {
+			box.Width += spacing(prev, class, st) * st.size
+		}
+
+		box.place(child, box.Width, 0)
+		box.Width += child.Width
+		prev = class
+	}
+	return box
+}
+
+// spacing 相邻原子之间的间距（em），上下标中省略中等和较宽间距
+func spacing(left, right atomClass, st style) float64 {
+	const thin, medium, thick = 3.0 / 18, 4.0 / 18, 5.0 / 18
+	inScript := st.script > 0
+
+	switch {
+	case left == classBin || right == classBin:
+		if inScript {
+			return 0
+		}
+		return medium
+	case left == classRel || right == classRel:
+		if inScript || left == classOpen || right == classClose || right == classPunct {
+			return 0
+		}
+		return thick
+	case left == classPunct:
+		if inScript {
+			return 0
+		}
+		return thin
+	case left == classOp && (right == classOrd || right == classOp || right == classInner):
+		return thin
+	case right == classOp && (left == classOrd || left == classClose || left == classInner):
+		return thin
+	case left == classInner && right == classOrd, left == classOrd && right == classInner:
+		if inScript {
+			return 0
+		}
+		return thin
+	}
+	return 0
+}
+
+// glyph 排版单个字符，返回的盒子使用字形的墨迹高度和深度
+func (l *layouter) glyph(r rune, spec fonts.Spec, size float64) *Box {
+	g := Glyph{Rune: r, Size: size, Font: spec}
+	box := &Box{Glyphs: []Glyph{g}}
+
+	face, err := g.Face()
+	if err != nil {
+		return box
+	}
+	if advance, ok := face.GlyphAdvance(r); ok {
+		box.Width = float64(advance) / 64
+	}
+	if bounds, _, ok := face.GlyphBounds(r); ok {
+		box.Height = math.Max(0, -float64(bounds.Min.Y)/64)
+		box.Depth = math.Max(0, float64(bounds.Max.Y)/64)
+	}
+	return box
+}
+
+func (l *layouter) spec(italic, bold bool) fonts.Spec {
+	spec := fonts.Spec{Family: l.family, Weight: fonts.Regular, Style: fonts.Normal}
+	if italic {
+		spec.Style = fonts.Italic
+	}
+	if bold {
+		spec.Weight = fonts.Bold
+	}
+	return spec
+}
+
+// layoutSymbol 排版符号，显示模式下的大型运算符放大并以数学轴为中心
+func (l *layouter) layoutSymbol(n *symbolNode, st style) *Box {
+	if n.class != classOp || !st.display || st.script > 0 {
+		return l.glyph(n.r, l.spec(n.italic, false), st.size)
+	}
+
+	g := l.glyph(n.r, l.spec(false, false), st.size*1.6)
+	shift := axisHeight*st.size - (g.Height-g.Depth)/2
+	box := &Box{Width: g.Width}
+	box.place(g, 0, shift)
+	return box
+}
+
+// layoutText 排版正体文本
+func (l *layouter) layoutText(text string, bold bool, st style) *Box {
+	box := &Box{}
+	spec := l.spec(false, bold)
+	for _, r := range text {
+		g := l.glyph(r, spec, st.size)
+		box.place(g, box.Width, 0)
+		box.Width += g.Width
+	}
+	return box
+}
+
+// layoutFrac 排版分式：分数线位于数学轴上，分子分母水平居中
+func (l *layouter) layoutFrac(n *fracNode, st style) *Box {
+	inner := st
+	inner.fracDepth++
+	if st.fracDepth > 0 || !st.display {
+		inner = l.scriptStyle(inner)
+	}
+
+	num, _ := l.layout(n.num, inner)
+	den, _ := l.layout(n.den, inner)
+
+	t := thickness(st)
+	gap := fracGap * st.size
+	pad := 0.1 * st.size
+	axis := axisHeight * st.size
+	width := math.Max(num.Width, den.Width) + 2*pad
+
+	box := &Box{Width: width}
+	box.place(num, (width-num.Width)/2, axis+t/2+gap+num.Depth)
+	box.place(den, (width-den.Width)/2, axis-t/2-gap-den.Height)
+	box.Rules = append(box.Rules, Rule{X: pad / 2, Y: axis - t/2, Width: width - pad, Height: t})
+	return box
+}
+
+// layoutScripts 排版上下标；显示模式下带上下限的运算符把上下标放在正上方和正下方
+func (l *layouter) layoutScripts(n *scriptsNode, st style) (*Box, atomClass) {
+	base, class := l.layout(n.base, st)
+	scriptSt := l.scriptStyle(st)
+
+	var sup, sub *Box
+	if n.sup != nil {
+		sup, _ = l.layout(n.sup, scriptSt)
+	}
+	if n.sub != nil {
+		sub, _ = l.layout(n.sub, scriptSt)
+	}
+
+	if st.display && st.script == 0 && hasLimits(n.base) {
+		return l.layoutLimits(base, sup, sub, st), class
+	}
+
+	box := &Box{}
+	box.place(base, 0, 0)
+	x := base.Width + 0.05*st.size
+	width := base.Width
+
+	supShift, subShift := 0.0, 0.0
+	if sup != nil {
+		supShift = math.Max(0.4*st.size, base.Height-0.4*sup.Height)
+	}
+	if sub != nil {
+		subShift = math.Max(0.2*st.size, base.Depth+0.5*sub.Height-0.2*st.size)
+	}
+	if sup != nil && sub != nil {
+		// 上下标之间至少留出一定间隙
+		if gap := (supShift - sup.Depth) - (sub.Height - subShift); gap < scriptGap*st.size {
+			subShift += scriptGap*st.size - gap
+		}
+	}
+
+	if sup != nil {
+		box.place(sup, x, supShift)
+		width = math.Max(width, x+sup.Width)
+	}
+	if sub != nil {
+		box.place(sub, x, -subShift)
+		width = math.Max(width, x+sub.Width)
+	}
+	box.Width = width
+	return box, class
+}
+
+// hasLimits 判断节点是否为带上下限的运算符
+func hasLimits(n node) bool {
+	switch n := n.(type) {
+	case *symbolNode:
+		return n.limits
+	case *textNode:
+		return n.limits
+	}
+	return false
+}
+
+// layoutLimits 将上下限放在运算符正上方和正下方
+func (l *layouter) layoutLimits(base, sup, sub *Box, st style) *Box {
+	gap := scriptGap * st.size
+	width := base.Width
+	if sup != nil {
+		width = math.Max(width, sup.Width)
+	}
+	if sub != nil {
+		width = math.Max(width, sub.Width)
+	}
+
+	box := &Box{Width: width}
+	box.place(base, (width-base.Width)/2, 0)
+	if sup != nil {
+		box.place(sup, (width-sup.Width)/2, base.Height+gap+sup.Depth)
+	}
+	if sub != nil {
+		box.place(sub, (width-sub.Width)/2, -(base.Depth + gap + sub.Height))
+	}
+	return box
+}
+
+// layoutSqrt 排版根式：折线根号加上横线，可选的根指数放在根号左上方
+func (l *layouter) layoutSqrt(n *sqrtNode, st style) *Box {
+	body, _ := l.layout(n.body, st)
+
+	t := thickness(st)
+	gap := fracGap * st.size
+	top := math.Max(body.Height, 0.7*st.size) + gap
+	bottom := math.Max(body.Depth, 0.1*st.size)
+	signWidth := 0.6 * st.size
+
+	// 根指数向左占用空间时把根号右移
+	offset := 0.0
+	var index *Box
+	if n.index != nil {
+		indexSt := l.scriptStyle(l.scriptStyle(st))
+		index, _ = l.layout(n.index, indexSt)
+		offset = math.Max(0, index.Width-0.45*signWidth)
+	}
+
+	x0 := offset
+	sign := Stroke{Width: t, Points: []gmMath.Vector2{
+		{X: x0, Y: 0.35 * top},
+		{X: x0 + 0.15*signWidth, Y: 0.45 * top},
+		{X: x0 + 0.45*signWidth, Y: -bottom},
+		{X: x0 + signWidth, Y: top},
+		{X: x0 + signWidth + body.Width + 0.1*st.size, Y: top},
+	}}
+
+	box := &Box{Width: x0 + signWidth + body.Width + 0.1*st.size, Height: top + t, Depth: bottom + t/2}
+	box.Strokes = append(box.Strokes, sign)
+	box.place(body, x0+signWidth, 0)
+	if index != nil {
+		box.place(index, 0, 0.5*top+index.Depth)
+	}
+	return box
+}
+
+// layoutMatrix 排版矩阵：按列对齐并以数学轴为中心，两侧绘制可伸缩定界符
+func (l *layouter) layoutMatrix(n *matrixNode, st style) *Box {
+	cellSt := st
+	cellSt.fracDepth++ // 单元格中的分式使用较小字号
+
+	cols := 0
+	for _, row := range n.rows {
+		cols = max(cols, len(row))
+	}
+	cells := make([][]*Box, len(n.rows))
+	colWidths := make([]float64, cols)
+	rowHeights := make([]float64, len(n.rows))
+	rowDepths := make([]float64, len(n.rows))
+	for i, row := range n.rows {
+		rowHeights[i], rowDepths[i] = 0.7*st.size, 0.25*st.size // 保证空行也有高度
+		for j, cell := range row {
+			b, _ := l.layout(cell, cellSt)
+			cells[i] = append(cells[i], b)
+			colWidths[j] = math.Max(colWidths[j], b.Width)
+			rowHeights[i] = math.Max(rowHeights[i], b.Height)
+			rowDepths[i] = math.Max(rowDepths[i], b.Depth)
+		}
+	}
+
+	rowGap := 0.3 * st.size
+	colGap := 0.8 * st.size
+	totalHeight := 0.0
+	for i := range n.rows {
+		totalHeight += rowHeights[i] + rowDepths[i]
+	}
+	totalHeight += rowGap * float64(max(len(n.rows)-1, 0))
+
+	top := axisHeight*st.size + totalHeight/2
+	bottom := top - totalHeight
+
+	grid := &Box{}
+	y := top
+	for i := range n.rows {
+		y -= rowHeights[i]
+		x := 0.0
+		for j := 0; j < cols; j++ {
+			if j < len(cells[i]) {
+				b := cells[i][j]
+				dx := (colWidths[j] - b.Width) / 2
+				if n.alignLeft {
+					dx = 0
+				}
+				grid.place(b, x+dx, y)
+			}
+			x += colWidths[j]
+			if j < cols-1 {
+				x += colGap
+			}
+		}
+		y -= rowDepths[i] + rowGap
+		grid.Width = x
+	}
+	grid.Height = math.Max(grid.Height, top)
+	grid.Depth = math.Max(grid.Depth, -bottom)
+
+	return l.wrapDelimiters(grid, n.left, n.right, bottom-0.1*st.size, top+0.1*st.size, st)
+}
+
+// layoutDelim 排版 \left ... \right：内容较矮时使用普通字符，较高时绘制可伸缩定界符
+func (l *layouter) layoutDelim(n *delimNode, st style) *Box {
+	body, _ := l.layout(n.body, st)
+	if body.Height+body.Depth <= 1.2*st.size {
+		box := &Box{}
+		items := []*Box{}
+		if n.left != 0 {
+			items = append(items, l.glyph(n.left, l.spec(false, false), st.size))
+		}
+		items = append(items, body)
+		if n.right != 0 {
+			items = append(items, l.glyph(n.right, l.spec(false, false), st.size))
+		}
+		for _, b := range items {
+			box.place(b, box.Width, 0)
+			box.Width += b.Width
+		}
+		return box
+	}
+
+	// 可伸缩定界符关于数学轴对称
+	axis := axisHeight * st.size
+	half := math.Max(body.Height-axis, body.Depth+axis) + 0.1*st.size
+	return l.wrapDelimiters(body, n.left, n.right, axis-half, axis+half, st)
+}
+
+// wrapDelimiters 在内容两侧绘制从 bottom 到 top 的定界符
+func (l *layouter) wrapDelimiters(body *Box, left, right rune, bottom, top float64, st style) *Box {
+	box := &Box{}
+	pad := 0.1 * st.size
+	if left != 0 {
+		d := delimiter(left, bottom, top, st)
+		box.place(d, 0, 0)
+		box.Width = d.Width + pad
+	}
+	box.place(body, box.Width, 0)
+	box.Width += body.Width
+	if right != 0 {
+		d := delimiter(right, bottom, top, st)
+		box.Width += pad
+		box.place(d, box.Width, 0)
+		box.Width += d.Width
+	}
+	return box
+}
+
+// delimiter 绘制高度从 bottom 到 top 的定界符，右定界符由左定界符镜像得到
+func delimiter(r rune, bottom, top float64, st style) *Box {
+	t := thickness(st)
+	height := top - bottom
+	mid := (top + bottom) / 2
+
+	var w float64
+	var strokes [][]gmMath.Vector2
+	mirror := false
+	switch r {
+	case '(', ')':
+		w = math.Min(0.35*st.size, 0.15*height+0.1*st.size)
+		strokes = [][]gmMath.Vector2{quadratic(
+			gmMath.Vector2{X: w, Y: top},
+			gmMath.Vector2{X: -w * 0.3, Y: mid},
+			gmMath.Vector2{X: w, Y: bottom})}
+		mirror = r == ')'
+	case '[', ']':
+		w = 0.3 * st.size
+		strokes = [][]gmMath.Vector2{{{X: w, Y: top}, {X: t, Y: top}, {X: t, Y: bottom}, {X: w, Y: bottom}}}
+		mirror = r == ']'
+	case '⌊', '⌋':
+		w = 0.3 * st.size
+		strokes = [][]gmMath.Vector2{{{X: t, Y: top}, {X: t, Y: bottom}, {X: w, Y: bottom}}}
+		mirror = r == '⌋'
+	case '⌈', '⌉':
+		w = 0.3 * st.size
+		strokes = [][]gmMath.Vector2{{{X: w, Y: top}, {X: t, Y: top}, {X: t, Y: bottom}}}
+		mirror = r == '⌉'
+	case '{', '}':
+		w = 0.4 * st.size
+		arm := math.Min(0.15*height, 0.3*st.size)
+		var points []gmMath.Vector2
+		points = append(points, quadratic(gmMath.Vector2{X: w, Y: top}, gmMath.Vector2{X: w / 2, Y: top}, gmMath.Vector2{X: w / 2, Y: top - arm})...)
+		points = append(points, quadratic(gmMath.Vector2{X: w / 2, Y: mid + arm}, gmMath.Vector2{X: w / 2, Y: mid}, gmMath.Vector2{X: 0, Y: mid})...)
+		points = append(points, quadratic(gmMath.Vector2{X: 0, Y: mid}, gmMath.Vector2{X: w / 2, Y: mid}, gmMath.Vector2{X: w / 2, Y: mid - arm})...)
+		points = append(points, quadratic(gmMath.Vector2{X: w / 2, Y: bottom + arm}, gmMath.Vector2{X: w / 2, Y: bottom}, gmMath.Vector2{X: w, Y: bottom})...)
+		strokes = [][]gmMath.Vector2{points}
+		mirror = r == '}'
+	case '⟨', '⟩':
+		w = 0.35 * st.size
+		strokes = [][]gmMath.Vector2{{{X: w, Y: top}, {X: t, Y: mid}, {X: w, Y: bottom}}}
+		mirror = r == '⟩'
+	case '|':
+		w = 0.2 * st.size
+		strokes = [][]gmMath.Vector2{{{X: w / 2, Y: top}, {X: w / 2, Y: bottom}}}
+	case '‖':
+		w = 0.35 * st.size
+		strokes = [][]gmMath.Vector2{
+			{{X: w / 4, Y: top}, {X: w / 4, Y: bottom}},
+			{{X: 3 * w / 4, Y: top}, {X: 3 * w / 4, Y: bottom}},
+		}
+	case '/':
+		w = 0.2*height + 0.1*st.size
+		strokes = [][]gmMath.Vector2{{{X: w, Y: top}, {X: 0, Y: bottom}}}
+	default:
+		return &Box{}
+	}
+
+	box := &Box{Width: w, Height: top, Depth: -bottom}
+	for _, points := range strokes {
+		if mirror {
+			for i := range points {
+				points[i].X = w - points[i].X
+			}
+		}
+		box.Strokes = append(box.Strokes, Stroke{Points: points, Width: t})
+	}
+	return box
+}
+
+// quadratic 将二次贝塞尔曲线采样为折线
+func quadratic(p0, c, p1 gmMath.Vector2) []gmMath.Vector2 {
+	const steps = 16
+	points := make([]gmMath.Vector2, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / steps
+		u := 1 - t
+		points = append(points, gmMath.Vector2{
+			X: u*u*p0.X + 2*u*t*c.X + t*t*p1.X,
+			Y: u*u*p0.Y + 2*u*t*c.Y + t*t*p1.Y,
+		})
+	}
+	return points
+}
@@ -0,0 +1,217 @@
+package mathtex
+
+import (
+	"testing"
+
+	"render2go/fonts"
+)
+
+// glyphRunes 按排版顺序拼接盒子中的字符
+func glyphRunes(b *Box) string {
+	runes := make([]rune, len(b.Glyphs))
+	for i, g := range b.Glyphs {
+		runes[i] = g.Rune
+	}
+	return string(runes)
+}
+
+// glyph 返回盒子中第 n 个（从 0 开始）字符为 r 的字形
+func glyph(t *testing.T, b *Box, r rune, n int) Glyph {
+	t.Helper()
+	for _, g := range b.Glyphs {
+		if g.Rune == r {
+			if n == 0 {
+				return g
+			}
+			n--
+		}
+	}
+	t.Fatalf("glyph %q not found in %q", r, glyphRunes(b))
+	return Glyph{}
+}
+
+func TestLayout(t *testing.T) {
+	const size = 20
+	tests := []struct {
+		name     string
+		src      string
+		textMode bool
+		runes    string
+		rules    int
+		strokes  int
+		check    func(t *testing.T, b *Box)
+	}{
+		{
+			name: "variable is italic on the baseline", src: "x", runes: "x",
+			check: func(t *testing.T, b *Box) {
+				x := glyph(t, b, 'x', 0)
+				if x.X != 0 || x.Y != 0 || x.Size != size || x.Font.Style != fonts.Italic {
+					t.Errorf("x = %+v, want italic at the origin with size %d", x, size)
+				}
+			},
+		},
+		{
+			name: "superscript is raised and smaller", src: "x^2", runes: "x2",
+			check: func(t *testing.T, b *Box) {
+				x, two := glyph(t, b, 'x', 0), glyph(t, b, '2', 0)
+				if two.Y <= 0 || two.Size >= x.Size || two.X <= x.X {
+					t.Errorf("superscript %+v is not raised, smaller and after %+v", two, x)
+				}
+				if two.Font.Style != fonts.Normal {
+					t.Errorf("digit style = %v, want upright", two.Font.Style)
+				}
+			},
+		},
+		{
+			name: "subscript is lowered", src: "x_i", runes: "xi",
+			check: func(t *testing.T, b *Box) {
+				if i := glyph(t, b, 'i', 0); i.Y >= 0 || b.Depth <= 0 {
+					t.Errorf("subscript %+v not below the baseline (depth %g)", i, b.Depth)
+				}
+			},
+		},
+		{
+			name: "nested superscripts shrink", src: "x^{2^3}", runes: "x23",
+			check: func(t *testing.T, b *Box) {
+				two, three := glyph(t, b, '2', 0), glyph(t, b, '3', 0)
+				if three.Size >= two.Size || three.Y <= two.Y {
+					t.Errorf("second-level script %+v not smaller and higher than %+v", three, two)
+				}
+			},
+		},
+		{
+			name: "fraction stacks around the rule", src: `\frac{a}{b}`, runes: "ab", rules: 1,
+			check: func(t *testing.T, b *Box) {
+				a, d, rule := glyph(t, b, 'a', 0), glyph(t, b, 'b', 0), b.Rules[0]
+				if a.Y <= rule.Y+rule.Height || d.Y >= rule.Y {
+					t.Errorf("numerator %+v and denominator %+v not on either side of %+v", a, d, rule)
+				}
+				if rule.Y <= 0 {
+					t.Errorf("fraction rule %+v not on the math axis above the baseline", rule)
+				}
+				if rule.X < 0 || rule.X+rule.Width > b.Width {
+					t.Errorf("fraction rule %+v outside the box width %g", rule, b.Width)
+				}
+			},
+		},
+		{
+			name: "nested fraction", src: `\frac{1}{\frac{2}{3}}`, runes: "123", rules: 2,
+			check: func(t *testing.T, b *Box) {
+				one, two, three := glyph(t, b, '1', 0), glyph(t, b, '2', 0), glyph(t, b, '3', 0)
+				if !(one.Y > two.Y && two.Y > three.Y) {
+					t.Errorf("glyphs not stacked top to bottom: %+v %+v %+v", one, two, three)
+				}
+			},
+		},
+		{
+			name: "square root draws the radical", src: `\sqrt{x}`, runes: "x", strokes: 1,
+			check: func(t *testing.T, b *Box) {
+				if x := glyph(t, b, 'x', 0); x.X <= 0 {
+					t.Errorf("radicand %+v not after the radical sign", x)
+				}
+			},
+		},
+		{
+			name: "root index", src: `\sqrt[3]{x}`, runes: "x3", strokes: 1,
+			check: func(t *testing.T, b *Box) {
+				x, three := glyph(t, b, 'x', 0), glyph(t, b, '3', 0)
+				if three.Size >= x.Size || three.Y <= 0 || three.X >= x.X {
+					t.Errorf("root index %+v not small, raised and before %+v", three, x)
+				}
+			},
+		},
+		{
+			name: "greek letters and operators", src: `\alpha+\beta`, runes: "α+β",
+			check: func(t *testing.T, b *Box) {
+				alpha, plus, beta := glyph(t, b, 'α', 0), glyph(t, b, '+', 0), glyph(t, b, 'β', 0)
+				if !(alpha.X < plus.X && plus.X < beta.X) {
+					t.Errorf("glyphs out of order: %+v %+v %+v", alpha, plus, beta)
+				}
+				if plus.Font.Style != fonts.Normal {
+					t.Errorf("operator style = %v, want upright", plus.Font.Style)
+				}
+			},
+		},
+		{
+			name: "function names are upright", src: `\sin x`, runes: "sinx",
+			check: func(t *testing.T, b *Box) {
+				if s := glyph(t, b, 's', 0); s.Font.Style != fonts.Normal {
+					t.Errorf("function name style = %v, want upright", s.Font.Style)
+				}
+			},
+		},
+		{
+			name: "text command keeps spaces upright", src: `\text{if } x`, runes: "if x",
+			check: func(t *testing.T, b *Box) {
+				if f := glyph(t, b, 'f', 0); f.Font.Style != fonts.Normal {
+					t.Errorf("text style = %v, want upright", f.Font.Style)
+				}
+			},
+		},
+		{
+			name: "matrix aligns rows and columns", src: `\begin{pmatrix}1&2\\3&4\end{pmatrix}`, runes: "1234", strokes: 2,
+			check: func(t *testing.T, b *Box) {
+				one, two := glyph(t, b, '1', 0), glyph(t, b, '2', 0)
+				three, four := glyph(t, b, '3', 0), glyph(t, b, '4', 0)
+				if one.Y != two.Y || three.Y != four.Y || one.Y <= three.Y {
+					t.Errorf("rows not aligned: %+v %+v / %+v %+v", one, two, three, four)
+				}
+				if one.X != three.X || two.X != four.X || one.X >= two.X {
+					t.Errorf("columns not aligned: %+v %+v / %+v %+v", one, two, three, four)
+				}
+			},
+		},
+		{
+			name: "text mode switches to math inside dollars", src: "area $x^2$", textMode: true, runes: "area x2",
+			check: func(t *testing.T, b *Box) {
+				a, x := glyph(t, b, 'a', 0), glyph(t, b, 'x', 0)
+				if a.Font.Style != fonts.Normal || x.Font.Style != fonts.Italic {
+					t.Errorf("styles = %v, %v, want upright text and italic math", a.Font.Style, x.Font.Style)
+				}
+				if two := glyph(t, b, '2', 0); two.Y <= 0 {
+					t.Errorf("superscript %+v not raised in text mode", two)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Layout(tt.src, Options{Size: size, TextMode: tt.textMode})
+			if err != nil {
+				t.Fatalf("Layout(%q) error: %v", tt.src, err)
+			}
+			if got := glyphRunes(b); got != tt.runes {
+				t.Errorf("Layout(%q) glyphs = %q, want %q", tt.src, got, tt.runes)
+			}
+			if len(b.Rules) != tt.rules || len(b.Strokes) != tt.strokes {
+				t.Errorf("Layout(%q) has %d rules and %d strokes, want %d and %d",
+					tt.src, len(b.Rules), len(b.Strokes), tt.rules, tt.strokes)
+			}
+			if b.Width <= 0 || b.Height <= 0 {
+				t.Errorf("Layout(%q) box %gx%g is empty", tt.src, b.Width, b.Height)
+			}
+			tt.check(t, b)
+		})
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	tests := []string{
+		`\frac{a}`,
+		`x^`,
+		`{x`,
+		`\foo`,
+		`\left(x`,
+		`\begin{foo}x\end{foo}`,
+		`\begin{matrix}1&2`,
+	}
+
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			if _, err := Layout(src, Options{Size: 20}); err == nil {
+				t.Errorf("Layout(%q) succeeded, want error", src)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package mathtex
+
+import (
+	"render2go/internal/i18n"
+	"strings"
+	"unicode"
+)
+
+// node 公式语法树节点
+type node interface{}
+
+// symbolNode 单个字符
+type symbolNode struct {
+	r       rune
+	class   atomClass
+	italic  bool // 变量按数学习惯用斜体
+	limits  bool
+	command string // 来源命令，普通字符为空
+}
+
+// textNode 正体文本，来自 \text、\mathrm、函数名或文本模式
+type textNode struct {
+	text   string
+	bold   bool
+	class  atomClass
+	limits bool
+}
+
+// groupNode 花括号分组或一串原子
+type groupNode struct {
+	items []node
+}
+
+// fracNode 分式
+type fracNode struct {
+	num, den node
+}
+
+// scriptsNode 带上标和/或下标的原子
+type scriptsNode struct {
+	base, sup, sub node
+}
+
+// sqrtNode 根式，index 为 nil 表示平方根
+type sqrtNode struct {
+	index, body node
+}
+
+// matrixNode 矩阵或 cases 环境
+type matrixNode struct {
+	rows        [][]node
+	left, right rune
+	alignLeft   bool // cases 环境的各列左对齐
+}
+
+// delimNode \left ... \right 包围的内容
+type delimNode struct {
+	left, right rune
+	body        node
+}
+
+// spaceNode 水平间距，单位为 em
+type spaceNode struct {
+	em float64
+}
+
+// token 公式词法单元
+type token struct {
+	kind string // "cmd"、"char" 或 "eof"
+	text string // 命令名（不含反斜杠）或字符
+	pos  int    // 在源码中的字符位置（从 1 开始）
+}
+
+// parser 递归下降解析 LaTeX 数学子集
+type parser struct {
+	src    []rune
+	pos    int
+	offset int // 文本模式下当前数学片段在整段源码中的起始位置
+}
+
+// parse 解析数学模式公式
+func parse(src string) (node, error) {
+	p := &parser{src: []rune(src)}
+	return p.parseToEnd()
+}
+
+// parseText 解析文本模式内容：$...$ 之间为数学公式，其余按正体文本排版，\$ 表示美元符号
+func parseText(src string) (node, error) {
+	runes := []rune(src)
+	group := &groupNode{}
+	var text strings.Builder
+
+	flushText := func() {
+		if text.Len() > 0 {
+			group.items = append(group.items, &textNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '$':
+			text.WriteRune('$')
+			i++
+		case runes[i] == '$':
+			end := i + 1
+			for end < len(runes) && !(runes[end] == '$' && runes[end-1] != '\\') {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, i18n.Errorf("tex.unclosed_math", i+1)
+			}
+			flushText()
+			p := &parser{src: runes[i+1 : end], offset: i + 1}
+			math, err := p.parseToEnd()
+			if err != nil {
+				return nil, err
+			}
+			group.items = append(group.items, math)
+			i = end
+		default:
+			text.WriteRune(runes[i])
+		}
+	}
+	flushText()
+	return group, nil
+}
+
+func (p *parser) parseToEnd() (node, error) {
+	items, err := p.parseList(func(t token) bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != "eof" {
+		return nil, p.unexpected(t)
+	}
+	return &groupNode{items: items}, nil
+}
+
+// next 读取下一个词法单元，跳过空白
+func (p *parser) next() token {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		return token{kind: "eof", pos: p.offset + p.pos + 1}
+	}
+
+	start := p.pos
+	ch := p.src[p.pos]
+	p.pos++
+	if ch != '\\' {
+		return token{kind: "char", text: string(ch), pos: p.offset + start + 1}
+	}
+
+	if p.pos >= len(p.src) {
+		return token{kind: "cmd", text: "", pos: p.offset + start + 1}
+	}
+	if !isLetter(p.src[p.pos]) {
+		// 单字符命令，如 \, \\ \{
+		p.pos++
+		return token{kind: "cmd", text: string(p.src[p.pos-1]), pos: p.offset + start + 1}
+	}
+	nameStart := p.pos
+	for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	return token{kind: "cmd", text: string(p.src[nameStart:p.pos]), pos: p.offset + start + 1}
+}
+
+// peek 查看下一个词法单元但不消耗
+func (p *parser) peek() token {
+	saved := p.pos
+	t := p.next()
+	p.pos = saved
+	return t
+}
+
+// parseList 解析原子序列直到 stop 返回 true 或输入结束
+func (p *parser) parseList(stop func(token) bool) ([]node, error) {
+	var items []node
+	for {
+		t := p.peek()
+		if t.kind == "eof" || stop(t) {
+			return items, nil
+		}
+		if t.kind == "char" && t.text == "}" {
+			return nil, p.unexpected(t)
+		}
+
+		var atom node
+		if t.kind == "char" && (t.text == "^" || t.text == "_") {
+			atom = &groupNode{} // 没有底数的上下标
+		} else {
+			var err error
+			if atom, err = p.parseAtom(); err != nil {
+				return nil, err
+			}
+		}
+
+		atom, err := p.parseScripts(atom)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, atom)
+	}
+}
+
+// parseScripts 解析紧跟在原子后的 ^ 和 _
+func (p *parser) parseScripts(base node) (node, error) {
+	var sup, sub node
+	for {
+		t := p.peek()
+		if t.kind != "char" || t.text != "^" && t.text != "_" {
+			break
+		}
+		p.next()
+		arg, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "^" {
+			if sup != nil {
+				return nil, i18n.Errorf("tex.double_script", "^", t.pos)
+			}
+			sup = arg
+		} else {
+			if sub != nil {
+				return nil, i18n.Errorf("tex.double_script", "_", t.pos)
+			}
+			sub = arg
+		}
+	}
+	if sup == nil && sub == nil {
+		return base, nil
+	}
+	return &scriptsNode{base: base, sup: sup, sub: sub}, nil
+}
+
+// parseArg 解析命令参数：花括号分组或单个原子
+func (p *parser) parseArg() (node, error) {
+	t := p.peek()
+	if t.kind == "eof" {
+		return nil, i18n.Errorf("tex.unexpected_end")
+	}
+	if t.kind == "char" && t.text == "{" {
+		return p.parseGroup()
+	}
+	return p.parseAtom()
+}
+
+// parseGroup 解析 {...}
+func (p *parser) parseGroup() (node, error) {
+	open := p.next()
+	items, err := p.parseList(func(t token) bool { return t.kind == "char" && t.text == "}" })
+	if err != nil {
+		return nil, err
+	}
+	if p.next().kind == "eof" {
+		return nil, i18n.Errorf("tex.unclosed_group", open.pos)
+	}
+	return &groupNode{items: items}, nil
+}
+
+// parseAtom 解析单个原子
+func (p *parser) parseAtom() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case "eof":
+		return nil, i18n.Errorf("tex.unexpected_end")
+	case "char":
+		if t.text == "{" {
+			p.pos-- // 交给 parseGroup 重新读取左花括号
+			return p.parseGroup()
+		}
+		if t.text == "&" || t.text == "}" || t.text == "$" {
+			return nil, p.unexpected(t)
+		}
+		r, class := charClass([]rune(t.text)[0])
+		return &symbolNode{r: r, class: class, italic: isLetter(r)}, nil
+	}
+	return p.parseCommand(t)
+}
+
+// parseCommand 解析反斜杠命令
+func (p *parser) parseCommand(t token) (node, error) {
+	name := t.text
+
+	switch name {
+	case "frac", "dfrac", "tfrac":
+		num, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		den, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return &fracNode{num: num, den: den}, nil
+	case "sqrt":
+		var index node
+		if next := p.peek(); next.kind == "char" && next.text == "[" {
+			p.next()
+			items, err := p.parseList(func(t token) bool { return t.kind == "char" && t.text == "]" })
+			if err != nil {
+				return nil, err
+			}
+			if p.next().kind == "eof" {
+				return nil, i18n.Errorf("tex.unclosed_group", next.pos)
+			}
+			index = &groupNode{items: items}
+		}
+		body, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return &sqrtNode{index: index, body: body}, nil
+	case "left":
+		return p.parseLeftRight(t)
+	case "begin":
+		return p.parseEnvironment(t)
+	case "text", "textrm", "mathrm", "operatorname", "mathbf", "textbf":
+		text, err := p.readRawGroup()
+		if err != nil {
+			return nil, err
+		}
+		bold := name == "mathbf" || name == "textbf"
+		class := classOrd
+		if name == "operatorname" {
+			class = classOp
+		}
+		return &textNode{text: text, bold: bold, class: class}, nil
+	case "right", "end", "\\":
+		return nil, p.unexpected(t)
+	}
+
+	if r, ok := greekLetters[name]; ok {
+		return &symbolNode{r: r, class: classOrd, italic: unicode.IsLower(r), command: name}, nil
+	}
+	if sym, ok := symbols[name]; ok {
+		return &symbolNode{r: sym.r, class: sym.class, limits: sym.limits, command: name}, nil
+	}
+	if limits, ok := functionNames[name]; ok {
+		return &textNode{text: name, class: classOp, limits: limits}, nil
+	}
+	if em, ok := spaces[name]; ok {
+		return &spaceNode{em: em}, nil
+	}
+	return nil, i18n.Errorf("tex.unknown_command", name, t.pos)
+}
+
+// readRawGroup 读取 {...} 中的原始文本（保留空格，支持嵌套花括号）
+func (p *parser) readRawGroup() (string, error) {
+	open := p.next()
+	if open.kind != "char" || open.text != "{" {
+		return "", i18n.Errorf("tex.expected_brace", open.pos)
+	}
+	start := p.pos
+	depth := 1
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				text := string(p.src[start:p.pos])
+				p.pos++
+				return text, nil
+			}
+		}
+		p.pos++
+	}
+	return "", i18n.Errorf("tex.unclosed_group", open.pos)
+}
+
+// parseDelimiter 读取 \left、\right 后的定界符，"." 表示空定界符
+func (p *parser) parseDelimiter() (rune, error) {
+	t := p.next()
+	switch t.kind {
+	case "char":
+		switch t.text {
+		case ".":
+			return 0, nil
+		case "(", ")", "[", "]", "|", "/":
+			return []rune(t.text)[0], nil
+		}
+	case "cmd":
+		if sym, ok := symbols[t.text]; ok && (sym.class == classOpen || sym.class == classClose || t.text == "|" || t.text == "vert" || t.text == "Vert") {
+			return sym.r, nil
+		}
+	case "eof":
+		return 0, i18n.Errorf("tex.unexpected_end")
+	}
+	return 0, i18n.Errorf("tex.invalid_delimiter", t.text, t.pos)
+}
+
+// parseLeftRight 解析 \left( ... \right)
+func (p *parser) parseLeftRight(start token) (node, error) {
+	left, err := p.parseDelimiter()
+	if err != nil {
+		return nil, err
+	}
+	items, err := p.parseList(func(t token) bool { return t.kind == "cmd" && t.text == "right" })
+	if err != nil {
+		return nil, err
+	}
+	if p.next().kind == "eof" {
+		return nil, i18n.Errorf("tex.missing_right", start.pos)
+	}
+	right, err := p.parseDelimiter()
+	if err != nil {
+		return nil, err
+	}
+	return &delimNode{left: left, right: right, body: &groupNode{items: items}}, nil
+}
+
+// parseEnvironment 解析 \begin{name} ... \end{name}，支持各类矩阵和 cases
+func (p *parser) parseEnvironment(start token) (node, error) {
+	name, err := p.readRawGroup()
+	if err != nil {
+		return nil, err
+	}
+	delims, ok := matrixDelimiters[name]
+	if !ok {
+		return nil, i18n.Errorf("tex.unknown_environment", name, start.pos)
+	}
+
+	m := &matrixNode{left: delims[0], right: delims[1], alignLeft: name == "cases"}
+	row := []node{}
+	isCellEnd := func(t token) bool {
+		return t.kind == "char" && t.text == "&" || t.kind == "cmd" && (t.text == "\\" || t.text == "end")
+	}
+	for {
+		items, err := p.parseList(isCellEnd)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, &groupNode{items: items})
+
+		t := p.next()
+		switch {
+		case t.kind == "eof":
+			return nil, i18n.Errorf("tex.missing_end", name, start.pos)
+		case t.kind == "char": // &
+			continue
+		case t.text == "\\":
+			m.rows = append(m.rows, row)
+			row = []node{}
+			continue
+		}
+
+		// \end{name}
+		endName, err := p.readRawGroup()
+		if err != nil {
+			return nil, err
+		}
+		if endName != name {
+			return nil, i18n.Errorf("tex.mismatched_end", name, endName, t.pos)
+		}
+		// 忽略末尾 \\ 产生的空行
+		if len(row) > 1 || len(row[0].(*groupNode).items) > 0 {
+			m.rows = append(m.rows, row)
+		}
+		return m, nil
+	}
+}
+
+// unexpected 生成意外符号错误
+func (p *parser) unexpected(t token) error {
+	if t.kind == "cmd" {
+		return i18n.Errorf("tex.unexpected_token", `\`+t.text, t.pos)
+	}
+	return i18n.Errorf("tex.unexpected_token", t.text, t.pos)
+}
+
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
@@ -0,0 +1,201 @@
+package mathtex
+
+// atomClass 原子类别，决定相邻原子之间的间距
+type atomClass int
+
+const (
+	classOrd   atomClass = iota // 普通符号：变量、数字
+	classOp                     // 大型运算符：\sum、\int、\lim
+	classBin                    // 二元运算符：+、-、\times
+	classRel                    // 关系符：=、<、\leq
+	classOpen                   // 左定界符
+	classClose                  // 右定界符
+	classPunct                  // 标点
+	classInner                  // 分式等复合结构
+)
+
+// symbol 命令对应的字符和类别
+type symbol struct {
+	r      rune
+	class  atomClass
+	limits bool // 显示模式下上下标放在正上方和正下方
+}
+
+// greekLetters 希腊字母，小写按数学习惯用斜体，大写用正体
+var greekLetters = map[string]rune{
+	"alpha": 'α', "beta": 'β', "gamma": 'γ', "delta": 'δ', "epsilon": 'ϵ',
+	"varepsilon": 'ε', "zeta": 'ζ', "eta": 'η', "theta": 'θ', "vartheta": 'ϑ',
+	"iota": 'ι', "kappa": 'κ', "lambda": 'λ', "mu": 'μ', "nu": 'ν',
+	"xi": 'ξ', "pi": 'π', "varpi": 'ϖ', "rho": 'ρ', "varrho": 'ϱ',
+	"sigma": 'σ', "varsigma": 'ς', "tau": 'τ', "upsilon": 'υ', "phi": 'ϕ',
+	"varphi": 'φ', "chi": 'χ', "psi": 'ψ', "omega": 'ω',
+	"Gamma": 'Γ', "Delta": 'Δ', "Theta": 'Θ', "Lambda": 'Λ', "Xi": 'Ξ',
+	"Pi": 'Π', "Sigma": 'Σ', "Upsilon": 'Υ', "Phi": 'Φ', "Psi": 'Ψ',
+	"Omega": 'Ω',
+}
+
+// symbols 运算符、关系符和其他符号命令
+var symbols = map[string]symbol{
+	// 二元运算符
+	"pm":     {'±', classBin, false},
+	"mp":     {'∓', classBin, false},
+	"times":  {'×', classBin, false},
+	"div":    {'÷', classBin, false},
+	"cdot":   {'⋅', classBin, false},
+	"ast":    {'∗', classBin, false},
+	"circ":   {'∘', classBin, false},
+	"bullet": {'∙', classBin, false},
+	"cup":    {'∪', classBin, false},
+	"cap":    {'∩', classBin, false},
+	"wedge":  {'∧', classBin, false},
+	"vee":    {'∨', classBin, false},
+	"oplus":  {'⊕', classBin, false},
+	"otimes": {'⊗', classBin, false},
+
+	// 关系符
+	"leq":            {'≤', classRel, false},
+	"le":             {'≤', classRel, false},
+	"geq":            {'≥', classRel, false},
+	"ge":             {'≥', classRel, false},
+	"neq":            {'≠', classRel, false},
+	"ne":             {'≠', classRel, false},
+	"approx":         {'≈', classRel, false},
+	"equiv":          {'≡', classRel, false},
+	"sim":            {'∼', classRel, false},
+	"simeq":          {'≃', classRel, false},
+	"cong":           {'≅', classRel, false},
+	"propto":         {'∝', classRel, false},
+	"ll":             {'≪', classRel, false},
+	"gg":             {'≫', classRel, false},
+	"in":             {'∈', classRel, false},
+	"notin":          {'∉', classRel, false},
+	"ni":             {'∋', classRel, false},
+	"subset":         {'⊂', classRel, false},
+	"supset":         {'⊃', classRel, false},
+	"subseteq":       {'⊆', classRel, false},
+	"supseteq":       {'⊇', classRel, false},
+	"perp":           {'⊥', classRel, false},
+	"parallel":       {'∥', classRel, false},
+	"mid":            {'∣', classRel, false},
+	"to":             {'→', classRel, false},
+	"rightarrow":     {'→', classRel, false},
+	"leftarrow":      {'←', classRel, false},
+	"gets":           {'←', classRel, false},
+	"leftrightarrow": {'↔', classRel, false},
+	"Rightarrow":     {'⇒', classRel, false},
+	"Leftarrow":      {'⇐', classRel, false},
+	"Leftrightarrow": {'⇔', classRel, false},
+	"implies":        {'⇒', classRel, false},
+	"iff":            {'⇔', classRel, false},
+	"mapsto":         {'↦', classRel, false},
+
+	// 大型运算符
+	"sum":    {'∑', classOp, true},
+	"prod":   {'∏', classOp, true},
+	"coprod": {'∐', classOp, true},
+	"bigcup": {'⋃', classOp, true},
+	"bigcap": {'⋂', classOp, true},
+	"int":    {'∫', classOp, false},
+	"iint":   {'∬', classOp, false},
+	"iiint":  {'∭', classOp, false},
+	"oint":   {'∮', classOp, false},
+
+	// 普通符号
+	"infty":    {'∞', classOrd, false},
+	"partial":  {'∂', classOrd, false},
+	"nabla":    {'∇', classOrd, false},
+	"forall":   {'∀', classOrd, false},
+	"exists":   {'∃', classOrd, false},
+	"emptyset": {'∅', classOrd, false},
+	"hbar":     {'ℏ', classOrd, false},
+	"ell":      {'ℓ', classOrd, false},
+	"angle":    {'∠', classOrd, false},
+	"degree":   {'°', classOrd, false},
+	"prime":    {'′', classOrd, false},
+	"neg":      {'¬', classOrd, false},
+	"ldots":    {'…', classInner, false},
+	"dots":     {'…', classInner, false},
+	"cdots":    {'⋯', classInner, false},
+	"vdots":    {'⋮', classOrd, false},
+	"ddots":    {'⋱', classOrd, false},
+
+	// 定界符
+	"{":      {'{', classOpen, false},
+	"}":      {'}', classClose, false},
+	"lbrace": {'{', classOpen, false},
+	"rbrace": {'}', classClose, false},
+	"langle": {'⟨', classOpen, false},
+	"rangle": {'⟩', classClose, false},
+	"lfloor": {'⌊', classOpen, false},
+	"rfloor": {'⌋', classClose, false},
+	"lceil":  {'⌈', classOpen, false},
+	"rceil":  {'⌉', classClose, false},
+	"|":      {'‖', classOrd, false},
+	"vert":   {'|', classOrd, false},
+	"Vert":   {'‖', classOrd, false},
+
+	// 转义字符
+	"%": {'%', classOrd, false},
+	"$": {'$', classOrd, false},
+	"#": {'#', classOrd, false},
+	"&": {'&', classOrd, false},
+	"_": {'_', classOrd, false},
+}
+
+// functionNames 以正体排版的函数名，值表示是否在显示模式下使用上下限位置
+var functionNames = map[string]bool{
+	"sin": false, "cos": false, "tan": false, "cot": false, "sec": false, "csc": false,
+	"arcsin": false, "arccos": false, "arctan": false,
+	"sinh": false, "cosh": false, "tanh": false,
+	"log": false, "ln": false, "lg": false, "exp": false,
+	"det": true, "dim": false, "deg": false, "arg": false, "ker": false, "gcd": true,
+	"lim": true, "liminf": true, "limsup": true,
+	"max": true, "min": true, "sup": true, "inf": true,
+}
+
+// spaces 间距命令，单位为 em
+var spaces = map[string]float64{
+	",":      3.0 / 18,
+	":":      4.0 / 18,
+	">":      4.0 / 18,
+	";":      5.0 / 18,
+	"!":      -3.0 / 18,
+	" ":      1.0 / 3,
+	"quad":   1,
+	"qquad":  2,
+	"thinsp": 3.0 / 18,
+}
+
+// matrixDelimiters 矩阵环境及其左右定界符，0 表示无定界符
+var matrixDelimiters = map[string][2]rune{
+	"matrix":  {0, 0},
+	"pmatrix": {'(', ')'},
+	"bmatrix": {'[', ']'},
+	"Bmatrix": {'{', '}'},
+	"vmatrix": {'|', '|'},
+	"Vmatrix": {'‖', '‖'},
+	"cases":   {'{', 0},
+}
+
+// charClass 普通字符在数学模式下的类别和替换字符
+func charClass(r rune) (rune, atomClass) {
+	switch r {
+	case '+':
+		return '+', classBin
+	case '-':
+		return '−', classBin // 使用真正的减号
+	case '*':
+		return '∗', classBin
+	case '=', '<', '>', ':':
+		return r, classRel
+	case '(', '[':
+		return r, classOpen
+	case ')', ']', '!', '?':
+		return r, classClose
+	case ',', ';':
+		return r, classPunct
+	case '\'':
+		return '′', classOrd
+	}
+	return r, classOrd
+}
@@ -0,0 +1,166 @@
+// Package render2go 提供在 Go 程序中嵌入 Render2Go 渲染器的稳定接口。
+//
+// 典型用法:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	result, err := render2go.Render(ctx, script, render2go.Options{
+//		Width:  1280,
+//		Height: 720,
+//		FPS:    30,
+//		Sink: render2go.FrameSinkFunc(func(index int, img image.Image) error {
+//			return encoder.Encode(img)
+//		}),
+//	})
+package render2go
+
+import (
+	"context"
+	"image"
+	"log/slog"
+	"time"
+
+	"render2go/config"
+	"render2go/internal/i18n"
+	"render2go/internal/logging"
+	"render2go/interpreter"
+	"render2go/renderer"
+)
+
+// FrameSink 序列帧输出目标
+type FrameSink = renderer.FrameSink
+
+// FrameSinkFunc 将普通函数适配为 FrameSink
+type FrameSinkFunc = renderer.FrameSinkFunc
+
+// NewDirSink 创建将帧保存为目录中 PNG 文件的输出目标
+func NewDirSink(dir string) FrameSink {
+	return renderer.NewDirSink(dir)
+}
+
+// ErrorCode 错误代码
+type ErrorCode = interpreter.ErrorCode
+
+// Position 源码位置
+type Position = interpreter.Position
+
+// ParseError 语法错误，Render 返回的错误可通过 errors.As 取出
+type ParseError = interpreter.ParseError
+
+// ParseErrors 一次解析中收集到的全部语法错误
+type ParseErrors = interpreter.ParseErrors
+
+// RuntimeError 执行错误，Render 返回的错误可通过 errors.As 取出
+type RuntimeError = interpreter.RuntimeError
+
+// UnknownObjectError 脚本引用了不存在的对象
+type UnknownObjectError = interpreter.UnknownObjectError
+
+// SetLanguage 设置错误和日志消息的默认语言（"zh-CN" 或 "en"，也接受 "en_US.UTF-8" 等写法），
+// 对之后开始的、未设置 Options.Language 的渲染生效
+func SetLanguage(lang string) error {
+	locale, err := i18n.ParseLocale(lang)
+	if err != nil {
+		return err
+	}
+	i18n.SetLocale(locale)
+	return nil
+}
+
+// Options 渲染选项，零值表示全部使用脚本中的设置
+type Options struct {
+	// Width、Height 覆盖脚本 scene 语句中的分辨率，任一为 0 时不覆盖
+	Width  int
+	Height int
+
+	// FPS 覆盖 render_frames / export 语句中的帧率，0 表示不覆盖
+	FPS int
+
+	// Sink 序列帧输出目标；为 nil 时帧写入脚本指定的目录并尝试调用 FFmpeg 生成视频，
+	// 否则所有帧交给 Sink，不再生成视频文件
+	Sink FrameSink
+
+	// Logger 状态日志，为 nil 时丢弃所有日志
+	Logger *slog.Logger
+
+	// Progress 每输出一帧调用一次，frame 从 1 开始
+	Progress func(frame, total int)
+
+	// Source 脚本来源名称，用于错误信息
+	Source string
+
+	// Config 项目配置（可由 config.Load 读取），非 nil 时只作用于本次渲染；
+	// 其中的字体路径加入进程共享的字体表
+	Config *config.Config
+
+	// Language 错误和日志消息的语言，写法与 SetLanguage 相同，为空时使用默认语言。
+	// 语言只作用于本次渲染，语言不同的渲染可以同时进行
+	Language string
+}
+
+// Result 渲染结果
+type Result struct {
+	Width   int           // 实际场景宽度
+	Height  int           // 实际场景高度
+	Frames  int           // 输出的序列帧总数
+	Image   image.Image   // 场景画布的最终图像，脚本未创建场景时为 nil
+	Elapsed time.Duration // 总耗时
+}
+
+// Render 执行脚本并渲染。ctx 取消后会在下一条语句或下一帧前停止，
+// 并返回 ctx.Err() 包装后的错误
+func Render(ctx context.Context, script string, opts Options) (Result, error) {
+	start := time.Now()
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	source := opts.Source
+	if source == "" {
+		source = "script"
+	}
+
+	// 开始时确定本次渲染的语言，之后调用 SetLanguage 不影响进行中的渲染
+	locale := i18n.CurrentLocale()
+	if opts.Language != "" {
+		var err error
+		if locale, err = i18n.ParseLocale(opts.Language); err != nil {
+			return Result{}, err
+		}
+	}
+
+	interp := interpreter.NewInterpreter(false)
+	interp.SetLogger(logger)
+	interp.SetLocale(locale)
+	evaluator := interp.GetEvaluator()
+	if opts.Config != nil {
+		rt, err := opts.Config.Runtime()
+		if err != nil {
+			return Result{}, err
+		}
+		evaluator.SetRuntime(rt)
+	}
+	evaluator.SetContext(ctx)
+	evaluator.SetResolution(opts.Width, opts.Height)
+	evaluator.SetFrameRate(opts.FPS)
+	evaluator.SetFrameSink(opts.Sink)
+	if opts.Progress != nil {
+		evaluator.SetProgressFunc(opts.Progress)
+	}
+
+	err := interp.RunString(script, source)
+
+	result := Result{
+		Frames:  evaluator.FramesRendered(),
+		Image:   evaluator.GetImage(),
+		Elapsed: time.Since(start),
+	}
+	if sc := evaluator.GetScene(); sc != nil {
+		result.Width = sc.GetWidth()
+		result.Height = sc.GetHeight()
+	}
+
+	return result, err
+}
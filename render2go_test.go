@@ -0,0 +1,274 @@
+package render2go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"render2go/config"
+	"render2go/internal/defaults"
+)
+
+func TestRenderToSink(t *testing.T) {
+	const script = `scene 160 90 "sink"
+create circle c 10 (0, 0)
+animate move c (40, 0) 1
+render_frames 10 1 "unused"
+`
+	var (
+		indices []int
+		bounds  []image.Rectangle
+	)
+	sink := FrameSinkFunc(func(index int, img image.Image) error {
+		indices = append(indices, index)
+		bounds = append(bounds, img.Bounds())
+		return nil
+	})
+	var progress []int
+	result, err := Render(context.Background(), script, Options{
+		Sink:     sink,
+		Progress: func(frame, total int) { progress = append(progress, frame) },
+		Config:   &config.Config{OutputRoot: t.TempDir()},
+	})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	if result.Width != 160 || result.Height != 90 || result.Frames != 10 {
+		t.Errorf("result = %dx%d with %d frames, want 160x90 with 10", result.Width, result.Height, result.Frames)
+	}
+	if len(indices) != 10 || len(progress) != 10 {
+		t.Fatalf("sink got %d frames and %d progress calls, want 10", len(indices), len(progress))
+	}
+	for i := range indices {
+		if indices[i] != i || progress[i] != i+1 {
+			t.Errorf("frame %d: index %d, progress %d", i, indices[i], progress[i])
+		}
+		if bounds[i] != image.Rect(0, 0, 160, 90) {
+			t.Errorf("frame %d bounds = %v, want 160x90", i, bounds[i])
+		}
+	}
+	if _, err := os.Stat("unused"); !os.IsNotExist(err) {
+		t.Errorf("frames were written to disk although a sink was set")
+	}
+}
+
+func TestRenderCancel(t *testing.T) {
+	const script = `scene 64 48 "cancel"
+create circle c 10 (0, 0)
+render_frames 10 5 "unused"
+`
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	written := 0
+	sink := FrameSinkFunc(func(index int, img image.Image) error {
+		written++
+		if written == 3 {
+			cancel()
+		}
+		return nil
+	})
+	result, err := Render(ctx, script, Options{Sink: sink, Config: &config.Config{OutputRoot: t.TempDir()}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Render error = %v, want context.Canceled", err)
+	}
+	if written != 3 || result.Frames != 3 {
+		t.Errorf("rendered %d frames (result %d) after cancelling at 3", written, result.Frames)
+	}
+}
+
+func TestRenderCancelStopsFFmpeg(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake FFmpeg is a shell script")
+	}
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "ffmpeg.pid")
+	ffmpeg := filepath.Join(dir, "ffmpeg")
+	fake := fmt.Sprintf("#!/bin/sh\necho $$ > %q\nexec sleep 30\n", pidFile)
+	if err := os.WriteFile(ffmpeg, []byte(fake), 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := fmt.Sprintf("scene 64 48 \"ffmpeg\"\ncreate circle c 10 (0, 0)\nexport %q 10 0.5\n",
+		filepath.Join(dir, "out.mp4"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// FFmpeg 启动后取消渲染
+	go func() {
+		for ctx.Err() == nil {
+			if _, err := os.Stat(pidFile); err == nil {
+				cancel()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	start := time.Now()
+	_, err := Render(ctx, script, Options{Config: &config.Config{OutputRoot: dir, FFmpegPath: ffmpeg}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Render error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Render took %v, FFmpeg was not stopped", elapsed)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("FFmpeg was not started: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if process, err := os.FindProcess(pid); err == nil && process.Signal(syscall.Signal(0)) == nil {
+		process.Kill()
+		t.Errorf("FFmpeg process %d still running after Render returned", pid)
+	}
+}
+
+func TestRenderOptionsIsolation(t *testing.T) {
+	const script = `scene 0 0 "isolation"
+create circle c 10 (0, 0)
+render_frames 0 1 "unused"
+`
+	tests := []struct {
+		name          string
+		config        config.Config
+		width, height int // Options 中的分辨率覆盖
+		wantW, wantH  int
+		wantFrames    int
+	}{
+		{"config a", config.Config{Width: 64, Height: 48, FPS: 4}, 0, 0, 64, 48, 4},
+		{"config b", config.Config{Width: 80, Height: 60, FPS: 6, Background: "#000000"}, 0, 0, 80, 60, 6},
+		{"options override", config.Config{Width: 64, Height: 48, FPS: 5}, 32, 24, 32, 24, 5},
+	}
+	before := defaults.CurrentRuntime()
+
+	// 配置不同的渲染同时进行，各自使用自己的配置
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, tt := range tests {
+			wg.Add(1)
+			go func(name string, cfg config.Config, width, height, wantW, wantH, wantFrames int) {
+				defer wg.Done()
+				cfg.OutputRoot = t.TempDir()
+				var mu sync.Mutex
+				sizes := map[image.Point]int{}
+				sink := FrameSinkFunc(func(index int, img image.Image) error {
+					mu.Lock()
+					sizes[img.Bounds().Size()]++
+					mu.Unlock()
+					return nil
+				})
+				result, err := Render(context.Background(), script, Options{
+					Width: width, Height: height, Sink: sink, Config: &cfg,
+				})
+				if err != nil {
+					t.Errorf("%s: Render error: %v", name, err)
+					return
+				}
+				if result.Width != wantW || result.Height != wantH || result.Frames != wantFrames {
+					t.Errorf("%s: result = %dx%d with %d frames, want %dx%d with %d",
+						name, result.Width, result.Height, result.Frames, wantW, wantH, wantFrames)
+				}
+				if len(sizes) != 1 || sizes[image.Pt(wantW, wantH)] != wantFrames {
+					t.Errorf("%s: frame sizes = %v, want %d frames of %dx%d", name, sizes, wantFrames, wantW, wantH)
+				}
+			}(tt.name, tt.config, tt.width, tt.height, tt.wantW, tt.wantH, tt.wantFrames)
+		}
+	}
+	wg.Wait()
+
+	if after := defaults.CurrentRuntime(); after.Width != before.Width || after.FPS != before.FPS ||
+		after.OutputRoot != before.OutputRoot || after.Background != before.Background {
+		t.Errorf("global runtime changed from %+v to %+v", before, after)
+	}
+}
+
+func TestRenderLanguage(t *testing.T) {
+	const script = "scene 64 48 \"lang\"\nset missing.color = \"red\"\n"
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"en", "runtime error (file lang.r2g, line 2"},
+		{"zh-CN", "执行错误 (文件: lang.r2g, 行: 2"},
+	}
+
+	// 语言不同的渲染同时进行，各自得到所要求语言的错误
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, tt := range tests {
+			wg.Add(1)
+			go func(language, want string) {
+				defer wg.Done()
+				_, err := Render(context.Background(), script, Options{Source: "lang.r2g", Language: language})
+				var unknown *UnknownObjectError
+				if !errors.As(err, &unknown) {
+					t.Errorf("Render(%s) error = %v, want UnknownObjectError", language, err)
+					return
+				}
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Render(%s) error = %q, want it to contain %q", language, err, want)
+				}
+			}(tt.language, tt.want)
+		}
+	}
+	wg.Wait()
+}
+
+func TestRenderVideoInstructionsLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"en", "# Video Generation Guide"},
+		{"zh-CN", "# 视频生成说明"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			dir := t.TempDir()
+			script := fmt.Sprintf("scene 64 48 \"guide\"\ncreate circle c 10 (0, 0)\nexport %q 10 0.2\n",
+				filepath.Join(dir, "out.mp4"))
+			cfg := &config.Config{OutputRoot: dir, FFmpegPath: filepath.Join(dir, "no-ffmpeg")}
+			if _, err := Render(context.Background(), script, Options{Config: cfg, Language: tt.language}); err != nil {
+				t.Fatalf("Render error: %v", err)
+			}
+
+			var found []string
+			filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err == nil && info.Name() == "VIDEO_INSTRUCTIONS.md" {
+					found = append(found, path)
+				}
+				return nil
+			})
+			if len(found) != 1 {
+				t.Fatalf("found instructions %v, want exactly one", found)
+			}
+			data, err := os.ReadFile(found[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			if !strings.HasPrefix(content, tt.want) {
+				t.Errorf("instructions start with %q, want %q", strings.SplitN(content, "\n", 2)[0], tt.want)
+			}
+			if !strings.Contains(content, "-framerate 10 -i \"frame_%06d.png\"") {
+				t.Errorf("instructions do not use the export frame rate:\n%s", content)
+			}
+		})
+	}
+}
@@ -1,15 +1,16 @@
 package renderer
 
 import (
-	"fmt"
+	"context"
 	"image"
-	"image/png"
-	"os"
-	"path/filepath"
+	"log/slog"
+	"render2go/internal/defaults"
 	"render2go/scene"
-	"time"
 )
 
+// ProgressFunc 渲染进度回调，frame 为已完成帧数，total 为总帧数
+type ProgressFunc func(frame, total int)
+
 // FrameSequenceRenderer 序列帧渲染器
 type FrameSequenceRenderer struct {
 	outputDir    string
@@ -18,20 +19,21 @@ type FrameSequenceRenderer struct {
 	currentFrame int
 	width        int
 	height       int
+	ctx          context.Context // 取消上下文
+	sink         FrameSink       // 帧输出目标
+	progress     ProgressFunc    // 进度回调
+	logger       *slog.Logger    // 状态日志
 }
 
 // NewFrameSequenceRenderer 创建新的序列帧渲染器
 func NewFrameSequenceRenderer(outputDir string, frameRate int, duration float64, width, height int) *FrameSequenceRenderer {
-	// 使用默认60fps以获得更流畅的动画效果
+	// 未指定帧率时使用内置的默认帧率
 	if frameRate <= 0 {
-		frameRate = 60
+		frameRate = defaults.BuiltinRuntime().FPS
 	}
 
 	totalFrames := int(duration * float64(frameRate))
 
-	// 创建输出目录
-	os.MkdirAll(outputDir, 0755)
-
 	return &FrameSequenceRenderer{
 		outputDir:    outputDir,
 		frameRate:    frameRate,
@@ -39,11 +41,48 @@ func NewFrameSequenceRenderer(outputDir string, frameRate int, duration float64,
 		currentFrame: 0,
 		width:        width,
 		height:       height,
+		ctx:          context.Background(),
+		sink:         NewDirSink(outputDir),
+		logger:       slog.Default(),
+	}
+}
+
+// SetLogger 设置状态日志输出
+func (fsr *FrameSequenceRenderer) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	fsr.logger = logger
+}
+
+// SetContext 设置取消上下文，上下文取消后渲染会在下一帧前停止
+func (fsr *FrameSequenceRenderer) SetContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	fsr.ctx = ctx
+}
+
+// SetSink 设置帧输出目标，为 nil 时恢复为输出目录
+func (fsr *FrameSequenceRenderer) SetSink(sink FrameSink) {
+	if sink == nil {
+		sink = NewDirSink(fsr.outputDir)
+	}
+	fsr.sink = sink
+}
+
+// SetProgressFunc 设置进度回调
+func (fsr *FrameSequenceRenderer) SetProgressFunc(fn ProgressFunc) {
+	fsr.progress = fn
 }
 
 // RenderFrame 渲染单帧
 func (fsr *FrameSequenceRenderer) RenderFrame(scn *scene.Scene, frameIndex int) error {
+	// 上下文已取消时不再渲染
+	if err := fsr.ctx.Err(); err != nil {
+		return err
+	}
+
 	// 设置场景时间
 	timePos := float64(frameIndex) / float64(fsr.frameRate)
 	scn.SetCurrentTime(timePos)
@@ -51,40 +90,14 @@ func (fsr *FrameSequenceRenderer) RenderFrame(scn *scene.Scene, frameIndex int)
 	// 渲染场景到图像
 	img := fsr.renderSceneToImage(scn)
 
-	// 保存帧图像
-	filename := fmt.Sprintf("frame_%06d.png", frameIndex)
-	filepath := filepath.Join(fsr.outputDir, filename)
-
-	return fsr.saveImage(img, filepath)
-}
-
-// RenderSequence 渲染完整序列
-func (fsr *FrameSequenceRenderer) RenderSequence(scn *scene.Scene) error {
-	fmt.Printf("🎬 开始渲染序列帧...\n")
-	fmt.Printf("   输出目录: %s\n", fsr.outputDir)
-	fmt.Printf("   帧率: %d fps\n", fsr.frameRate)
-	fmt.Printf("   总帧数: %d\n", fsr.totalFrames)
-
-	start := time.Now()
-
-	for i := 0; i < fsr.totalFrames; i++ {
-		if err := fsr.RenderFrame(scn, i); err != nil {
-			return fmt.Errorf("渲染第 %d 帧失败: %v", i, err)
-		}
-
-		// 显示进度
-		if i%10 == 0 || i == fsr.totalFrames-1 {
-			progress := float64(i+1) / float64(fsr.totalFrames) * 100
-			fmt.Printf("   进度: %.1f%% (%d/%d)\n", progress, i+1, fsr.totalFrames)
-		}
+	// 写出帧图像
+	if err := fsr.sink.WriteFrame(frameIndex, img); err != nil {
+		return err
 	}
 
-	elapsed := time.Since(start)
-	fmt.Printf("✅ 序列帧渲染完成！耗时: %v\n", elapsed)
-
-	// 生成FFmpeg命令提示
-	fsr.generateFFmpegCommand()
-
+	if fsr.progress != nil {
+		fsr.progress(frameIndex+1, fsr.totalFrames)
+	}
 	return nil
 }
 
@@ -110,47 +123,6 @@ func (fsr *FrameSequenceRenderer) renderSceneToImage(scn *scene.Scene) image.Ima
 	return tempRenderer.GetImage()
 }
 
-// saveImage 保存图像到文件
-func (fsr *FrameSequenceRenderer) saveImage(img image.Image, filepath string) error {
-	file, err := os.Create(filepath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return png.Encode(file, img)
-}
-
-// generateFFmpegCommand 生成FFmpeg转换命令
-func (fsr *FrameSequenceRenderer) generateFFmpegCommand() {
-	fmt.Printf("\n🎥 使用FFmpeg生成视频:\n")
-
-	// 生成MP4命令
-	mp4Command := fmt.Sprintf(
-		"ffmpeg -framerate %d -i \"%s/frame_%%06d.png\" -c:v libx264 -pix_fmt yuv420p output.mp4",
-		fsr.frameRate, fsr.outputDir)
-
-	// 生成GIF命令
-	gifCommand := fmt.Sprintf(
-		"ffmpeg -framerate %d -i \"%s/frame_%%06d.png\" -vf \"palettegen\" palette.png && ffmpeg -framerate %d -i \"%s/frame_%%06d.png\" -i palette.png -lavfi \"paletteuse\" output.gif",
-		fsr.frameRate, fsr.outputDir, fsr.frameRate, fsr.outputDir)
-
-	fmt.Printf("\n📹 生成MP4视频:\n%s\n", mp4Command)
-	fmt.Printf("\n🎞️ 生成GIF动画:\n%s\n", gifCommand)
-
-	// 保存命令到文件
-	cmdFile, err := os.Create(filepath.Join(fsr.outputDir, "generate_video.bat"))
-	if err == nil {
-		defer cmdFile.Close()
-		cmdFile.WriteString("@echo off\n")
-		cmdFile.WriteString("echo 正在生成视频...\n")
-		cmdFile.WriteString(mp4Command + "\n")
-		cmdFile.WriteString("echo 视频生成完成: output.mp4\n")
-		cmdFile.WriteString("pause\n")
-		fmt.Printf("\n💾 批处理文件已保存: %s/generate_video.bat\n", fsr.outputDir)
-	}
-}
-
 // GetFrameCount 获取总帧数
 func (fsr *FrameSequenceRenderer) GetFrameCount() int {
 	return fsr.totalFrames
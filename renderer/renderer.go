@@ -1,7 +1,6 @@
 package renderer
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"image/png"
@@ -11,10 +10,14 @@ import (
 	"render2go/core"
 	"render2go/geometry"
 	_ "render2go/interfaces" // 使用 _ 导入接口包
+	"render2go/internal/defaults"
+	"render2go/internal/i18n"
 	gmMath "render2go/math"
 	"strings"
 
 	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
 )
 
 // CanvasRenderer 基于gg库的画布渲染器
@@ -24,19 +27,17 @@ type CanvasRenderer struct {
 	height              int
 	coordinateSystem    *gmMath.CoordinateSystem
 	autoSaveProjectName string
-	fontLoaded          bool    // 字体是否已加载
-	lastFontSize        float64 // 上次加载的字体大小
+	outputRoot          string // 自动保存的输出根目录
 }
 
-// NewCanvasRenderer 创建新的画布渲染器
+// NewCanvasRenderer 创建新的画布渲染器，自动保存的输出根目录使用内置默认值
 func NewCanvasRenderer(width, height int) *CanvasRenderer {
 	return &CanvasRenderer{
 		context:          gg.NewContext(width, height),
 		width:            width,
 		height:           height,
 		coordinateSystem: gmMath.NewCoordinateSystem(width, height),
-		fontLoaded:       false,
-		lastFontSize:     0,
+		outputRoot:       defaults.BuiltinRuntime().OutputRoot,
 	}
 }
 
@@ -83,6 +84,11 @@ func (r *CanvasRenderer) SetAutoSaveProjectName(projectName string) {
 	r.autoSaveProjectName = projectName
 }
 
+// SetOutputRoot 设置自动保存的输出根目录，默认使用内置的 output
+func (r *CanvasRenderer) SetOutputRoot(root string) {
+	r.outputRoot = root
+}
+
 // Clear 清空画布
 func (r *CanvasRenderer) Clear(red, green, blue float64) {
 	r.context.SetRGB(red, green, blue)
@@ -119,6 +125,10 @@ func (r *CanvasRenderer) Render(object core.Mobject) {
 	switch obj := object.(type) {
 	case *geometry.Text:
 		r.renderText(obj)
+	case *geometry.MathTex:
+		r.renderMathTex(obj)
+	case *geometry.Markdown:
+		r.renderMarkdown(obj)
 	case *geometry.Circle:
 		r.renderCircle(obj)
 	case *geometry.Triangle:
@@ -131,6 +141,28 @@ func (r *CanvasRenderer) Render(object core.Mobject) {
 		r.renderArrow(obj)
 	case *geometry.Polygon:
 		r.renderPolygon(obj)
+	case *geometry.Path:
+		r.renderBezierPath(obj, 1.0)
+	case *geometry.FunctionGraph:
+		r.renderBezierPath(obj.Path, obj.GetFillOpacity())
+	case *geometry.ParametricCurve:
+		r.renderBezierPath(obj.Path, obj.GetFillOpacity())
+	case *geometry.SVG:
+		r.renderPathGroup(obj.PathGroup)
+	case *geometry.ContourPlot:
+		r.renderPathGroup(obj.PathGroup)
+	case *geometry.VectorField:
+		r.renderVectorField(obj)
+	case *geometry.StreamLines:
+		r.renderStreamLines(obj)
+	case *geometry.Area:
+		r.renderBezierPath(obj.Path, 1.0)
+	case *geometry.RiemannSum:
+		r.renderPathGroup(obj.PathGroup)
+	case *geometry.GraphTracker:
+		r.renderGraphTracker(obj)
+	case *geometry.Image:
+		r.renderImage(obj)
 	case *geometry.CoordinateSystem:
 		r.renderCoordinateSystem(obj)
 	default:
@@ -146,29 +178,86 @@ func (r *CanvasRenderer) renderText(text *geometry.Text) {
 		return // 空文本不渲染
 	}
 
-	// 获取原始字体大小
-	fontSize := text.GetSize()
-	if fontSize <= 0 {
-		fontSize = 12 // 默认字体大小
+	// 排版结果中的字形由全局字体注册表缓存，所有渲染器共享
+	layout, topLeft := text.Layout()
+	if layout.Face == nil {
+		return
 	}
+	origin := r.coordinateSystem.ToScreen(topLeft)
 
-	// 简化字体大小计算 - 直接使用原始大小，不进行复杂缩放
-	// 只做基本的范围限制
-	scaledFontSize := fontSize
-	if scaledFontSize < 8 {
-		scaledFontSize = 8 // 最小字体大小
-	} else if scaledFontSize > 36 {
-		scaledFontSize = 36 // 降低最大字体大小
+	r.setTextColor(text)
+
+	// 旋转的文本绕锚点旋转，屏幕坐标的 y 轴向下，角度取反
+	if rotation := text.GetRotation(); rotation != 0 {
+		pivot := r.coordinateSystem.ToScreen(text.GetPosition())
+		r.context.Push()
+		defer r.context.Pop()
+		r.context.RotateAbout(-rotation, pivot.X, pivot.Y)
 	}
 
-	// 获取文本中心位置并转换到屏幕坐标
-	center := text.GetCenter()
-	screenPos := r.coordinateSystem.ToScreen(center)
+	// 逐行绘制，位置与 Text 的边界框保持一致
+	r.context.SetFontFace(layout.Face)
+	for _, line := range layout.Lines {
+		if len(line.Runs) > 0 {
+			r.renderTextRuns(text, layout, line, origin)
+			continue
+		}
+		r.context.DrawString(line.Text, origin.X+line.X, origin.Y+line.Baseline)
+	}
+}
+
+// renderTextRuns 渲染富文本的一行：依次绘制高亮背景、文字和下划线
+func (r *CanvasRenderer) renderTextRuns(text *geometry.Text, layout geometry.TextLayout, line geometry.TextLine, origin gmMath.Vector2) {
+	metrics := layout.Face.Metrics()
+	ascent := float64(metrics.Ascent) / 64
+	descent := float64(metrics.Descent) / 64
+	baseline := origin.Y + line.Baseline
+	size := text.RenderSize()
+
+	for _, run := range line.Runs {
+		span, _ := text.Span(run.Span)
+		x := origin.X + line.X + run.X
+
+		if span.Highlight != nil {
+			pad := 0.1 * size
+			r.context.DrawRectangle(x-pad/2, baseline-ascent-pad/2, run.Width+pad, ascent+descent+pad)
+			r.setSpanColor(text, span.Highlight)
+			r.context.Fill()
+		}
+
+		r.setTextColor(text)
+		if span.Color != nil {
+			r.setSpanColor(text, span.Color)
+		}
+		r.context.SetFontFace(run.Face)
+		r.context.DrawString(run.Text, x, baseline)
+
+		if span.Underline {
+			thickness := math.Max(size/16, 1)
+			r.context.DrawRectangle(x, baseline+descent/3, run.Width, thickness)
+			r.context.Fill()
+		}
+	}
+}
+
+// setSpanColor 设置富文本片段的颜色，透明度随所属文本变化以便淡入淡出
+func (r *CanvasRenderer) setSpanColor(text *geometry.Text, c color.Color) {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	opacity := text.GetFillOpacity()
+	if opacity <= 0 {
+		opacity = 1.0
+	}
+	if textColor, ok := text.GetColor().(color.RGBA); ok {
+		opacity *= float64(textColor.A) / 255.0
+	}
+	r.context.SetRGBA(float64(rgba.R)/255.0, float64(rgba.G)/255.0, float64(rgba.B)/255.0, float64(rgba.A)/255.0*opacity)
+}
 
-	// 设置文本颜色和透明度
-	if c, ok := text.GetColor().(color.RGBA); ok {
+// setTextColor 设置文本类对象的颜色和透明度
+func (r *CanvasRenderer) setTextColor(obj core.Mobject) {
+	if c, ok := obj.GetColor().(color.RGBA); ok {
 		// 对于文本，如果fillOpacity为0，默认设为1.0（完全不透明）
-		opacity := text.GetFillOpacity()
+		opacity := obj.GetFillOpacity()
 		if opacity <= 0 {
 			opacity = 1.0
 		}
@@ -178,23 +267,74 @@ func (r *CanvasRenderer) renderText(text *geometry.Text) {
 		// 如果颜色设置有问题，使用黑色作为默认颜色
 		r.context.SetRGBA(0, 0, 0, 1.0)
 	}
+}
+
+// renderMathTex 渲染公式：逐个绘制字符、分数线和根号等线条
+func (r *CanvasRenderer) renderMathTex(m *geometry.MathTex) {
+	box := m.Box()
+	origin := m.Origin()
+	r.setTextColor(m)
+
+	toScreen := func(x, y float64) gmMath.Vector2 {
+		return r.coordinateSystem.ToScreen(gmMath.Vector2{X: origin.X + x, Y: origin.Y + y})
+	}
 
-	// 尝试加载中文字体，使用缓存机制避免重复加载
-	if !r.fontLoaded || math.Abs(r.lastFontSize-scaledFontSize) > 1.0 {
-		err := r.loadChineseFont(scaledFontSize)
+	for _, g := range box.Glyphs {
+		face, err := g.Face()
 		if err != nil {
-			// 如果加载中文字体失败，使用gg的默认字体
-			if err := r.context.LoadFontFace("", scaledFontSize); err != nil {
-				// 如果连默认字体都加载失败，设置一个基本字体
-				r.context.SetFontFace(nil) // 使用内置字体
+			continue
+		}
+		r.context.SetFontFace(face)
+		p := toScreen(g.X, g.Y)
+		r.context.DrawString(string(g.Rune), p.X, p.Y)
+	}
+
+	for _, rule := range box.Rules {
+		p := toScreen(rule.X, rule.Y+rule.Height) // 屏幕坐标以左上角为起点
+		r.context.DrawRectangle(p.X, p.Y, rule.Width*r.coordinateSystem.Scale, rule.Height*r.coordinateSystem.Scale)
+		r.context.Fill()
+	}
+
+	for _, stroke := range box.Strokes {
+		for i, pt := range stroke.Points {
+			p := toScreen(pt.X, pt.Y)
+			if i == 0 {
+				r.context.MoveTo(p.X, p.Y)
+			} else {
+				r.context.LineTo(p.X, p.Y)
 			}
 		}
-		r.fontLoaded = true
-		r.lastFontSize = scaledFontSize
+		r.context.SetLineWidth(stroke.Width * r.coordinateSystem.Scale)
+		r.context.Stroke()
+	}
+}
+
+// renderMarkdown 渲染 Markdown 文本：先绘制行内代码底色，再逐段绘制文字
+func (r *CanvasRenderer) renderMarkdown(md *geometry.Markdown) {
+	layout, topLeft := md.Layout()
+	origin := r.coordinateSystem.ToScreen(topLeft)
+
+	// 代码底色使用文本颜色的淡色，在深色和浅色主题下都能看清
+	cr, cg, cb, _ := md.GetColor().RGBA()
+	for _, run := range layout.Runs {
+		if !run.Code {
+			continue
+		}
+		pad := 0.15 * run.Ascent
+		r.context.DrawRectangle(origin.X+run.X-pad, origin.Y+run.Baseline-run.Ascent-pad/2,
+			run.Width+2*pad, run.Ascent+run.Descent+pad)
+		r.context.SetRGBA(float64(cr)/0xffff, float64(cg)/0xffff, float64(cb)/0xffff, 0.12)
+		r.context.Fill()
 	}
 
-	// 渲染文本，居中对齐
-	r.context.DrawStringAnchored(textContent, screenPos.X, screenPos.Y, 0.5, 0.5)
+	r.setTextColor(md)
+	for _, run := range layout.Runs {
+		if run.Face == nil {
+			continue
+		}
+		r.context.SetFontFace(run.Face)
+		r.context.DrawString(run.Text, origin.X+run.X, origin.Y+run.Baseline)
+	}
 }
 
 // renderCircle 渲染圆形
@@ -340,6 +480,9 @@ func (r *CanvasRenderer) renderCoordinateSystem(cs *geometry.CoordinateSystem) {
 	for _, gridLine := range cs.GetGridLines() {
 		r.Render(gridLine)
 	}
+	for _, curve := range cs.GetGridCurves() {
+		r.Render(curve)
+	}
 
 	// 渲染坐标轴
 	if xAxis := cs.GetXAxis(); xAxis != nil {
@@ -402,11 +545,131 @@ func (r *CanvasRenderer) renderPath(points []gmMath.Vector2, closed bool, filled
 	}
 }
 
+// renderImage 把图像（或其裁剪区域）仿射映射到对象的四个角上，
+// 因此缩放、旋转后的图像也能正确绘制；透明度作为整体遮罩
+func (r *CanvasRenderer) renderImage(img *geometry.Image) {
+	data := img.GetImageData()
+	source := img.Source()
+	dst, ok := r.context.Image().(draw.Image)
+	if data == nil || source.Empty() || !ok {
+		return
+	}
+	opacity := math.Max(0, math.Min(1, img.GetFillOpacity()))
+	if opacity == 0 {
+		return
+	}
+
+	topLeft, topRight, bottomLeft := img.Corners()
+	tl := r.coordinateSystem.ToScreen(topLeft)
+	u := r.coordinateSystem.ToScreen(topRight).Sub(tl).Scale(1 / float64(source.Dx()))
+	v := r.coordinateSystem.ToScreen(bottomLeft).Sub(tl).Scale(1 / float64(source.Dy()))
+	sx, sy := float64(source.Min.X), float64(source.Min.Y)
+	s2d := f64.Aff3{
+		u.X, v.X, tl.X - u.X*sx - v.X*sy,
+		u.Y, v.Y, tl.Y - u.Y*sx - v.Y*sy,
+	}
+
+	var interpolator draw.Interpolator = draw.BiLinear
+	if img.Filter() == geometry.FilterNearest {
+		interpolator = draw.NearestNeighbor
+	}
+	var options *draw.Options
+	if opacity < 1 {
+		options = &draw.Options{SrcMask: image.NewUniform(color.Alpha{A: uint8(opacity*255 + 0.5)})}
+	}
+	interpolator.Transform(dst, s2d, data, source, draw.Over, options)
+}
+
+// renderPathGroup 依次渲染路径组中的各条路径，整体透明度与各路径自身的透明度相乘
+func (r *CanvasRenderer) renderPathGroup(group *geometry.PathGroup) {
+	for _, part := range group.Parts() {
+		r.renderBezierPath(part, group.GetFillOpacity())
+	}
+}
+
+// renderVectorField 渲染向量场的箭头，流动时再画出粒子的拖尾
+func (r *CanvasRenderer) renderVectorField(field *geometry.VectorField) {
+	for _, arrow := range field.Arrows() {
+		r.Render(arrow)
+	}
+	for _, trail := range field.Trails() {
+		r.renderBezierPath(trail, field.GetFillOpacity())
+	}
+}
+
+// renderStreamLines 渲染流线，流动时流线变暗并画出粒子的拖尾
+func (r *CanvasRenderer) renderStreamLines(lines *geometry.StreamLines) {
+	for _, part := range lines.Parts() {
+		r.renderBezierPath(part, lines.LineOpacity())
+	}
+	for _, trail := range lines.Trails() {
+		r.renderBezierPath(trail, lines.GetFillOpacity())
+	}
+}
+
+// renderGraphTracker 渲染函数图像上的跟踪对象：直线和圆点，再画出标签
+func (r *CanvasRenderer) renderGraphTracker(tracker *geometry.GraphTracker) {
+	r.renderPathGroup(tracker.PathGroup)
+	if label := tracker.Label(); label != nil {
+		r.Render(label)
+	}
+}
+
+// renderBezierPath 渲染路径对象：曲线命令直接交给 gg 绘制，不做折线近似。
+// 填充透明度大于 0 时按路径的填充规则填充，描边宽度大于 0 时再描边；opacity 为整体透明度。
+func (r *CanvasRenderer) renderBezierPath(path *geometry.Path, opacity float64) {
+	if path.IsEmpty() {
+		return
+	}
+
+	for _, seg := range path.Segments() {
+		pts := make([]gmMath.Vector2, len(seg.Points))
+		for i, pt := range seg.Points {
+			pts[i] = r.coordinateSystem.ToScreen(pt)
+		}
+		switch seg.Command {
+		case geometry.PathMove:
+			r.context.MoveTo(pts[0].X, pts[0].Y)
+		case geometry.PathLine:
+			r.context.LineTo(pts[0].X, pts[0].Y)
+		case geometry.PathQuad:
+			r.context.QuadraticTo(pts[0].X, pts[0].Y, pts[1].X, pts[1].Y)
+		case geometry.PathCubic:
+			r.context.CubicTo(pts[0].X, pts[0].Y, pts[1].X, pts[1].Y, pts[2].X, pts[2].Y)
+		case geometry.PathClose:
+			r.context.ClosePath()
+		}
+	}
+
+	stroke := path.GetStrokeWidth() > 0
+	if fillOpacity := path.GetFillOpacity() * opacity; fillOpacity > 0 {
+		c := color.RGBAModel.Convert(path.GetColor()).(color.RGBA)
+		if path.EvenOdd() {
+			r.context.SetFillRuleEvenOdd()
+		} else {
+			r.context.SetFillRuleWinding()
+		}
+		r.context.SetRGBA(float64(c.R)/255.0, float64(c.G)/255.0, float64(c.B)/255.0, float64(c.A)/255.0*fillOpacity)
+		if stroke {
+			r.context.FillPreserve()
+		} else {
+			r.context.Fill()
+		}
+	}
+	if stroke {
+		c := color.RGBAModel.Convert(path.GetStrokeColor()).(color.RGBA)
+		r.context.SetRGBA(float64(c.R)/255.0, float64(c.G)/255.0, float64(c.B)/255.0, float64(c.A)/255.0*opacity)
+		r.context.SetLineWidth(path.GetStrokeWidth() * r.coordinateSystem.Scale)
+		r.context.Stroke()
+	}
+	r.context.ClearPath()
+}
+
 // Present 呈现画面（自动保存到项目目录）
 func (r *CanvasRenderer) Present() {
 	// 如果设置了项目名称，自动保存当前帧
 	if r.autoSaveProjectName != "" {
-		outputDir := fmt.Sprintf("output/%s/frames", r.autoSaveProjectName)
+		outputDir := filepath.Join(r.outputRoot, r.autoSaveProjectName, "frames")
 		os.MkdirAll(outputDir, 0755)
 		filename := filepath.Join(outputDir, r.autoSaveProjectName+".png")
 		r.SaveFrame(filename)
@@ -424,18 +687,18 @@ func (r *CanvasRenderer) SaveFrame(filename string) error {
 	dir := filepath.Dir(filename)
 	if dir != "." && dir != "" {
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("创建保存目录失败 '%s': %v", dir, err)
+			return i18n.Errorf("io.mkdir_failed", dir, err)
 		}
 	}
 
 	file, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("创建输出文件失败 '%s': %v", filename, err)
+		return i18n.Errorf("io.create_file_failed", filename, err)
 	}
 	defer file.Close()
 
 	if err := png.Encode(file, r.context.Image()); err != nil {
-		return fmt.Errorf("PNG编码失败: %v", err)
+		return i18n.Errorf("io.png_encode_failed", err)
 	}
 
 	return nil
@@ -445,45 +708,3 @@ func (r *CanvasRenderer) SaveFrame(filename string) error {
 func (r *CanvasRenderer) GetImage() image.Image {
 	return r.context.Image()
 }
-
-// loadChineseFont 尝试加载系统中文字体
-func (r *CanvasRenderer) loadChineseFont(fontSize float64) error {
-	// Windows系统中文字体路径
-	var fontPaths []string
-
-	// 检测操作系统并设置相应的字体路径
-	switch {
-	case strings.Contains(os.Getenv("OS"), "Windows"): // Windows
-		fontPaths = []string{
-			"C:/Windows/Fonts/msyh.ttc",    // 微软雅黑
-			"C:/Windows/Fonts/msyhbd.ttc",  // 微软雅黑 Bold
-			"C:/Windows/Fonts/simhei.ttf",  // 黑体
-			"C:/Windows/Fonts/simsun.ttc",  // 宋体
-			"C:/Windows/Fonts/arial.ttf",   // Arial (英文后备)
-			"C:/Windows/Fonts/calibri.ttf", // Calibri (英文后备)
-		}
-	default: // Linux/Unix
-		fontPaths = []string{
-			"/usr/share/fonts/truetype/wqy/wqy-microhei.ttc",
-			"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
-			"/System/Library/Fonts/PingFang.ttc", // macOS
-			"/System/Library/Fonts/Arial.ttf",    // macOS英文后备
-		}
-	}
-
-	// 尝试加载第一个可用的字体
-	for _, fontPath := range fontPaths {
-		if _, err := os.Stat(fontPath); os.IsNotExist(err) {
-			continue
-		}
-
-		// 使用gg库的LoadFontFace方法加载字体
-		err := r.context.LoadFontFace(fontPath, fontSize)
-		if err == nil {
-			return nil // 成功加载字体
-		}
-	}
-
-	// 如果没有找到系统字体，返回错误让调用者处理
-	return fmt.Errorf("no suitable font found")
-}
@@ -0,0 +1,54 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"render2go/internal/i18n"
+)
+
+// FrameSink 帧输出目标，序列帧渲染器通过它写出每一帧
+type FrameSink interface {
+	WriteFrame(index int, img image.Image) error
+}
+
+// FrameSinkFunc 将普通函数适配为 FrameSink
+type FrameSinkFunc func(index int, img image.Image) error
+
+// WriteFrame 调用函数本身
+func (f FrameSinkFunc) WriteFrame(index int, img image.Image) error {
+	return f(index, img)
+}
+
+// DirSink 将帧保存为目录中的 PNG 文件（frame_000000.png 命名）
+type DirSink struct {
+	dir string
+}
+
+// NewDirSink 创建目录输出目标
+func NewDirSink(dir string) *DirSink {
+	return &DirSink{dir: dir}
+}
+
+// Dir 获取输出目录
+func (s *DirSink) Dir() string {
+	return s.dir
+}
+
+// WriteFrame 将帧编码为 PNG 写入目录
+func (s *DirSink) WriteFrame(index int, img image.Image) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return i18n.Errorf("io.mkdir_failed", s.dir, err)
+	}
+
+	filename := filepath.Join(s.dir, fmt.Sprintf("frame_%06d.png", index))
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
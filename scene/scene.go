@@ -4,6 +4,7 @@ import (
 	"render2go/animation"
 	"render2go/core"
 	"render2go/interfaces"
+	"render2go/internal/defaults"
 	gmMath "render2go/math"
 	"strings"
 	"time"
@@ -16,31 +17,38 @@ type Scene struct {
 	width            int
 	height           int
 	background       [3]float64 // RGB background color
+	frameRate        int        // PlayAnimation 的帧率
 	coordinateSystem *gmMath.CoordinateSystem
 }
 
-// NewScene 创建新场景，默认1920*1080分辨率
+// NewScene 创建新场景，未指定分辨率时使用内置的默认分辨率1920*1080，
+// 背景色和帧率同样使用内置默认值，可由 SetBackground、SetFrameRate 覆盖
 func NewScene(width, height int) *Scene {
-	// 如果没有指定分辨率，使用默认的1920*1080
+	rt := defaults.BuiltinRuntime()
 	if width <= 0 {
-		width = 1920
+		width = rt.Width
 	}
 	if height <= 0 {
-		height = 1080
+		height = rt.Height
 	}
 
 	return &Scene{
-		objects:          make([]core.Mobject, 0),
-		width:            width,
-		height:           height,
-		background:       [3]float64{1.0, 1.0, 1.0}, // 白色背景
+		objects:    make([]core.Mobject, 0),
+		width:      width,
+		height:     height,
+		background: [3]float64{ // 内置的背景色为白色
+			float64(rt.Background.R) / 255.0,
+			float64(rt.Background.G) / 255.0,
+			float64(rt.Background.B) / 255.0,
+		},
+		frameRate:        rt.FPS,
 		coordinateSystem: gmMath.NewCoordinateSystem(width, height),
 	}
 }
 
-// NewDefaultScene 创建默认1920*1080场景
+// NewDefaultScene 创建默认分辨率的场景
 func NewDefaultScene() *Scene {
-	return NewScene(1920, 1080)
+	return NewScene(0, 0)
 }
 
 // GetCoordinateSystem 获取坐标系统
@@ -92,8 +100,8 @@ func (s *Scene) Clear() {
 func (s *Scene) PlayAnimation(anim animation.Animation) {
 	anim.Reset()
 
-	// 计算动画步数 (使用60fps以获得更流畅的效果)
-	fps := 60.0
+	// 计算动画步数（默认使用配置的帧率）
+	fps := float64(s.frameRate)
 	duration := anim.GetDuration()
 	totalFrames := int(duration.Seconds() * fps)
 
@@ -163,6 +171,13 @@ func (s *Scene) SetBackground(r, g, b float64) {
 	s.background = [3]float64{r, g, b}
 }
 
+// SetFrameRate 设置 PlayAnimation 播放动画的帧率
+func (s *Scene) SetFrameRate(fps int) {
+	if fps > 0 {
+		s.frameRate = fps
+	}
+}
+
 // GetObjects 获取场景中的所有对象
 func (s *Scene) GetObjects() []core.Mobject {
 	return s.objects